@@ -32,6 +32,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "performance",
+				Aliases:   []string{"p"},
+				Usage:     "Get the attestation and proposal performance of the node's minipool validators",
+				UsageText: "rocketpool minipool performance",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPerformance(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -0,0 +1,87 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func getPerformance(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get minipool performance
+	performance, err := rp.MinipoolPerformance()
+	if err != nil {
+		return err
+	}
+
+	// Check tracking status
+	if len(performance.Minipools) == 0 {
+		fmt.Println("The node does not have any minipools yet.")
+		return nil
+	}
+	if !performance.Tracking {
+		fmt.Println("Validator performance has not been recorded yet. The node daemon checks it once validators are active on the Beacon Chain.")
+		return nil
+	}
+	fmt.Printf("Validator performance from epoch %d to epoch %d:\n", performance.FirstEpoch, performance.LastEpoch)
+	fmt.Println("")
+
+	// Minipools
+	for _, minipool := range performance.Minipools {
+		printMinipoolPerformance(minipool)
+	}
+
+	// Return
+	return nil
+
+}
+
+func printMinipoolPerformance(minipool api.MinipoolPerformanceDetails) {
+
+	fmt.Printf("--------------------\n")
+	fmt.Printf("\n")
+
+	// Validator details
+	fmt.Printf("Address:              %s\n", minipool.Address.Hex())
+	fmt.Printf("Validator pubkey:     %s\n", hex.AddPrefix(minipool.ValidatorPubkey.Hex()))
+	if !minipool.ValidatorExists {
+		fmt.Printf("Validator seen:       no\n")
+		fmt.Printf("\n")
+		return
+	}
+	fmt.Printf("Validator index:      %d\n", minipool.ValidatorIndex)
+	if !minipool.Tracked {
+		fmt.Printf("Performance tracked:  no\n")
+		fmt.Printf("\n")
+		return
+	}
+
+	// Attestation details
+	fmt.Printf("Attestations:         %d of %d\n", minipool.AttestationsExpected-minipool.AttestationsMissed, minipool.AttestationsExpected)
+	if minipool.AttestationsMissed > 0 {
+		fmt.Printf("%sMissed attestations:  %d%s\n", colorYellow, minipool.AttestationsMissed, colorReset)
+	} else {
+		fmt.Printf("Missed attestations:  0\n")
+	}
+	fmt.Printf("Inclusion distance:   %.2f slots (average)\n", minipool.AverageInclusionDistance)
+
+	// Proposal details
+	fmt.Printf("Proposals:            %d of %d\n", minipool.ProposalsExpected-minipool.ProposalsMissed, minipool.ProposalsExpected)
+	if minipool.ProposalsMissed > 0 {
+		fmt.Printf("%sMissed proposals:     %d%s\n", colorYellow, minipool.ProposalsMissed, colorReset)
+	}
+
+	fmt.Printf("\n")
+
+}
@@ -85,7 +85,22 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "rewards",
 				Aliases:   []string{"e"},
 				Usage:     "Get the time and your expected RPL rewards of the next checkpoint",
-				UsageText: "rocketpool node rewards",
+				UsageText: "rocketpool node rewards [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "history",
+						Usage: "Show the Beacon Chain yield of each minipool over time instead",
+					},
+					cli.StringFlag{
+						Name:  "period",
+						Usage: "The period to group the history by ('daily' or 'weekly')",
+						Value: "daily",
+					},
+					cli.BoolFlag{
+						Name:  "csv",
+						Usage: "Print the history as CSV",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -93,7 +108,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						return err
 					}
 
+					// Validate flags
+					if _, err := cliutils.ValidateRewardsPeriod("period", c.String("period")); err != nil {
+						return err
+					}
+
 					// Run
+					if c.Bool("history") {
+						return getRewardsHistory(c)
+					}
 					return getRewards(c)
 
 				},
@@ -403,6 +426,50 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "pending-transactions",
+				Usage:     "List the node's pending transactions which are being managed",
+				UsageText: "rocketpool node pending-transactions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPendingTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "cancel-transaction",
+				Usage:     "Cancel a pending transaction by replacing it with a zero-value transfer to the node at higher fees",
+				UsageText: "rocketpool node cancel-transaction [options] tx-hash",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the cancellation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return cancelTransaction(c, hash)
+
+				},
+			},
 		},
 	})
 }
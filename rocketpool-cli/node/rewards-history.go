@@ -0,0 +1,93 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Date format for history periods
+const HistoryDateFormat = "2006-01-02"
+
+func getRewardsHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get node rewards history
+	history, err := rp.NodeRewardsHistory(c.String("period"))
+	if err != nil {
+		return err
+	}
+
+	// Print as CSV
+	if c.Bool("csv") {
+		return printRewardsHistoryCsv(history)
+	}
+
+	// Check history
+	if len(history.Minipools) == 0 {
+		fmt.Println("The node does not have any active minipool validators yet.")
+		return nil
+	}
+
+	// Print the history of each minipool
+	for _, minipool := range history.Minipools {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Minipool %s (validator %d):\n", minipool.Address.Hex(), minipool.ValidatorIndex)
+		if len(minipool.Periods) == 0 {
+			fmt.Printf("No balance history has been recorded yet.\n")
+			fmt.Printf("\n")
+			continue
+		}
+		for _, period := range minipool.Periods {
+			yield := float64(0)
+			if period.StartBalance > 0 {
+				yield = period.Earned / period.StartBalance * 100
+			}
+			fmt.Printf("%s:  %.6f ETH earned (%.4f%%), balance %.6f ETH\n", period.Start.Format(HistoryDateFormat), period.Earned, yield, period.EndBalance)
+		}
+		fmt.Printf("\n")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Print the rewards history as CSV, with a row per minipool and period
+func printRewardsHistoryCsv(history api.NodeRewardsHistoryResponse) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write([]string{"minipool", "validator_index", "period_start", "start_epoch", "end_epoch", "start_balance_eth", "end_balance_eth", "earned_eth"}); err != nil {
+		return err
+	}
+	for _, minipool := range history.Minipools {
+		for _, period := range minipool.Periods {
+			if err := writer.Write([]string{
+				minipool.Address.Hex(),
+				strconv.FormatUint(minipool.ValidatorIndex, 10),
+				period.Start.Format(HistoryDateFormat),
+				strconv.FormatUint(period.StartEpoch, 10),
+				strconv.FormatUint(period.EndEpoch, 10),
+				strconv.FormatFloat(period.StartBalance, 'f', 9, 64),
+				strconv.FormatFloat(period.EndBalance, 'f', 9, 64),
+				strconv.FormatFloat(period.Earned, 'f', 9, 64),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -81,6 +81,7 @@ func nodeStakeRpl(c *cli.Context) error {
 				// Approve RPL for swapping
 				response, err := rp.NodeSwapRplApprove(maxApproval)
 				if err != nil {
+					rp.PrintMultiTxDryRunNote(err)
 					return err
 				}
 				hash := response.ApproveTxHash
@@ -125,6 +126,7 @@ func nodeStakeRpl(c *cli.Context) error {
 			// Swap RPL
 			swapResponse, err := rp.NodeSwapRpl(status.AccountBalances.FixedSupplyRPL)
 			if err != nil {
+				rp.PrintMultiTxDryRunNote(err)
 				return err
 			}
 
@@ -263,6 +265,7 @@ func nodeStakeRpl(c *cli.Context) error {
 		// Approve RPL for staking
 		response, err := rp.NodeStakeRplApprove(maxApproval)
 		if err != nil {
+			rp.PrintMultiTxDryRunNote(err)
 			return err
 		}
 		hash := response.ApproveTxHash
@@ -109,6 +109,7 @@ func nodeSwapRpl(c *cli.Context) error {
 		// Approve RPL for swapping
 		response, err := rp.NodeSwapRplApprove(maxApproval)
 		if err != nil {
+			rp.PrintMultiTxDryRunNote(err)
 			return err
 		}
 		hash := response.ApproveTxHash
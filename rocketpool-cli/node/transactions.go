@@ -0,0 +1,92 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Date format for pending transactions
+const TransactionTimeFormat = "2006-01-02 15:04:05 MST"
+
+func getPendingTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get pending transactions
+	response, err := rp.PendingTransactions()
+	if err != nil {
+		return err
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The node does not have any pending transactions.")
+		return nil
+	}
+
+	// Print transactions
+	for _, tx := range response.Transactions {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Transaction %s (nonce %d):\n", tx.Hash.Hex(), tx.Nonce)
+		if tx.Task != "" {
+			fmt.Printf("Submitted by the %s task at %s.\n", tx.Task, tx.SubmittedTime.Format(TransactionTimeFormat))
+		} else {
+			fmt.Printf("Submitted at %s.\n", tx.SubmittedTime.Format(TransactionTimeFormat))
+		}
+		if tx.To != nil {
+			fmt.Printf("Sent to %s.\n", tx.To.Hex())
+		}
+		fmt.Printf("Max fee %.6f Gwei, max priority fee %.6f Gwei.\n", eth.WeiToGwei(tx.MaxFeeWei), eth.WeiToGwei(tx.MaxPriorityFeeWei))
+		if tx.Replacements > 0 {
+			fmt.Printf("Replaced %d time(s) with higher fees; the original transaction was %s.\n", tx.Replacements, tx.OriginalHash.Hex())
+		}
+		if tx.Cancelled {
+			fmt.Printf("The transaction is being cancelled.\n")
+		}
+		if tx.MaxFeeReached {
+			fmt.Printf("The transaction's fees have reached the limit and will not be raised further.\n")
+		}
+		fmt.Printf("\n")
+	}
+	return nil
+
+}
+
+func cancelTransaction(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel transaction %s? This will replace it with a transfer of 0 ETH to the node at higher fees.", hash.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Cancel transaction
+	response, err := rp.CancelTransaction(hash)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Cancelling transaction %s...\n", hash.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	fmt.Println("The node daemon will raise the cancellation's fees if it gets stuck; use `rocketpool node pending-transactions` to follow its progress.")
+	return nil
+
+}
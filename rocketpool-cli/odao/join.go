@@ -79,6 +79,7 @@ func join(c *cli.Context) error {
 				// Approve RPL for swapping
 				response, err := rp.NodeSwapRplApprove(maxApproval)
 				if err != nil {
+					rp.PrintMultiTxDryRunNote(err)
 					return err
 				}
 				hash := response.ApproveTxHash
@@ -123,6 +124,7 @@ func join(c *cli.Context) error {
 			// Swap RPL
 			swapResponse, err := rp.NodeSwapRpl(status.AccountBalances.FixedSupplyRPL)
 			if err != nil {
+				rp.PrintMultiTxDryRunNote(err)
 				return err
 			}
 
@@ -179,6 +181,7 @@ func join(c *cli.Context) error {
 	// Approve RPL for joining the ODAO
 	response, err := rp.ApproveRPLToJoinTNDAO()
 	if err != nil {
+		rp.PrintMultiTxDryRunNote(err)
 		return err
 	}
 	hash := response.ApproveTxHash
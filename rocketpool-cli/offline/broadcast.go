@@ -0,0 +1,61 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func broadcastTransactions(c *cli.Context, signedPath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Read signed transactions
+	signedBytes, err := ioutil.ReadFile(signedPath)
+	if err != nil {
+		return fmt.Errorf("Could not read signed transactions from %s: %w", signedPath, err)
+	}
+	var signed api.SignedTransactionsFile
+	if err := json.Unmarshal(signedBytes, &signed); err != nil {
+		return fmt.Errorf("Could not decode signed transactions: %w", err)
+	}
+	if len(signed.Transactions) == 0 {
+		fmt.Printf("%s does not contain any transactions.\n", signedPath)
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to submit %d signed transaction(s)?", len(signed.Transactions)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit transactions in nonce order, waiting for each to be mined
+	for _, tx := range signed.Transactions {
+		response, err := rp.BroadcastTransaction(tx.RawTx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Submitted transaction from %s (nonce %d).\n", tx.From.Hex(), tx.Nonce)
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			return err
+		}
+	}
+
+	// Log & return
+	fmt.Printf("Successfully submitted %d transaction(s).\n", len(signed.Transactions))
+	return nil
+
+}
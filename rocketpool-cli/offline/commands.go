@@ -0,0 +1,61 @@
+package offline
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register sign command
+func RegisterSignCommand(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Sign node account transactions exported with --export-unsigned, on a machine with the node wallet",
+		UsageText: "rocketpool sign [options] unsigned-file signed-file",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "yes, y",
+				Usage: "Automatically confirm signing the transactions",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 2); err != nil {
+				return err
+			}
+
+			// Run
+			return signTransactions(c, c.Args().Get(0), c.Args().Get(1))
+
+		},
+	})
+}
+
+// Register broadcast command
+func RegisterBroadcastCommand(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Submit node account transactions signed with 'rocketpool sign'",
+		UsageText: "rocketpool broadcast [options] signed-file",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "yes, y",
+				Usage: "Automatically confirm submitting the transactions",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+
+			// Run
+			return broadcastTransactions(c, c.Args().Get(0))
+
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Config
+const SignedFileMode = 0644
+
+func signTransactions(c *cli.Context, unsignedPath string, signedPath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Read unsigned transactions
+	unsignedBytes, err := ioutil.ReadFile(unsignedPath)
+	if err != nil {
+		return fmt.Errorf("Could not read unsigned transactions from %s: %w", unsignedPath, err)
+	}
+	var unsigned api.UnsignedTransactionsFile
+	if err := json.Unmarshal(unsignedBytes, &unsigned); err != nil {
+		return fmt.Errorf("Could not decode unsigned transactions: %w", err)
+	}
+	if len(unsigned.Transactions) == 0 {
+		fmt.Printf("%s does not contain any transactions.\n", unsignedPath)
+		return nil
+	}
+
+	// Print transactions for review
+	for _, tx := range unsigned.Transactions {
+		fmt.Printf("Transaction from %s (nonce %d):\n", tx.From.Hex(), tx.Nonce)
+		if tx.To != nil {
+			fmt.Printf("To: %s\n", tx.To.Hex())
+		}
+		fmt.Printf("Value: %.6f ETH\n", eth.WeiToEth(tx.Value))
+		fmt.Printf("Data: %s\n", tx.Data.String())
+		fmt.Printf("Gas limit: %d\n", tx.GasLimit)
+		fmt.Printf("Max fee %.6f Gwei, max priority fee %.6f Gwei.\n\n", eth.WeiToGwei(tx.MaxFeeWei), eth.WeiToGwei(tx.MaxPriorityFeeWei))
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to sign %d transaction(s) with the node account?", len(unsigned.Transactions)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign transactions
+	response, err := rp.SignTransactions(unsigned)
+	if err != nil {
+		return err
+	}
+
+	// Write signed transactions
+	signedBytes, err := json.MarshalIndent(api.SignedTransactionsFile{Transactions: response.Transactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not encode signed transactions: %w", err)
+	}
+	if err := ioutil.WriteFile(signedPath, signedBytes, SignedFileMode); err != nil {
+		return fmt.Errorf("Could not write signed transactions to %s: %w", signedPath, err)
+	}
+
+	// Log & return
+	fmt.Printf("Wrote %d signed transaction(s) to %s.\n", len(response.Transactions), signedPath)
+	fmt.Println("Submit them from a machine connected to the network using `rocketpool broadcast`.")
+	return nil
+
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/offline"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
@@ -119,6 +121,18 @@ ______           _        _    ______           _
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.StringFlag{
+			Name:  "account",
+			Usage: "The name of the node account to use, as added with 'wallet add-account' (defaults to the default node account)",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Sign and simulate transactions against the pending block instead of submitting them, printing the signed transactions; operations with multiple transactions stop after the first",
+		},
+		cli.StringFlag{
+			Name:  "export-unsigned",
+			Usage: "Export node account transactions unsigned to this file instead of submitting them, to be signed on another machine with 'rocketpool sign'",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
@@ -179,6 +193,8 @@ ______           _        _    ______           _
 	queue.RegisterCommands(app, "queue", []string{"q"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
+	offline.RegisterSignCommand(app, "sign", []string{})
+	offline.RegisterBroadcastCommand(app, "broadcast", []string{})
 
 	app.Before = func(c *cli.Context) error {
 		// Check user ID
@@ -194,12 +210,18 @@ ______           _        _    ______           _
 			os.Exit(1)
 		}
 
+		// Check for conflicting flags
+		if c.Bool("dry-run") && c.String("export-unsigned") != "" {
+			fmt.Fprintln(os.Stderr, "The `--dry-run` and `--export-unsigned` flags can't be used together.")
+			os.Exit(1)
+		}
+
 		return nil
 	}
 
 	// Run application
 	fmt.Println("")
-	if err := app.Run(os.Args); err != nil {
+	if err := app.Run(os.Args); err != nil && !errors.Is(err, rocketpool.ErrDryRun) && !errors.Is(err, rocketpool.ErrUnsignedExport) {
 		cliutils.PrettyPrintError(err)
 	}
 	fmt.Println("")
@@ -121,6 +121,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "ignore-slash-timer",
 						Usage: "Bypass the safety timer that forces a delay when switching to a new ETH2 client",
 					},
+					cli.BoolFlag{
+						Name:  "ignore-slashing-protection",
+						Usage: "Start a new validator client without importing slashing protection history into it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -208,6 +209,15 @@ func startService(c *cli.Context) error {
 		}
 	}
 
+	// Check that the validator client can sign through the remote signer
+	cfg, err := rp.LoadMergedConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateRemoteSigner(); err != nil {
+		return err
+	}
+
 	if !c.Bool("ignore-slash-timer") {
 		// Do the client swap check
 		err := checkForValidatorChange(rp, userConfig)
@@ -226,6 +236,21 @@ func startService(c *cli.Context) error {
 		fmt.Printf("%sIgnoring anti-slashing safety delay.%s\n", colorYellow, colorReset)
 	}
 
+	// Refuse to switch validator clients without importing their slashing protection history
+	if !c.Bool("ignore-slashing-protection") {
+		imported, err := checkSlashingProtectionImported(rp, userConfig)
+		if err != nil {
+			return fmt.Errorf("Couldn't verify that slashing protection history was imported into the validator client: %w\n"+
+				"Rerun this command with the `--ignore-slashing-protection` flag if you have no active validators or understand the risks.", err)
+		} else if !imported {
+			return errors.New("You have changed your validator client, but haven't imported your slashing protection history into it.\n" +
+				"Run `rocketpool wallet export-slashing-protection` before switching and `rocketpool wallet import-slashing-protection` after, " +
+				"or rerun this command with the `--ignore-slashing-protection` flag if you have no active validators or understand the risks.")
+		}
+	} else {
+		fmt.Printf("%sIgnoring slashing protection history check.%s\n", colorYellow, colorReset)
+	}
+
 	// Start service
 	return rp.StartService(getComposeFiles(c))
 
@@ -329,6 +354,67 @@ func checkForValidatorChange(rp *rocketpool.Client, userConfig config.RocketPool
 	return nil
 }
 
+// Check whether slashing protection history was imported into a newly selected validator client
+func checkSlashingProtectionImported(rp *rocketpool.Client, userConfig config.RocketPoolConfig) (bool, error) {
+
+	// Keys are imported along with their history through the keymanager API once the client is running
+	cfg, err := rp.LoadMergedConfig()
+	if err != nil {
+		return false, fmt.Errorf("Error loading settings: %w", err)
+	}
+	if cfg.Smartnode.KeymanagerApi.Url != "" {
+		return true, nil
+	}
+
+	// Get the current and pending validator images; no history needs importing on the first start
+	prefix, err := getContainerPrefix(rp)
+	if err != nil {
+		return false, fmt.Errorf("Error getting validator container prefix: %w", err)
+	}
+	hasValidator, err := rp.HasDockerContainer(prefix + ValidatorContainerSuffix)
+	if err != nil {
+		return false, fmt.Errorf("Error checking for the validator container: %w", err)
+	}
+	if !hasValidator {
+		return true, nil
+	}
+	currentValidatorImageString, err := rp.GetDockerImage(prefix + ValidatorContainerSuffix)
+	if err != nil {
+		return false, fmt.Errorf("Error getting current validator image: %w", err)
+	}
+	newClient := cfg.Chains.Platform.GetClientById(userConfig.Chains.Platform.Client.Selected)
+	if newClient == nil {
+		return false, fmt.Errorf("Error getting selected client - either it does not exist (user has not run `rocketpool service config` yet) or the selected client is invalid.")
+	}
+	pendingValidatorImageString := newClient.GetValidatorImage()
+
+	// No history needs importing on the first start or if the client hasn't changed
+	currentValidatorName, err := getDockerImageName(currentValidatorImageString)
+	if err != nil {
+		return false, fmt.Errorf("Error getting current validator image name: %w", err)
+	}
+	pendingValidatorName, err := getDockerImageName(pendingValidatorImageString)
+	if err != nil {
+		return false, fmt.Errorf("Error getting pending validator image name: %w", err)
+	}
+	if currentValidatorName == "" || currentValidatorName == pendingValidatorName {
+		return true, nil
+	}
+
+	// History can't be imported into clients without an import command
+	if cfg.GetSlashingProtectionCommands(*newClient).ImportCommand == "" {
+		return false, fmt.Errorf("importing slashing protection history into %s is not supported", newClient.Name)
+	}
+
+	// Check the history was imported into the pending image
+	importedImage, err := rp.GetSlashingProtectionImport()
+	if err != nil {
+		return false, err
+	}
+	return importedImage == pendingValidatorImageString, nil
+
+}
+
 // Get the name of the container responsible for validator duties based on the client name
 // TODO: this is temporary and can change, clean it up when Nimbus supports split mode
 func getContainerNameForValidatorDuties(CurrentValidatorClientName string, rp *rocketpool.Client) (string, error) {
@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getNodeAccounts(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get node accounts
+	response, err := rp.NodeAccounts()
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	for _, account := range response.Accounts {
+		fmt.Printf("%s: %s\n", account.Name, account.Address.Hex())
+	}
+	return nil
+
+}
+
+func addNodeAccount(c *cli.Context, name string, index uint) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Add node account
+	response, err := rp.AddNodeAccount(name, index)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Added node account '%s' at index %d: %s\n", name, index, response.AccountAddress.Hex())
+	fmt.Printf("Use 'rocketpool --account %s ...' to run commands as this account.\n", name)
+	fmt.Println("Restart the node and watchtower daemons with 'rocketpool service start' to run their tasks for this account.")
+	return nil
+
+}
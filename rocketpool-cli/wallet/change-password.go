@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func changePassword(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Prompt for passwords
+	currentPassword := cliutils.PromptPassword("Please enter your current wallet password:", "^.*$", "")
+	newPassword := promptPassword()
+
+	// Change password
+	response, err := rp.ChangePassword(currentPassword, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	if len(response.UnchangedKeystores) > 0 {
+		fmt.Println("The node wallet password was successfully changed.")
+		fmt.Printf("These validator keystores don't support re-encryption and were left unchanged: %s.\n", strings.Join(response.UnchangedKeystores, ", "))
+	} else {
+		fmt.Println("The node wallet password was successfully changed and all validator keystores were re-encrypted.")
+	}
+	fmt.Println("Backups of the wallet and re-encrypted keystores, which held your previous password and secrets, were removed.")
+	fmt.Println("Copies of them kept elsewhere can still be decrypted with your previous password.")
+	return nil
+
+}
@@ -1,9 +1,13 @@
 package wallet
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 )
 
 // Register commands
@@ -32,6 +36,53 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "change-password",
+				Usage:     "Change the node wallet password and re-encrypt all validator keystores",
+				UsageText: "rocketpool wallet change-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return changePassword(c)
+
+				},
+			},
+
+			{
+				Name:      "unlock",
+				Usage:     "Unlock the node wallet when its password is not stored on disk",
+				UsageText: "rocketpool wallet unlock [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The wallet password",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return unlockWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
@@ -46,6 +97,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "confirm-mnemonic, c",
 						Usage: "Automatically confirm the mnemonic phrase",
 					},
+					cli.UintFlag{
+						Name:  "shares",
+						Usage: "Split the mnemonic phrase into this many shares instead of printing it",
+					},
+					cli.UintFlag{
+						Name:  "threshold",
+						Usage: "The number of shares required to recover the wallet (requires --shares)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -61,6 +120,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						}
 					}
 
+					if c.IsSet("shares") || c.IsSet("threshold") {
+						if err := shamir.ValidateThreshold(int(c.Uint("shares")), int(c.Uint("threshold"))); err != nil {
+							return err
+						}
+					}
+
 					// Run
 					return initWallet(c)
 
@@ -70,7 +135,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "recover",
 				Aliases:   []string{"r"},
-				Usage:     "Recover a node wallet from a mnemonic phrase",
+				Usage:     "Recover a node wallet from a mnemonic phrase or mnemonic shares",
 				UsageText: "rocketpool wallet recover [options]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
@@ -81,6 +146,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "mnemonic, m",
 						Usage: "The mnemonic phrase to recover the wallet from",
 					},
+					cli.StringSliceFlag{
+						Name:  "share, s",
+						Usage: "A mnemonic share to recover the wallet from; use once for each share",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -99,6 +168,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
 							return err
 						}
+						if len(c.StringSlice("share")) > 0 {
+							return errors.New("Only one of --mnemonic and --share can be used")
+						}
+					}
+					for _, share := range c.StringSlice("share") {
+						if _, err := cliutils.ValidateWalletMnemonicShare("mnemonic share", share); err != nil {
+							return err
+						}
 					}
 
 					// Run
@@ -125,6 +202,92 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "accounts",
+				Usage:     "List the node accounts in the node wallet",
+				UsageText: "rocketpool wallet accounts",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getNodeAccounts(c)
+
+				},
+			},
+
+			{
+				Name:      "add-account",
+				Usage:     "Add a named node account derived at an index of the node wallet",
+				UsageText: "rocketpool wallet add-account name index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					name, err := cliutils.ValidateNodeAccountName("account name", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					index, err := cliutils.ValidatePositiveUint("account index", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return addNodeAccount(c, name, uint(index))
+
+				},
+			},
+
+			{
+				Name:      "import-validator-keys",
+				Usage:     "Import EIP-2335 validator keystores created with other tools into the node wallet",
+				UsageText: "rocketpool wallet import-validator-keys [options] path...",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password-file, p",
+						Usage: "A file containing the keystore password, used for all keystores",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if len(c.Args()) == 0 {
+						return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+					}
+
+					// Run
+					return importValidatorKeys(c, c.Args())
+
+				},
+			},
+
+			{
+				Name:      "verify",
+				Usage:     "Verify the node wallet decrypts and all validating minipool keys are in the keystores",
+				UsageText: "rocketpool wallet verify",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -142,6 +305,82 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "delete-validator-key",
+				Usage:     "Delete a validator key from the validator client through the keymanager API and save its slashing protection data",
+				UsageText: "rocketpool wallet delete-validator-key [options] pubkey",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The `path` to write the EIP-3076 slashing protection data to (defaults to slashing-protection-<pubkey>.json)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the validator key",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return deleteValidatorKey(c, pubkey)
+
+				},
+			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export the validator client's slashing protection history in EIP-3076 format",
+				UsageText: "rocketpool wallet export-slashing-protection [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The `path` to write the slashing protection data to",
+						Value: "slashing-protection.json",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm removing validator keys when exporting through the keymanager API",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportSlashingProtection(c)
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import EIP-3076 slashing protection history into the selected validator client",
+				UsageText: "rocketpool wallet import-slashing-protection path",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return importSlashingProtection(c, c.Args().Get(0))
+
+				},
+			},
 		},
 	})
 }
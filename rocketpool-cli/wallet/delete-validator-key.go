@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func deleteValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Check the output path before the key is deleted
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("slashing-protection-%s.json", pubkey.Hex())
+	}
+	if err := checkOutputPath(outputPath); err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to delete validator key %s from the validator client? It will stop validating until the key is imported again.", pubkey.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Delete validator key
+	response, err := rp.DeleteValidatorKey(pubkey)
+	if err != nil {
+		return err
+	}
+
+	// Save slashing protection data
+	if err := ioutil.WriteFile(outputPath, []byte(response.SlashingProtection), 0600); err != nil {
+		return fmt.Errorf("Could not write slashing protection data to %s: %w", outputPath, err)
+	}
+
+	// Log & return
+	fmt.Printf("Validator key %s was deleted from the validator client.\n", pubkey.Hex())
+	fmt.Printf("Its slashing protection data was saved to %s.\n", outputPath)
+	return nil
+
+}
@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func importValidatorKeys(c *cli.Context, paths []string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get keystore files
+	keystorePaths, err := getKeystorePaths(paths)
+	if err != nil {
+		return err
+	}
+	if len(keystorePaths) == 0 {
+		fmt.Println("No validator keystores were found.")
+		return nil
+	}
+	fmt.Printf("Found %d validator keystore(s):\n", len(keystorePaths))
+	for _, path := range keystorePaths {
+		fmt.Println(path)
+	}
+	fmt.Println("")
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Validator keys must never be active on more than one machine at a time, or they will be slashed. Make sure these keys are no longer running anywhere else, and import their slashing protection history with 'rocketpool wallet import-slashing-protection'. Are you sure you want to import them?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get keystore password
+	var password string
+	if c.String("password-file") != "" {
+		passwordBytes, err := ioutil.ReadFile(c.String("password-file"))
+		if err != nil {
+			return fmt.Errorf("Could not read password file: %w", err)
+		}
+		password = strings.TrimRight(string(passwordBytes), "\r\n")
+	} else {
+		password = cliutils.PromptPassword("Please enter the password for the validator keystores:", "^.*$", "")
+	}
+
+	// Import keystores
+	imported := 0
+	for _, path := range keystorePaths {
+		keystoreBytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			fmt.Printf("Could not read %s: %s\n", path, err.Error())
+			continue
+		}
+		response, err := rp.ImportValidatorKey(string(keystoreBytes), password)
+		if err != nil {
+			fmt.Printf("Could not import %s: %s\n", path, err.Error())
+			continue
+		}
+		fmt.Printf("Imported validator key %s.\n", response.Pubkey.Hex())
+		imported++
+	}
+
+	// Log & return
+	fmt.Println("")
+	fmt.Printf("%d of %d validator key(s) were imported.\n", imported, len(keystorePaths))
+	if imported == 0 {
+		return nil
+	}
+	cfg, err := rp.LoadMergedConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Imported validator keys can't be recovered from your mnemonic. Please back up your node wallet file now, and keep the original keystores safe.")
+	if cfg.Smartnode.KeymanagerApi.Url == "" {
+		fmt.Println("Please restart your validator client so it loads the imported keys.")
+	}
+	return nil
+
+}
+
+// Get the keystore files at a set of paths, searching folders for staking-deposit-cli keystore files
+func getKeystorePaths(paths []string) ([]string, error) {
+	keystorePaths := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			keystorePaths = append(keystorePaths, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "keystore*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("Could not search %s: %w", path, err)
+		}
+		keystorePaths = append(keystorePaths, matches...)
+	}
+	return keystorePaths, nil
+}
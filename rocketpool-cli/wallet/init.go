@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 	"github.com/rocket-pool/smartnode/shared/utils/term"
 )
 
@@ -54,6 +55,11 @@ func initWallet(c *cli.Context) error {
 		return err
 	}
 
+	// Print mnemonic shares
+	if c.IsSet("shares") {
+		return printMnemonicShares(c, response.Mnemonic, response.AccountAddress.Hex())
+	}
+
 	// Print mnemonic
 	fmt.Println("Your mnemonic phrase to recover your wallet is printed below. It can be used to recover your node account and validator keys if they are lost.")
 	fmt.Println("Record this phrase somewhere secure and private. Do not share it with anyone as it will give them control of your node account and validators.")
@@ -78,3 +84,43 @@ func initWallet(c *cli.Context) error {
 	return nil
 
 }
+
+// Split the mnemonic into shares and print them instead of the mnemonic
+func printMnemonicShares(c *cli.Context, mnemonic string, accountAddress string) error {
+
+	// Split mnemonic
+	threshold := int(c.Uint("threshold"))
+	shares, err := shamir.SplitMnemonic(mnemonic, int(c.Uint("shares")), threshold)
+	if err != nil {
+		return err
+	}
+
+	// Print shares
+	fmt.Printf("Your mnemonic phrase has been split into %d shares, which are printed below. Any %d of them can be used to recover your node account and validator keys if they are lost.\n", len(shares), threshold)
+	fmt.Println("Give each share to a different person to record somewhere secure and private. Anyone holding enough shares will have control of your node account and validators.")
+	for si, share := range shares {
+		fmt.Println("==============================================================================================================================================")
+		fmt.Printf("Share %d of %d:\n", si+1, len(shares))
+		fmt.Println("")
+		fmt.Println(share)
+		fmt.Println("")
+	}
+	fmt.Println("==============================================================================================================================================")
+	fmt.Println("")
+
+	// Confirm shares
+	if !c.Bool("confirm-mnemonic") {
+		for si, share := range shares {
+			confirmMnemonicShare(share, si+1)
+		}
+	}
+
+	// Clear terminal output
+	_ = term.Clear()
+
+	// Log & return
+	fmt.Println("The node wallet was successfully initialized.")
+	fmt.Printf("Node account: %s\n", accountAddress)
+	return nil
+
+}
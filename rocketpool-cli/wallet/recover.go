@@ -6,6 +6,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 )
 
 func recoverWallet(c *cli.Context) error {
@@ -40,14 +42,27 @@ func recoverWallet(c *cli.Context) error {
 		}
 	}
 
-	// Prompt for mnemonic
+	// Prompt for mnemonic or shares
 	var mnemonic string
+	var shares []string
 	if c.String("mnemonic") != "" {
 		mnemonic = c.String("mnemonic")
+	} else if len(c.StringSlice("share")) > 0 {
+		shares = c.StringSlice("share")
+	} else if selected, _ := cliutils.Select("How would you like to recover your wallet?", []string{"From a mnemonic phrase", "From mnemonic shares"}); selected == 1 {
+		shares = promptMnemonicShares()
 	} else {
 		mnemonic = promptMnemonic()
 	}
 
+	// Recover mnemonic from shares
+	if shares != nil {
+		mnemonic, err = shamir.CombineMnemonicShares(shares)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Log
 	fmt.Println("Recovering node wallet...")
 
@@ -68,6 +83,14 @@ func recoverWallet(c *cli.Context) error {
 	} else {
 		fmt.Println("No validator keys were found.")
 	}
+	if len(response.UnrecoveredValidatorKeys) > 0 {
+		fmt.Println("")
+		fmt.Println("These validator keys were not derived from the mnemonic and could not be recovered:")
+		for _, key := range response.UnrecoveredValidatorKeys {
+			fmt.Println(key.Hex())
+		}
+		fmt.Println("If they were imported, restore them with `rocketpool wallet import-validator-keys` from their original keystores.")
+	}
 	return nil
 
 }
@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Config
+const DefaultValidatorContainerSuffix = "_validator"
+
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load config
+	cfg, err := rp.LoadMergedConfig()
+	if err != nil {
+		return err
+	}
+
+	// Check the output path before anything is exported
+	if err := checkOutputPath(c.String("output")); err != nil {
+		return err
+	}
+
+	// Export slashing protection data
+	var data []byte
+	if cfg.Smartnode.KeymanagerApi.Url != "" {
+
+		// Keys must be removed from the validator client to export their history through the keymanager API
+		if !(c.Bool("yes") || cliutils.Confirm("Exporting slashing protection data through the keymanager API deletes the node's validator keys from the validator client, which will stop validating. Are you sure you want to continue?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		response, err := rp.ExportSlashingProtection()
+		if err != nil {
+			// Save the data of keys which were deleted before the error
+			if response.SlashingProtection != "" {
+				if writeErr := ioutil.WriteFile(c.String("output"), []byte(response.SlashingProtection), 0600); writeErr != nil {
+					fmt.Printf("Could not write the slashing protection data of deleted validator keys to %s: %s\nData:\n%s\n", c.String("output"), writeErr.Error(), response.SlashingProtection)
+				} else {
+					fmt.Printf("Slashing protection data for %d deleted validator(s) was saved to %s.\n", len(response.ValidatorKeys), c.String("output"))
+				}
+			}
+			return err
+		}
+		data = []byte(response.SlashingProtection)
+
+	} else {
+
+		// Get the client which is currently running validator duties
+		container := getSlashingProtectionContainer(cfg, nil)
+		image, err := rp.GetDockerImage(container)
+		if err != nil {
+			return fmt.Errorf("Could not get the current validator image: %w", err)
+		}
+		client := cfg.Chains.Platform.GetClientByValidatorImage(image)
+		if client == nil {
+			return fmt.Errorf("The current validator image %s does not match a supported client.", image)
+		}
+		commands := cfg.GetSlashingProtectionCommands(*client)
+		if commands.ExportCommand == "" {
+			return fmt.Errorf("Exporting slashing protection data is not supported for %s.", client.Name)
+		}
+		container = getSlashingProtectionContainer(cfg, client)
+		fmt.Printf("Exporting slashing protection data from %s...\n", client.Name)
+		data, err = rp.ExportClientSlashingProtection(container, image, commands.ExportCommand)
+		if err != nil {
+			return err
+		}
+
+	}
+
+	// Check data
+	interchange, err := slashing.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	// Write data
+	if err := ioutil.WriteFile(c.String("output"), data, 0600); err != nil {
+		return fmt.Errorf("Could not write slashing protection data to %s: %w", c.String("output"), err)
+	}
+
+	// Log & return
+	fmt.Printf("Slashing protection data for %d validator(s) was saved to %s.\n", len(interchange.Data), c.String("output"))
+	return nil
+
+}
+
+func importSlashingProtection(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Read & check data
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Could not read slashing protection data from %s: %w", path, err)
+	}
+	interchange, err := slashing.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	// Load config
+	cfg, err := rp.LoadMergedConfig()
+	if err != nil {
+		return err
+	}
+
+	// Import through the keymanager API
+	if cfg.Smartnode.KeymanagerApi.Url != "" {
+		response, err := rp.ImportSlashingProtection(data)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d validator key(s) were imported into the validator client with their slashing protection data.\n", len(response.ValidatorKeys))
+		return nil
+	}
+
+	// Import into the selected client's database
+	client := cfg.GetSelectedEth2Client()
+	if client == nil {
+		return errors.New("No validator client is selected; please run `rocketpool service config` first.")
+	}
+	commands := cfg.GetSlashingProtectionCommands(*client)
+	if commands.ImportCommand == "" {
+		return fmt.Errorf("Importing slashing protection data is not supported for %s.", client.Name)
+	}
+	image := client.GetValidatorImage()
+	fmt.Printf("Importing slashing protection data for %d validator(s) into %s...\n", len(interchange.Data), client.Name)
+	if err := rp.ImportClientSlashingProtection(getSlashingProtectionContainer(cfg, client), image, commands.ImportCommand, data); err != nil {
+		return err
+	}
+
+	// Record the import so the new client can be started
+	if err := rp.SetSlashingProtectionImport(image); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Slashing protection data was successfully imported into %s.\n", client.Name)
+	return nil
+
+}
+
+// Get the container whose volumes hold a client's slashing protection database
+func getSlashingProtectionContainer(cfg config.RocketPoolConfig, client *config.ClientOption) string {
+	if client != nil {
+		if suffix := cfg.GetSlashingProtectionCommands(*client).ContainerSuffix; suffix != "" {
+			return cfg.Smartnode.ProjectName + suffix
+		}
+	}
+	return cfg.Smartnode.ProjectName + DefaultValidatorContainerSuffix
+}
+
+// Check that slashing protection data won't overwrite an existing file
+func checkOutputPath(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists; please move it or choose a different output path.", path)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("Could not check output path %s: %w", path, err)
+	}
+	return nil
+}
@@ -33,7 +33,13 @@ func getStatus(c *cli.Context) error {
 	// Print status & return
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
-		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		fmt.Printf("Node account (%s): %s\n", status.AccountName, status.AccountAddress.Hex())
+		if len(status.ImportedValidatorKeys) > 0 {
+			fmt.Printf("The wallet holds %d imported validator key(s) which can't be recovered from its mnemonic; keep a backup of the wallet file:\n", len(status.ImportedValidatorKeys))
+			for _, key := range status.ImportedValidatorKeys {
+				fmt.Println(key.Hex())
+			}
+		}
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func unlockWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Println("The node wallet is already unlocked.")
+		return nil
+	}
+
+	// Get password
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter your wallet password:", "^.*$", "")
+	}
+
+	// Unlock wallet
+	response, err := rp.UnlockWallet(password)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The node wallet was successfully unlocked. Node account: %s\n", response.AccountAddress.Hex())
+	return nil
+
+}
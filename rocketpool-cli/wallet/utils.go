@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 )
 
 // Prompt for a wallet password
@@ -52,3 +53,34 @@ func confirmMnemonic(mnemonic string) {
 		}
 	}
 }
+
+// Prompt for recovery mnemonic shares until enough have been entered to recover the mnemonic
+func promptMnemonicShares() []string {
+	shares := []string{}
+	threshold := 0
+	for threshold == 0 || len(shares) < threshold {
+		share := cliutils.PromptPassword(fmt.Sprintf("Please enter mnemonic share %d:", len(shares)+1), "^.*$", "")
+		shareThreshold, err := shamir.GetMnemonicShareThreshold(share)
+		if err != nil {
+			fmt.Printf("Invalid mnemonic share: %s\n", err.Error())
+			fmt.Println("")
+			continue
+		}
+		threshold = shareThreshold
+		shares = append(shares, share)
+	}
+	return shares
+}
+
+// Confirm a recovery mnemonic share
+func confirmMnemonicShare(share string, index int) {
+	for {
+		confirmation := cliutils.Prompt(fmt.Sprintf("Please enter recorded share %d to confirm it is correct:", index), "^.*$", "")
+		if share == confirmation {
+			return
+		} else {
+			fmt.Println("The share you entered does not match. Please try again.")
+			fmt.Println("")
+		}
+	}
+}
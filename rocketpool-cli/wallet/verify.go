@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func verifyWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Verify wallet
+	response, err := rp.VerifyWallet()
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The node wallet decrypts successfully.")
+	fmt.Printf("Checked %d validating minipool key(s).\n", len(response.ValidatorKeys))
+	if len(response.Failures) == 0 {
+		fmt.Println("All validator keys are present in the keystores.")
+		return nil
+	}
+	fmt.Println("")
+	fmt.Printf("%d problem(s) were found:\n", len(response.Failures))
+	for _, failure := range response.Failures {
+		fmt.Printf("%s (%s): %s\n", failure.Pubkey.Hex(), failure.Keystore, failure.Error)
+	}
+	fmt.Println("")
+	fmt.Println("Run 'rocketpool wallet rebuild' to restore missing validator keystores.")
+	return nil
+
+}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/rocketpool/api/debug"
 	"github.com/urfave/cli"
 
@@ -18,8 +23,12 @@ import (
 	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Color of messages logged while waiting for transactions, which are written to stderr so they don't mix with responses
+const WaitColor = color.FgHiBlue
+
 // Waits for an auction transaction
 func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse, error) {
 
@@ -27,9 +36,37 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 	if err != nil {
 		return nil, err
 	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := apitypes.APIResponse{}
+
+	// Track node account transactions, so they can be listed & cancelled and are replaced with higher fees while stuck
+	if w.IsInitialized() {
+		txm, err := services.GetTransactionManager(c)
+		if err != nil {
+			return nil, err
+		}
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := txm.TrackFrom(context.Background(), hash, opts.From, "api")
+		if err != nil {
+			return nil, fmt.Errorf("Error tracking transaction: %w", err)
+		}
+		if tx != nil {
+			if _, err := txm.Wait(context.Background(), tx, opts, log.NewColorLogger(WaitColor)); err != nil {
+				return nil, err
+			}
+			return &response, nil
+		}
+	}
+
+	// Wait for other transactions to be mined
 	_, err = utils.WaitForTransaction(rp.Client, hash)
 	if err != nil {
 		return nil, err
@@ -56,6 +93,28 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		return err
 	}
 
+	// Include simulated or unsigned transactions in responses in dry-run or unsigned export mode
+	command.Before = func(c *cli.Context) error {
+		api.SetDryRun(nil)
+		api.SetUnsignedExport(nil)
+		if c.GlobalBool("unsigned") && c.GlobalBool("dry-run") {
+			return errors.New("The --unsigned and --dry-run flags can't be used together")
+		}
+		if c.GlobalBool("unsigned") {
+			export := services.GetTransactionExport(c)
+			export.Take()
+			api.SetUnsignedExport(export)
+		} else if c.GlobalBool("dry-run") {
+			dr, err := services.GetDryRun(c)
+			if err != nil {
+				return err
+			}
+			dr.Take()
+			api.SetDryRun(dr)
+		}
+		return nil
+	}
+
 	// Register subcommands
 	auction.RegisterSubcommands(&command, "auction", []string{"a"})
 	faucet.RegisterSubcommands(&command, "faucet", []string{"f"})
@@ -56,14 +56,14 @@ func ExportValidators(c *cli.Context) error {
 	// Get eth2 config
 	wg1.Go(func() error {
 		var err error
-		eth2Config, err = bc.GetEth2Config()
+		eth2Config, err = bc.GetEth2Config(context.Background())
 		return err
 	})
 
 	// Get beacon head
 	wg1.Go(func() error {
 		var err error
-		beaconHead, err = bc.GetBeaconHead()
+		beaconHead, err = bc.GetBeaconHead(context.Background())
 		return err
 	})
 
@@ -88,7 +88,7 @@ func ExportValidators(c *cli.Context) error {
 	}
 
 	// Get minipool validator statuses
-	validators, err := rp.GetMinipoolValidators(rpl, bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: blockEpoch})
+	validators, err := rp.GetMinipoolValidators(context.Background(), rpl, bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: blockEpoch})
 	if err != nil {
 		return err
 	}
@@ -34,6 +34,25 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "performance",
+				Aliases:   []string{"p"},
+				Usage:     "Get the attestation and proposal performance of the node's minipool validators",
+				UsageText: "rocketpool api minipool performance",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPerformance(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-stake",
 				Usage:     "Check whether the minipool is ready to be staked, moving from prelaunch to staking status",
@@ -1,11 +1,11 @@
 package minipool
 
 import (
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -96,31 +96,39 @@ func exitMinipool(c *cli.Context, minipoolAddress common.Address) (*api.ExitMini
 	}
 
 	// Get beacon head
-	head, err := bc.GetBeaconHead()
+	head, err := bc.GetBeaconHead(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetDomainData(eth2types.DomainVoluntaryExit[:], head.Epoch)
+	// Get fork info for the voluntary exit signature domain
+	fork, err := bc.GetFork(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	eth2Config, err := bc.GetEth2Config(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	forkInfo := validator.ForkInfo{
+		Fork:                  fork,
+		GenesisValidatorsRoot: eth2Config.GenesisValidatorsRoot,
+	}
 
 	// Get validator index
-	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	validatorIndex, err := bc.GetValidatorIndex(context.Background(), validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get signed voluntary exit message
-	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	signature, err := validator.GetSignedExitMessage(w.GetValidatorSigner(validatorKey), validatorIndex, head.Epoch, forkInfo)
 	if err != nil {
 		return nil, err
 	}
 
 	// Broadcast voluntary exit message
-	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+	if err := bc.ExitValidator(context.Background(), validatorIndex, head.Epoch, signature); err != nil {
 		return nil, err
 	}
 
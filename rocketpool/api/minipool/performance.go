@@ -0,0 +1,91 @@
+package minipool
+
+import (
+	"context"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getPerformance(c *cli.Context) (*api.MinipoolPerformanceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolPerformanceResponse{}
+
+	// Get the performance recorded by the node daemon
+	store, err := performance.Load(w.GetNodeAccountDataPath(cfg.GetPerformancePath()))
+	if err != nil {
+		return nil, err
+	}
+	response.Tracking = !store.IsEmpty()
+	response.FirstEpoch = store.FirstEpoch
+	response.LastEpoch = store.LastEpoch
+
+	// Get minipool validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := rputils.GetMinipoolValidators(context.Background(), rp, bc, addresses, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get minipool performance
+	response.Minipools = make([]api.MinipoolPerformanceDetails, len(addresses))
+	for mi, address := range addresses {
+		validator := validators[address]
+		details := api.MinipoolPerformanceDetails{
+			Address:         address,
+			ValidatorPubkey: validator.Pubkey,
+			ValidatorExists: validator.Exists,
+			ValidatorIndex:  validator.Index,
+		}
+		if validatorPerformance, ok := store.Validators[validator.Index]; ok && validator.Exists {
+			details.Tracked = true
+			details.AttestationsExpected = validatorPerformance.AttestationsExpected
+			details.AttestationsMissed = validatorPerformance.AttestationsMissed
+			details.AverageInclusionDistance = validatorPerformance.AverageInclusionDistance()
+			details.ProposalsExpected = validatorPerformance.ProposalsExpected
+			details.ProposalsMissed = validatorPerformance.ProposalsMissed
+		}
+		response.Minipools[mi] = details
+	}
+
+	// Return response
+	return &response, nil
+
+}
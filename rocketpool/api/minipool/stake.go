@@ -84,7 +84,7 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 
 	if response.CanStake {
 		// Get eth2 config
-		eth2Config, err := bc.GetEth2Config()
+		eth2Config, err := bc.GetEth2Config(context.Background())
 		if err != nil {
 			return nil, err
 		}
@@ -106,7 +106,7 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		}
 
 		// Get validator deposit data
-		depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
+		depositData, depositDataRoot, err := validator.GetDepositData(w.GetValidatorSigner(validatorKey), withdrawalCredentials, eth2Config)
 		if err != nil {
 			return nil, err
 		}
@@ -171,7 +171,7 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	}
 
 	// Get eth2 config
-	eth2Config, err := bc.GetEth2Config()
+	eth2Config, err := bc.GetEth2Config(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +193,7 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	}
 
 	// Get validator deposit data
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
+	depositData, depositDataRoot, err := validator.GetDepositData(w.GetValidatorSigner(validatorKey), withdrawalCredentials, eth2Config)
 	if err != nil {
 		return nil, err
 	}
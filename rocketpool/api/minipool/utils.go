@@ -58,13 +58,13 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 	// Get eth2 config
 	wg1.Go(func() error {
 		var err error
-		eth2Config, err = bc.GetEth2Config()
+		eth2Config, err = bc.GetEth2Config(context.Background())
 		return err
 	})
 
 	// Get current epoch
 	wg1.Go(func() error {
-		head, err := bc.GetBeaconHead()
+		head, err := bc.GetBeaconHead(context.Background())
 		if err == nil {
 			currentEpoch = head.Epoch
 		}
@@ -86,7 +86,7 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 	}
 
 	// Get minipool validator statuses
-	validators, err := rputils.GetMinipoolValidators(rp, bc, addresses, nil, nil)
+	validators, err := rputils.GetMinipoolValidators(context.Background(), rp, bc, addresses, nil, nil)
 	if err != nil {
 		return []api.MinipoolDetails{}, err
 	}
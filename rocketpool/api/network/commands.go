@@ -53,6 +53,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "gas-prices",
+				Usage:     "Get gas price suggestions from the gas oracle",
+				UsageText: "rocketpool api network gas-prices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasPrices(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"s"},
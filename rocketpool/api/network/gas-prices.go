@@ -0,0 +1,35 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getGasPrices(c *cli.Context) (*api.GasPricesResponse, error) {
+
+	// Get services
+	oracle, err := services.GetGasOracle(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasPricesResponse{}
+
+	// Get gas prices
+	prices, provider, err := oracle.GetGasPrices()
+	if err != nil {
+		return nil, err
+	}
+	response.Provider = provider
+	response.PriorityFeeWei = prices.PriorityFeeWei
+	for _, tier := range prices.Tiers {
+		response.Tiers = append(response.Tiers, api.GasPriceTier{Name: tier.Name, MaxFeeWei: tier.MaxFeeWei})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -755,6 +755,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-history",
+				Usage:     "Get the node's minipool validator balance history by period",
+				UsageText: "rocketpool api node rewards-history period",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					period, err := cliutils.ValidateRewardsPeriod("period", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsHistory(c, period))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "deposit-contract-info",
 				Usage:     "Get information about the deposit contract specified by Rocket Pool and the Beacon Chain client",
@@ -772,6 +794,68 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "pending-transactions",
+				Usage:     "Get the node's pending transactions which are being managed",
+				UsageText: "rocketpool api node pending-transactions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPendingTransactions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "cancel-transaction",
+				Usage:     "Cancel a pending transaction by replacing it with a zero-value transfer to the node at higher fees",
+				UsageText: "rocketpool api node cancel-transaction tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(cancelTransaction(c, hash))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "broadcast-transaction",
+				Usage:     "Submit a transaction signed on another machine",
+				UsageText: "rocketpool api node broadcast-transaction raw-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					tx, err := cliutils.ValidateRawTransaction("raw-tx", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastTransaction(c, tx))
+					return nil
+
+				},
+			},
 		},
 	})
 }
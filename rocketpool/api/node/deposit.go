@@ -61,7 +61,7 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 	}
 
 	// Get eth2 config
-	eth2Config, err := bc.GetEth2Config()
+	eth2Config, err := bc.GetEth2Config(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +170,8 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 		}
 
 		// Get validator deposit data and associated parameters
-		depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
+		// The next validator key has not been stored in any keystore yet, so it is signed locally
+		depositData, depositDataRoot, err := validator.GetDepositData(validator.NewLocalSigner(validatorKey), withdrawalCredentials, eth2Config)
 		if err != nil {
 			return err
 		}
@@ -279,7 +280,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	}
 
 	// Get eth2 config
-	eth2Config, err := bc.GetEth2Config()
+	eth2Config, err := bc.GetEth2Config(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +355,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	}
 
 	// Get validator deposit data and associated parameters
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
+	depositData, depositDataRoot, err := validator.GetDepositData(w.GetValidatorSigner(validatorKey), withdrawalCredentials, eth2Config)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +363,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	signature := rptypes.BytesToValidatorSignature(depositData.Signature)
 
 	// Make sure a validator with this pubkey doesn't already exist
-	status, err := bc.GetValidatorStatus(pubKey, nil)
+	status, err := bc.GetValidatorStatus(context.Background(), pubKey, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error checking for existing validator status: %w\nYour funds have not been deposited for your own safety.", err)
 	}
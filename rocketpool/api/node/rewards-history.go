@@ -0,0 +1,104 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getRewardsHistory(c *cli.Context, period string) (*api.NodeRewardsHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsHistoryResponse{Period: period}
+
+	// Get minipool validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := rputils.GetMinipoolValidators(context.Background(), rp, bc, addresses, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	eth2Config, err := bc.GetEth2Config(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+
+	// Get the balance history of each minipool
+	store := history.NewStore(w.GetNodeAccountDataPath(cfg.GetBalanceHistoryPath()))
+	response.Minipools = []api.MinipoolRewardsHistory{}
+	for _, address := range addresses {
+		validator := validators[address]
+		if !validator.Exists {
+			continue
+		}
+		samples, err := store.GetSamples(validator.Index)
+		if err != nil {
+			return nil, err
+		}
+		yields := history.GetPeriodYields(samples, genesisTime, eth2Config.SecondsPerEpoch, history.Period(period))
+		periods := make([]api.RewardsHistoryPeriod, len(yields))
+		for yi, yield := range yields {
+			periods[yi] = api.RewardsHistoryPeriod{
+				Start:        yield.Start,
+				StartEpoch:   yield.StartEpoch,
+				EndEpoch:     yield.EndEpoch,
+				StartBalance: gweiToEth(float64(yield.StartBalance)),
+				EndBalance:   gweiToEth(float64(yield.EndBalance)),
+				Earned:       gweiToEth(float64(yield.Earned())),
+			}
+		}
+		response.Minipools = append(response.Minipools, api.MinipoolRewardsHistory{
+			Address:        address,
+			ValidatorIndex: validator.Index,
+			Periods:        periods,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Convert a gwei amount to ETH
+func gweiToEth(gwei float64) float64 {
+	return gwei * eth.WeiPerGwei / eth.WeiPerEth
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -209,7 +210,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 
 	// Get the beacon head
 	wg.Go(func() error {
-		_beaconHead, err := bc.GetBeaconHead()
+		_beaconHead, err := bc.GetBeaconHead(context.Background())
 		if err != nil {
 			return fmt.Errorf("Error getting beacon chain head: %w", err)
 		}
@@ -223,7 +224,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 	}
 
 	// Calculate the total deposits and corresponding beacon chain balance share
-	minipoolDetails, err := eth2.GetBeaconBalances(rp, bc, addresses, beaconHead, nil)
+	minipoolDetails, err := eth2.GetBeaconBalances(context.Background(), rp, bc, addresses, beaconHead, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -8,7 +8,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/tokens"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -211,8 +210,7 @@ func waitForApprovalAndStakeRpl(c *cli.Context, amountWei *big.Int, hash common.
 	}
 
 	// Wait for the RPL approval TX to successfully get mined
-	_, err = utils.WaitForTransaction(rp.Client, hash)
-	if err != nil {
+	if err := eth1.WaitForDependency(c, rp.Client, hash); err != nil {
 		return nil, err
 	}
 
@@ -6,7 +6,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/tokens"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -207,8 +206,7 @@ func waitForApprovalAndSwapFsRpl(c *cli.Context, amountWei *big.Int, hash common
 	}
 
 	// Wait for the fixed-supply RPL approval TX to successfully get mined
-	_, err = utils.WaitForTransaction(rp.Client, hash)
-	if err != nil {
+	if err := eth1.WaitForDependency(c, rp.Client, hash); err != nil {
 		return nil, err
 	}
 
@@ -44,7 +44,7 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
 	}
 
 	// Get eth2 sync progress
-	syncStatus, err := bc.GetSyncStatus()
+	syncStatus, err := bc.GetSyncStatus(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +57,7 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
 	}
 
 	// Get eth1 latest block timestamp
-	latestBlockTime, err := services.GetEthClientLatestBlockTimestamp(c)
+	latestBlockTime, err := services.GetEthClientLatestBlockTimestamp(context.Background(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,126 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getPendingTransactions(c *cli.Context) (*api.PendingTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	txm, err := services.GetTransactionManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PendingTransactionsResponse{}
+
+	// Get pending transactions
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	txs, err := txm.GetPendingTransactions(nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.Transactions = make([]api.PendingTransaction, len(txs))
+	for ti, tx := range txs {
+		response.Transactions[ti] = api.PendingTransaction{
+			Hash:              tx.GetHash(),
+			OriginalHash:      tx.Hashes[0],
+			Nonce:             tx.Nonce,
+			Task:              tx.Task,
+			To:                tx.To,
+			MaxFeeWei:         tx.MaxFeeWei,
+			MaxPriorityFeeWei: tx.MaxPriorityFeeWei,
+			Replacements:      len(tx.Hashes) - 1,
+			SubmittedTime:     tx.SubmittedTime,
+			Cancelled:         tx.IsCancelled(),
+			MaxFeeReached:     tx.MaxFeeReached,
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelTransaction(c *cli.Context, hash common.Hash) (*api.CancelTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	txm, err := services.GetTransactionManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelTransactionResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancel transaction
+	txHash, err := txm.Cancel(context.Background(), hash, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = txHash
+
+	// Return response
+	return &response, nil
+
+}
+
+func broadcastTransaction(c *cli.Context, tx *types.Transaction) (*api.BroadcastTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClientProxy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastTransactionResponse{}
+
+	// Submit transaction
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, fmt.Errorf("Could not submit transaction: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
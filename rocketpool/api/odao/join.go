@@ -8,7 +8,6 @@ import (
 	tndao "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/tokens"
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
@@ -196,8 +195,7 @@ func waitForApprovalAndJoin(c *cli.Context, hash common.Hash) (*api.JoinTNDAOJoi
 	}
 
 	// Wait for the RPL approval TX to successfully get mined
-	_, err = utils.WaitForTransaction(rp.Client, hash)
-	if err != nil {
+	if err := eth1.WaitForDependency(c, rp.Client, hash); err != nil {
 		return nil, err
 	}
 
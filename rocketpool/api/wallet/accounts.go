@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getNodeAccounts(c *cli.Context) (*api.NodeAccountsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	wallets, err := services.GetNodeAccountWallets(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeAccountsResponse{}
+
+	// Get node accounts
+	for _, w := range wallets {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.Accounts = append(response.Accounts, api.NodeAccount{
+			Name:    w.GetNodeAccountName(),
+			Address: nodeAccount.Address,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func addNodeAccount(c *cli.Context, name string, index uint) (*api.AddNodeAccountResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AddNodeAccountResponse{}
+
+	// Add node account
+	if err := w.AddNodeAccount(name, index); err != nil {
+		return nil, err
+	}
+
+	// Get node account address
+	aw, err := w.ForNodeAccount(name)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := aw.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func changePassword(c *cli.Context, currentPassword, newPassword string) (*api.ChangePasswordResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ChangePasswordResponse{}
+
+	// Change password
+	unchangedKeystores, err := w.ChangePassword(currentPassword, newPassword)
+	if err != nil {
+		return nil, err
+	}
+	response.UnchangedKeystores = unchangedKeystores
+
+	// Return response
+	return &response, nil
+
+}
@@ -57,6 +57,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "change-password",
+				Usage:     "Change the node wallet password and re-encrypt all validator keystores",
+				UsageText: "rocketpool api wallet change-password current-password new-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					currentPassword, err := cliutils.ValidateNodePassword("current wallet password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					newPassword, err := cliutils.ValidateNodePassword("new wallet password", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(changePassword(c, currentPassword, newPassword))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "unlock",
+				Usage:     "Unlock the node wallet with its password",
+				UsageText: "rocketpool api wallet unlock password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("wallet password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(unlockWallet(c, password))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
@@ -118,6 +166,86 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "accounts",
+				Usage:     "List the node accounts in the node wallet",
+				UsageText: "rocketpool api wallet accounts",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeAccounts(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "add-account",
+				Usage:     "Add a named node account derived at an index of the node wallet",
+				UsageText: "rocketpool api wallet add-account name index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					name, err := cliutils.ValidateNodeAccountName("account name", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					index, err := cliutils.ValidatePositiveUint("account index", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(addNodeAccount(c, name, uint(index)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "import-validator-key",
+				Usage:     "Import an EIP-2335 validator keystore into the node wallet",
+				UsageText: "rocketpool api wallet import-validator-key keystore password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importValidatorKey(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify",
+				Usage:     "Verify the node wallet decrypts and all validating minipool keys are in the keystores",
+				UsageText: "rocketpool api wallet verify",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyWallet(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -136,6 +264,82 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "delete-validator-key",
+				Usage:     "Delete a validator key from the validator client and export its slashing protection data",
+				UsageText: "rocketpool api wallet delete-validator-key pubkey",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(deleteValidatorKey(c, pubkey))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Delete the node's validator keys from the validator client and export their slashing protection data",
+				UsageText: "rocketpool api wallet export-slashing-protection",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportSlashingProtection(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import the node's validator keys into the validator client with their slashing protection data",
+				UsageText: "rocketpool api wallet import-slashing-protection < data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importSlashingProtection(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-transactions",
+				Usage:     "Sign node account transactions exported in JSON format on another machine",
+				UsageText: "rocketpool api wallet sign-transactions < data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signTransactions(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func deleteValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) (*api.DeleteValidatorKeyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DeleteValidatorKeyResponse{}
+
+	// Delete validator key
+	slashingProtection, err := w.DeleteValidatorKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	response.SlashingProtection = string(slashingProtection)
+
+	// Return response
+	return &response, nil
+
+}
@@ -41,12 +41,14 @@ func exportWallet(c *cli.Context) (*api.ExportWalletResponse, error) {
 	}
 	response.Wallet = wallet
 
-	// Get account private key
-	privateKey, err := w.GetNodePrivateKeyBytes()
-	if err != nil {
-		return nil, err
+	// Get account private key if it is held locally
+	if !w.HasExternalNodeSigner() {
+		privateKey, err := w.GetNodePrivateKeyBytes()
+		if err != nil {
+			return nil, err
+		}
+		response.AccountPrivateKey = hex.EncodeToString(privateKey)
 	}
-	response.AccountPrivateKey = hex.EncodeToString(privateKey)
 
 	// Return response
 	return &response, nil
@@ -4,9 +4,11 @@ import (
 	"errors"
 
 	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -56,8 +58,12 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	response.ValidatorKeys = pubkeys
 
 	// Recover validator keys
+	// Imported keys can't be derived from the mnemonic, so are reported instead
+	response.UnrecoveredValidatorKeys = []types.ValidatorPubkey{}
 	for _, pubkey := range pubkeys {
-		if err := w.RecoverValidatorKey(pubkey); err != nil {
+		if err := w.RecoverValidatorKey(pubkey); errors.Is(err, wallet.ErrValidatorKeyNotFound) {
+			response.UnrecoveredValidatorKeys = append(response.UnrecoveredValidatorKeys, pubkey)
+		} else if err != nil {
 			return nil, err
 		}
 	}
@@ -0,0 +1,90 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+func signTransactions(c *cli.Context) (*api.SignTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignTransactionsResponse{}
+
+	// Read and decode transactions
+	data, err := apiutils.ReadInput()
+	if err != nil {
+		return nil, fmt.Errorf("Could not read transactions: %w", err)
+	}
+	var txs api.UnsignedTransactionsFile
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("Could not decode transactions: %w", err)
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	chainID := w.GetChainID()
+
+	// Sign transactions
+	response.Transactions = make([]api.SignedTransaction, len(txs.Transactions))
+	for ti, tx := range txs.Transactions {
+
+		// Check the transaction is for the node account on this network
+		if tx.From != opts.From {
+			return nil, fmt.Errorf("Transaction %d is from %s, not the node account %s", ti, tx.From.Hex(), opts.From.Hex())
+		}
+		if tx.ChainID == nil || tx.ChainID.Cmp(chainID) != 0 {
+			return nil, fmt.Errorf("Transaction %d is for chain ID %s, not %s", ti, tx.ChainID, chainID)
+		}
+
+		// Sign; the nonce is set on the transactor so it isn't reallocated
+		opts.Nonce = new(big.Int).SetUint64(tx.Nonce)
+		signedTx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainID,
+			Nonce:     tx.Nonce,
+			GasTipCap: tx.MaxPriorityFeeWei,
+			GasFeeCap: tx.MaxFeeWei,
+			Gas:       tx.GasLimit,
+			To:        tx.To,
+			Value:     tx.Value,
+			Data:      tx.Data,
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("Could not sign transaction %d: %w", ti, err)
+		}
+		rawTx, err := signedTx.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode transaction %d: %w", ti, err)
+		}
+		response.Transactions[ti] = api.SignedTransaction{
+			Hash:  signedTx.Hash(),
+			From:  opts.From,
+			Nonce: signedTx.Nonce(),
+			RawTx: rawTx,
+		}
+
+	}
+
+	// Return response
+	return &response, nil
+
+}
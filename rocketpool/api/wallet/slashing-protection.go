@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+func exportSlashingProtection(c *cli.Context) (*api.ExportSlashingProtectionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportSlashingProtectionResponse{}
+
+	// Remove validator keys and get their slashing protection data
+	// Data for keys removed before an error is still returned with it
+	interchange, exportErr := w.ExportSlashingProtection()
+	if interchange == nil {
+		return nil, exportErr
+	}
+	data, err := interchange.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	response.SlashingProtection = string(data)
+	response.ValidatorKeys = interchange.GetPubkeys()
+
+	// Return response
+	return &response, exportErr
+
+}
+
+func importSlashingProtection(c *cli.Context) (*api.ImportSlashingProtectionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportSlashingProtectionResponse{}
+
+	// Read and parse slashing protection data
+	data, err := apiutils.ReadInput()
+	if err != nil {
+		return nil, fmt.Errorf("Could not read slashing protection data: %w", err)
+	}
+	interchange, err := slashing.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store validator keys with their slashing protection data
+	pubkeys, err := w.ImportSlashingProtection(interchange)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorKeys = pubkeys
+
+	// Return response
+	return &response, nil
+
+}
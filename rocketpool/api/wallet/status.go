@@ -34,8 +34,16 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		response.AccountName = w.GetNodeAccountName()
 		response.AccountAddress = nodeAccount.Address
 
+		// Get imported validator keys, which can't be recovered from the mnemonic
+		importedPubkeys, err := w.GetImportedValidatorPubkeys()
+		if err != nil {
+			return nil, err
+		}
+		response.ImportedValidatorKeys = importedPubkeys
+
 	}
 
 	// Return response
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func unlockWallet(c *cli.Context, password string) (*api.UnlockWalletResponse, error) {
+
+	// Get services
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.UnlockWalletResponse{}
+
+	// Check wallet is locked
+	if w.IsInitialized() {
+		return nil, errors.New("The node wallet is already unlocked")
+	}
+
+	// Set password
+	if err := pm.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	// Decrypt the wallet; lock it again if the password is incorrect
+	err = w.Reload()
+	if err == nil && !w.IsInitialized() {
+		err = errors.New("The node wallet has not been initialized")
+	}
+	if err != nil {
+		if deleteErr := pm.DeletePassword(); deleteErr != nil {
+			return nil, fmt.Errorf("%w; additionally, could not lock the wallet again: %s", err, deleteErr.Error())
+		}
+		return nil, fmt.Errorf("Could not unlock the node wallet: %w", err)
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Return response
+	return &response, nil
+
+}
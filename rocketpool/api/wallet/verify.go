@@ -0,0 +1,82 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func verifyWallet(c *cli.Context) (*api.VerifyWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyWalletResponse{
+		Failures: []api.ValidatorKeyFailure{},
+	}
+
+	// Check the wallet decrypts
+	if err := w.Verify(); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get node's validating pubkeys
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorKeys = pubkeys
+
+	// Verify validator keys
+	for _, pubkey := range pubkeys {
+		failures, err := w.VerifyValidatorKey(pubkey)
+		if err != nil {
+			response.Failures = append(response.Failures, api.ValidatorKeyFailure{
+				Pubkey:   pubkey,
+				Keystore: "wallet",
+				Error:    err.Error(),
+			})
+			continue
+		}
+		names := make([]string, 0, len(failures))
+		for name := range failures {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			response.Failures = append(response.Failures, api.ValidatorKeyFailure{
+				Pubkey:   pubkey,
+				Keystore: name,
+				Error:    failures[name].Error(),
+			})
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -1,6 +1,7 @@
 package collectors
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -85,7 +86,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 	// Get sync committee duties
 	wg.Go(func() error {
 		var err error
-		validatorIndices, err = rp.GetNodeValidatorIndices(collector.rp, collector.ec, collector.bc, collector.nodeAddress)
+		validatorIndices, err = rp.GetNodeValidatorIndices(context.Background(), collector.rp, collector.ec, collector.bc, collector.nodeAddress)
 		if err != nil {
 			return fmt.Errorf("Error getting validator indices: %w", err)
 		}
@@ -94,7 +95,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 	wg.Go(func() error {
 		var err error
-		head, err = collector.bc.GetBeaconHead()
+		head, err = collector.bc.GetBeaconHead(context.Background())
 		if err != nil {
 			return fmt.Errorf("Error getting beaconchain head: %w", err)
 		}
@@ -109,7 +110,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 	wg2.Go(func() error {
 		// Get current duties
-		duties, err := collector.bc.GetValidatorSyncDuties(validatorIndices, head.Epoch)
+		duties, err := collector.bc.GetValidatorSyncDuties(context.Background(), validatorIndices, head.Epoch)
 		if err != nil {
 			return fmt.Errorf("Error getting sync duties: %w", err)
 		}
@@ -125,13 +126,13 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 	wg2.Go(func() error {
 		// Get epochs per sync committee period config to query next period
-		config, err := collector.bc.GetEth2Config()
+		config, err := collector.bc.GetEth2Config(context.Background())
 		if err != nil {
 			return fmt.Errorf("Error getting ETH2 config: %w", err)
 		}
 
 		// Get upcoming duties
-		duties, err := collector.bc.GetValidatorSyncDuties(validatorIndices, head.Epoch+config.EpochsPerSyncCommitteePeriod)
+		duties, err := collector.bc.GetValidatorSyncDuties(context.Background(), validatorIndices, head.Epoch+config.EpochsPerSyncCommitteePeriod)
 		if err != nil {
 			return fmt.Errorf("Error getting sync duties: %w", err)
 		}
@@ -147,7 +148,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 
 	wg2.Go(func() error {
 		// Get proposals in this epoch
-		duties, err := collector.bc.GetValidatorProposerDuties(validatorIndices, head.Epoch)
+		duties, err := collector.bc.GetValidatorProposerDuties(context.Background(), validatorIndices, head.Epoch)
 		if err != nil {
 			return fmt.Errorf("Error getting proposer duties: %w", err)
 		}
@@ -160,7 +161,7 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 		// https://eth2book.info/altair/annotated-spec/#compute_proposer_index
 		/*
 			// Get proposals in the next epoch
-			duties, err = collector.bc.GetValidatorProposerDuties(validatorIndices, head.Epoch + 1)
+			duties, err = collector.bc.GetValidatorProposerDuties(context.Background(), validatorIndices, head.Epoch + 1)
 			if err != nil {
 				return fmt.Errorf("Error getting proposer duties: %w", err)
 			}
@@ -319,7 +319,7 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 
 	// Get the beacon head
 	wg.Go(func() error {
-		_beaconHead, err := collector.bc.GetBeaconHead()
+		_beaconHead, err := collector.bc.GetBeaconHead(context.Background())
 		if err != nil {
 			return fmt.Errorf("Error getting beacon chain head: %w", err)
 		}
@@ -364,7 +364,7 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 	}
 
 	// Calculate the total deposits and corresponding beacon chain balance share
-	minipoolDetails, err := eth2.GetBeaconBalances(collector.rp, collector.bc, addresses, beaconHead, nil)
+	minipoolDetails, err := eth2.GetBeaconBalances(context.Background(), collector.rp, collector.bc, addresses, beaconHead, nil)
 	if err != nil {
 		log.Printf("%s\n", err.Error())
 		return
@@ -0,0 +1,108 @@
+package collectors
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+)
+
+// Represents the collector for the node's validator duty performance
+type ValidatorPerformanceCollector struct {
+
+	// The number of attestations each validator was expected to make
+	attestationsExpected *prometheus.Desc
+
+	// The number of attestations each validator missed
+	attestationsMissed *prometheus.Desc
+
+	// The average inclusion distance of each validator's attestations
+	averageInclusionDistance *prometheus.Desc
+
+	// The number of blocks each validator was expected to propose
+	proposalsExpected *prometheus.Desc
+
+	// The number of block proposals each validator missed
+	proposalsMissed *prometheus.Desc
+
+	// The last epoch checked
+	lastEpoch *prometheus.Desc
+
+	// Performance by validator index, updated by the performance tracking task
+	Validators map[uint64]performance.ValidatorPerformance
+	LastEpoch  float64
+
+	// Mutex
+	UpdateLock sync.Mutex
+}
+
+// Create a new ValidatorPerformanceCollector instance
+func NewValidatorPerformanceCollector() *ValidatorPerformanceCollector {
+	subsystem := "validator_performance"
+	labels := []string{"validator"}
+	return &ValidatorPerformanceCollector{
+		attestationsExpected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestations_expected_total"),
+			"The number of attestations each validator was expected to make",
+			labels, nil,
+		),
+		attestationsMissed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestations_missed_total"),
+			"The number of attestations each validator missed",
+			labels, nil,
+		),
+		averageInclusionDistance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "average_inclusion_distance"),
+			"The average inclusion distance of each validator's attestations",
+			labels, nil,
+		),
+		proposalsExpected: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals_expected_total"),
+			"The number of blocks each validator was expected to propose",
+			labels, nil,
+		),
+		proposalsMissed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals_missed_total"),
+			"The number of block proposals each validator missed",
+			labels, nil,
+		),
+		lastEpoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_epoch"),
+			"The last epoch checked for validator performance",
+			nil, nil,
+		),
+		Validators: make(map[uint64]performance.ValidatorPerformance),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ValidatorPerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.attestationsExpected
+	channel <- collector.attestationsMissed
+	channel <- collector.averageInclusionDistance
+	channel <- collector.proposalsExpected
+	channel <- collector.proposalsMissed
+	channel <- collector.lastEpoch
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ValidatorPerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for index, validator := range collector.Validators {
+		label := strconv.FormatUint(index, 10)
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationsExpected, prometheus.CounterValue, float64(validator.AttestationsExpected), label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationsMissed, prometheus.CounterValue, float64(validator.AttestationsMissed), label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.averageInclusionDistance, prometheus.GaugeValue, validator.AverageInclusionDistance(), label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalsExpected, prometheus.CounterValue, float64(validator.ProposalsExpected), label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalsMissed, prometheus.CounterValue, float64(validator.ProposalsMissed), label)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastEpoch, prometheus.GaugeValue, collector.LastEpoch)
+
+}
@@ -0,0 +1,58 @@
+package node
+
+import (
+	"context"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Manage transactions task
+type manageTransactions struct {
+	c   *cli.Context
+	log log.ColorLogger
+	w   *wallet.Wallet
+	txm *transactions.Manager
+}
+
+// Create manage transactions task
+func newManageTransactions(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*manageTransactions, error) {
+
+	// Get services
+	txm, err := services.GetTransactionManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageTransactions{
+		c:   c,
+		log: logger,
+		w:   w,
+		txm: txm,
+	}, nil
+
+}
+
+// Resume the node's pending transactions left by tasks which stopped waiting for them, replacing any which are stuck
+func (t *manageTransactions) run(ctx context.Context) error {
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Update pending transactions
+	return t.txm.Process(ctx, opts, t.log)
+
+}
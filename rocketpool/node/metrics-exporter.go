@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -8,21 +9,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
+func runMetricsServer(ctx context.Context, c *cli.Context, logger log.ColorLogger, validatorPerformanceCollectors map[*wallet.Wallet]*collectors.ValidatorPerformanceCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -41,20 +39,12 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 		return nil
 	}
 
-	nodeAccount, err := w.GetNodeAccount()
-	if err != nil {
-		return fmt.Errorf("Error getting node account: %w", err)
-	}
-
 	// Create the collectors
 	demandCollector := collectors.NewDemandCollector(rp)
 	performanceCollector := collectors.NewPerformanceCollector(rp)
 	supplyCollector := collectors.NewSupplyCollector(rp)
 	rplCollector := collectors.NewRplCollector(rp)
 	odaoCollector := collectors.NewOdaoCollector(rp)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg)
-	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg)
-	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -63,9 +53,22 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
 	registry.MustRegister(supplyCollector)
 	registry.MustRegister(rplCollector)
 	registry.MustRegister(odaoCollector)
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(trustedNodeCollector)
-	registry.MustRegister(beaconCollector)
+
+	// Register the collectors for each node account, labelled with its address
+	for w, validatorPerformanceCollector := range validatorPerformanceCollectors {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return fmt.Errorf("Error getting node account: %w", err)
+		}
+		nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg)
+		trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg)
+		beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address)
+		accountRegistry := prometheus.WrapRegistererWith(prometheus.Labels{"node": nodeAccount.Address.Hex()}, registry)
+		accountRegistry.MustRegister(nodeCollector)
+		accountRegistry.MustRegister(trustedNodeCollector)
+		accountRegistry.MustRegister(beaconCollector)
+		accountRegistry.MustRegister(validatorPerformanceCollector)
+	}
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -84,8 +87,21 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger) error {
             </html>`,
 		))
 	})
-	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
-	if err != nil {
+	server := &http.Server{Addr: fmt.Sprintf("%s:%d", metricsAddress, metricsPort)}
+
+	// Stop the HTTP server on shutdown
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-stopped:
+		}
+	}()
+
+	err = server.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("Error running HTTP server: %w", err)
 	}
 
@@ -8,21 +8,30 @@ import (
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/scheduler"
+	"github.com/rocket-pool/smartnode/shared/utils/shutdown"
 )
 
 // Config
 var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
+var maxTaskBackoff, _ = time.ParseDuration("30m")
 
 const (
 	MaxConcurrentEth1Requests = 200
 
-	ClaimRplRewardsColor         = color.FgGreen
-	StakePrelaunchMinipoolsColor = color.FgBlue
-	MetricsColor                 = color.FgHiYellow
-	ErrorColor                   = color.FgRed
+	ClaimRplRewardsColor           = color.FgGreen
+	StakePrelaunchMinipoolsColor   = color.FgBlue
+	TrackValidatorPerformanceColor = color.FgCyan
+	RecordBalanceHistoryColor      = color.FgMagenta
+	ManageTransactionsColor        = color.FgHiBlue
+	MetricsColor                   = color.FgHiYellow
+	ErrorColor                     = color.FgRed
 )
 
 // Register node command
@@ -43,53 +52,120 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
+	// Initialize error logger
+	errorLog := log.NewColorLogger(ErrorColor)
+
+	// Stop on interrupt or termination
+	ctx := shutdown.Context(errorLog)
+
 	// Wait until node is registered
-	if err := services.WaitNodeRegistered(c, true); err != nil {
+	if err := services.WaitNodeRegistered(ctx, c, true); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
 	}
 
-	// Initialize tasks
-	claimRplRewards, err := newClaimRplRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
+	// Get services
+	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	shutdownTimeout, err := cfg.GetShutdownTimeout()
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return err
 	}
 
-	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
+	// Get a wallet for each node account
+	wallets, err := services.GetNodeAccountWallets(c)
+	if err != nil {
+		return err
+	}
+
+	// Register tasks with the scheduler for each node account, running some early on beacon events
+	taskScheduler := scheduler.NewScheduler(errorLog)
+	eventTrigger := scheduler.NewEventTrigger()
+	validatorPerformanceCollectors := map[*wallet.Wallet]*collectors.ValidatorPerformanceCollector{}
+	for _, w := range wallets {
+
+		// Initialize the metrics reporters
+		validatorPerformanceCollector := collectors.NewValidatorPerformanceCollector()
+		validatorPerformanceCollectors[w] = validatorPerformanceCollector
+
+		// Initialize tasks
+		claimRplRewards, err := newClaimRplRewards(c, services.NewNodeAccountLogger(w, ClaimRplRewardsColor), w)
+		if err != nil {
+			return err
+		}
+		stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, services.NewNodeAccountLogger(w, StakePrelaunchMinipoolsColor), w)
+		if err != nil {
+			return err
+		}
+		trackValidatorPerformance, err := newTrackValidatorPerformance(c, services.NewNodeAccountLogger(w, TrackValidatorPerformanceColor), w, validatorPerformanceCollector)
+		if err != nil {
+			return err
+		}
+		recordBalanceHistory, err := newRecordBalanceHistory(c, services.NewNodeAccountLogger(w, RecordBalanceHistoryColor), w)
+		if err != nil {
+			return err
+		}
+
+		manageTransactions, err := newManageTransactions(c, services.NewNodeAccountLogger(w, ManageTransactionsColor), w)
+		if err != nil {
+			return err
+		}
+
+		// Register tasks; tasks submitting transactions run one at a time with the task filling nonce gaps, so a gap isn't filled while a submission is in progress
+		transactions := w.GetNodeAccountName()
+		tasks := []scheduler.Task{
+			{Name: "claimRplRewards", Run: claimRplRewards.run, Group: transactions},
+			{Name: "stakePrelaunchMinipools", Run: stakePrelaunchMinipools.run, Trigger: eventTrigger.Subscribe(beacon.EventFinalizedCheckpoint), Group: transactions},
+			{Name: "trackValidatorPerformance", Run: trackValidatorPerformance.run, Trigger: eventTrigger.Subscribe(beacon.EventFinalizedCheckpoint)},
+			{Name: "recordBalanceHistory", Run: recordBalanceHistory.run, Trigger: eventTrigger.Subscribe(beacon.EventFinalizedCheckpoint)},
+			{Name: "manageTransactions", Run: manageTransactions.run, Group: transactions},
+		}
+		for ti, task := range tasks {
+			task.Name, task.ConfigName = services.GetNodeAccountTaskName(w, task.Name), task.Name
+			task.Interval = tasksInterval
+			task.StartDelay = time.Duration(ti) * taskCooldown
+			task.MaxBackoff = maxTaskBackoff
+			if err := taskScheduler.Register(task, cfg.Tasks.Node); err != nil {
+				return err
+			}
+		}
+
+	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(3)
 
-	// Run task loop
+	// Run task loop until shutdown
 	go func() {
-		for {
-			if err := claimRplRewards.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := stakePrelaunchMinipools.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(tasksInterval)
-		}
+		taskScheduler.Run(ctx, shutdownTimeout)
+		wg.Done()
+	}()
+
+	// Trigger tasks from beacon events until shutdown
+	go func() {
+		eventTrigger.Run(ctx, bc, errorLog)
 		wg.Done()
 	}()
 
-	// Run metrics loop
+	// Run metrics loop until shutdown
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor))
+		err := runMetricsServer(ctx, c, log.NewColorLogger(MetricsColor), validatorPerformanceCollectors)
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 
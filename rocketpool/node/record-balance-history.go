@@ -0,0 +1,119 @@
+package node
+
+import (
+	"context"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record balance history task
+type recordBalanceHistory struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	bc        beacon.Client
+	store     *history.Store
+	lastEpoch *uint64
+}
+
+// Create record balance history task
+func newRecordBalanceHistory(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*recordBalanceHistory, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &recordBalanceHistory{
+		c:     c,
+		log:   logger,
+		w:     w,
+		rp:    rp,
+		bc:    bc,
+		store: history.NewStore(w.GetNodeAccountDataPath(cfg.GetBalanceHistoryPath())),
+	}, nil
+
+}
+
+// Record the node's validator balances at the start of the current epoch
+func (t *recordBalanceHistory) run(ctx context.Context) error {
+
+	// Get the last recorded epoch
+	if t.lastEpoch == nil {
+		lastEpoch, _, err := t.store.GetLastEpoch()
+		if err != nil {
+			return err
+		}
+		t.lastEpoch = &lastEpoch
+	}
+
+	// Check for a new epoch
+	head, err := t.bc.GetBeaconHead(ctx)
+	if err != nil {
+		return err
+	}
+	if head.Epoch <= *t.lastEpoch {
+		return nil
+	}
+
+	// Wait for eth clients to sync
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+
+	// Get node validator statuses at the epoch boundary
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if len(pubkeys) == 0 {
+		*t.lastEpoch = head.Epoch
+		return nil
+	}
+	statuses, err := t.bc.GetValidatorStatuses(ctx, pubkeys, &beacon.ValidatorStatusOptions{Epoch: head.Epoch})
+	if err != nil {
+		return err
+	}
+
+	// Record balances
+	balances := make(map[uint64]uint64, len(statuses))
+	for _, status := range statuses {
+		if status.Exists {
+			balances[status.Index] = status.Balance
+		}
+	}
+	if err := t.store.AddSamples(head.Epoch, balances); err != nil {
+		return err
+	}
+	*t.lastEpoch = head.Epoch
+
+	// Return
+	return nil
+
+}
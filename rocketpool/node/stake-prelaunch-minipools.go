@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -42,6 +43,7 @@ type stakePrelaunchMinipools struct {
 	cfg            config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	bc             beacon.Client
 	d              *client.Client
 	gasThreshold   float64
@@ -51,18 +53,18 @@ type stakePrelaunchMinipools struct {
 }
 
 // Create stake prelaunch minipools task
-func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakePrelaunchMinipools, error) {
+func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*stakePrelaunchMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +109,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		bc:             bc,
 		d:              d,
 		gasThreshold:   gasThreshold,
@@ -118,7 +121,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 }
 
 // Stake prelaunch minipools
-func (t *stakePrelaunchMinipools) run() error {
+func (t *stakePrelaunchMinipools) run(ctx context.Context) error {
 
 	// Reload the wallet (in case a call to `node deposit` changed it)
 	if err := t.w.Reload(); err != nil {
@@ -126,7 +129,7 @@ func (t *stakePrelaunchMinipools) run() error {
 	}
 
 	// Wait for eth client to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -149,7 +152,7 @@ func (t *stakePrelaunchMinipools) run() error {
 	}
 
 	// Get eth2 config
-	eth2Config, err := t.bc.GetEth2Config()
+	eth2Config, err := t.bc.GetEth2Config(ctx)
 	if err != nil {
 		return err
 	}
@@ -160,7 +163,7 @@ func (t *stakePrelaunchMinipools) run() error {
 	// Stake minipools
 	successCount := 0
 	for _, mp := range minipools {
-		success, err := t.stakeMinipool(mp, eth2Config)
+		success, err := t.stakeMinipool(ctx, mp, eth2Config)
 		if err != nil {
 			t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mp.Address.Hex(), err))
 			return err
@@ -171,7 +174,8 @@ func (t *stakePrelaunchMinipools) run() error {
 	}
 
 	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
+	// Keys imported through the keymanager API are loaded by the running validator client
+	if successCount > 0 && t.cfg.Smartnode.KeymanagerApi.Url == "" {
 		if err := t.restartValidator(); err != nil {
 			return err
 		}
@@ -256,7 +260,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 }
 
 // Stake a minipool
-func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, eth2Config beacon.Eth2Config) (bool, error) {
+func (t *stakePrelaunchMinipools) stakeMinipool(ctx context.Context, mp *minipool.Minipool, eth2Config beacon.Eth2Config) (bool, error) {
 
 	// Log
 	t.log.Printlnf("Staking minipool %s...", mp.Address.Hex())
@@ -278,7 +282,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, eth2Confi
 	}
 
 	// Get validator deposit data
-	depositData, depositDataRoot, err := validator.GetDepositData(validatorKey, withdrawalCredentials, eth2Config)
+	depositData, depositDataRoot, err := validator.GetDepositData(t.w.GetValidatorSigner(validatorKey), withdrawalCredentials, eth2Config)
 	if err != nil {
 		return false, err
 	}
@@ -305,7 +309,11 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, eth2Confi
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return false, err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return false, err
 		}
@@ -345,7 +353,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mp *minipool.Minipool, eth2Confi
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "stakePrelaunchMinipools", t.log)
 	if err != nil {
 		return false, err
 	}
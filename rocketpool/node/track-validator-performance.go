@@ -0,0 +1,164 @@
+package node
+
+import (
+	"context"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+// Older unchecked epochs are skipped, so catching up after downtime doesn't fetch every block missed
+const MaxPerformanceEpochsPerRun = 8
+
+// Track validator performance task
+type trackValidatorPerformance struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	w       *wallet.Wallet
+	ec      *client.EthClientProxy
+	rp      *rocketpool.RocketPool
+	bc      beacon.Client
+	coll    *collectors.ValidatorPerformanceCollector
+	store   *performance.Store
+	tracker *performance.Tracker
+}
+
+// Create track validator performance task
+func newTrackValidatorPerformance(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet, coll *collectors.ValidatorPerformanceCollector) (*trackValidatorPerformance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClientProxy(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load recorded performance
+	store, err := performance.Load(w.GetNodeAccountDataPath(cfg.GetPerformancePath()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &trackValidatorPerformance{
+		c:     c,
+		log:   logger,
+		w:     w,
+		ec:    ec,
+		rp:    rp,
+		bc:    bc,
+		coll:  coll,
+		store: store,
+	}, nil
+
+}
+
+// Track validator performance
+func (t *trackValidatorPerformance) run(ctx context.Context) error {
+
+	// Wait for eth clients to sync
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+
+	// Create the tracker
+	if t.tracker == nil {
+		eth2Config, err := t.bc.GetEth2Config(ctx)
+		if err != nil {
+			return err
+		}
+		t.tracker = performance.NewTracker(t.bc, eth2Config.SlotsPerEpoch)
+	}
+
+	// Get the epochs to check
+	head, err := t.bc.GetBeaconHead(ctx)
+	if err != nil {
+		return err
+	}
+	if head.Epoch < performance.EpochDelay {
+		return nil
+	}
+	endEpoch := head.Epoch - performance.EpochDelay
+	var startEpoch uint64
+	if !t.store.IsEmpty() {
+		if t.store.LastEpoch >= endEpoch {
+			return nil
+		}
+		startEpoch = t.store.LastEpoch + 1
+	}
+	if endEpoch-startEpoch >= MaxPerformanceEpochsPerRun {
+		startEpoch = endEpoch - MaxPerformanceEpochsPerRun + 1
+	}
+
+	// Get node validator indices
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	indices, err := rp.GetNodeValidatorIndices(ctx, t.rp, t.ec, t.bc, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Checking validator performance for epochs %d to %d...", startEpoch, endEpoch)
+
+	// Check each epoch
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		epochPerformance, err := t.tracker.GetEpochPerformance(ctx, indices, epoch)
+		if err != nil {
+			return err
+		}
+		for index, validator := range epochPerformance {
+			if validator.AttestationsMissed > 0 {
+				t.log.Printlnf("Validator %d missed an attestation in epoch %d.", index, epoch)
+			}
+			if validator.ProposalsMissed > 0 {
+				t.log.Printlnf("Validator %d missed %d block proposal(s) in epoch %d.", index, validator.ProposalsMissed, epoch)
+			}
+		}
+		t.store.AddEpoch(epoch, epochPerformance)
+		if err := t.store.Save(); err != nil {
+			return err
+		}
+	}
+
+	// Update the metrics collector
+	t.coll.UpdateLock.Lock()
+	defer t.coll.UpdateLock.Unlock()
+	for index, validator := range t.store.Validators {
+		t.coll.Validators[index] = *validator
+	}
+	t.coll.LastEpoch = float64(t.store.LastEpoch)
+
+	// Return
+	return nil
+
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/server"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
@@ -107,6 +108,18 @@ func main() {
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.StringFlag{
+			Name:  "account",
+			Usage: "The name of the node account to use, as added with 'wallet add-account' (defaults to the default node account)",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Sign and simulate transactions against the pending block instead of submitting them, printing the signed transactions",
+		},
+		cli.BoolFlag{
+			Name:  "unsigned",
+			Usage: "Return node account transactions unsigned instead of submitting them, to be signed on another machine",
+		},
 		cli.StringFlag{
 			Name:  "metricsAddress, m",
 			Usage: "Address to serve metrics on if enabled",
@@ -122,6 +135,7 @@ func main() {
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
+	server.RegisterCommands(app, "server", []string{"s"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
 
 	// Get command being run
@@ -0,0 +1,340 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/shutdown"
+)
+
+// Config
+const (
+	SocketFileMode = 0660
+	MaxRequestSize = 1 << 20
+	MaxInputSize   = 64 << 20
+
+	ApiPath     = "/api"
+	VersionPath = "/version"
+
+	ServerColor = color.FgHiCyan
+	ErrorColor  = color.FgRed
+)
+
+// Global flags which are set per request rather than inherited from the daemon
+var requestFlags = map[string]bool{
+	"maxFee":     true,
+	"maxPrioFee": true,
+	"gasLimit":   true,
+	"nonce":      true,
+	"account":    true,
+	"dry-run":    true,
+	"unsigned":   true,
+}
+
+// API server
+type apiServer struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	errLog log.ColorLogger
+	token  string
+
+	// API commands share the service singletons and response output, so they are run one at a time
+	lock sync.Mutex
+}
+
+// Register server command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run Rocket Pool API server daemon",
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run daemon
+func run(c *cli.Context) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	shutdownTimeout, err := cfg.GetShutdownTimeout()
+	if err != nil {
+		return err
+	}
+
+	// Check listeners
+	socketPath := os.ExpandEnv(cfg.ApiServer.SocketPath)
+	listenAddress := cfg.ApiServer.ListenAddress
+	if socketPath == "" && listenAddress == "" {
+		return errors.New("No API server socket path or listen address configured")
+	}
+
+	// Initialize server
+	s := &apiServer{
+		c:      c,
+		log:    log.NewColorLogger(ServerColor),
+		errLog: log.NewColorLogger(ErrorColor),
+	}
+
+	// Load the auth token; required for TCP listeners
+	if cfg.ApiServer.TokenPath != "" {
+		tokenBytes, err := ioutil.ReadFile(os.ExpandEnv(cfg.ApiServer.TokenPath))
+		if err != nil {
+			return fmt.Errorf("Could not read API server token: %w", err)
+		}
+		s.token = strings.TrimSpace(string(tokenBytes))
+	}
+	if listenAddress != "" && s.token == "" {
+		return errors.New("An API server token is required to listen on a TCP address")
+	}
+
+	// Create listeners
+	listeners := []net.Listener{}
+	if socketPath != "" {
+		if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Could not remove stale API server socket at %s: %w", socketPath, err)
+		}
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return fmt.Errorf("Could not listen on API server socket at %s: %w", socketPath, err)
+		}
+		if err := os.Chmod(socketPath, SocketFileMode); err != nil {
+			return fmt.Errorf("Could not set API server socket permissions: %w", err)
+		}
+		listeners = append(listeners, listener)
+	}
+	if listenAddress != "" {
+		listener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return fmt.Errorf("Could not listen on API server address %s: %w", listenAddress, err)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	// Stop on interrupt or termination
+	ctx := shutdown.Context(s.errLog)
+
+	// Don't let API command errors with exit codes terminate the daemon
+	cli.OsExiter = func(code int) {}
+
+	// Set up handlers
+	server := &http.Server{Handler: s.handler()}
+
+	// Serve on each listener
+	wg := new(sync.WaitGroup)
+	wg.Add(len(listeners))
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			s.log.Printlnf("Starting API server on %s.", listener.Addr().String())
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				s.errLog.Printlnf("Error running API server on %s: %s", listener.Addr().String(), err.Error())
+			}
+			wg.Done()
+		}(listener)
+	}
+
+	// Stop accepting requests on shutdown, letting commands in progress finish
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.errLog.Printlnf("API server did not stop cleanly: %s", err.Error())
+			server.Close()
+		}
+	}()
+
+	// Wait for all listeners to stop
+	wg.Wait()
+	return nil
+
+}
+
+// Get the server's request handler
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ApiPath, s.handleApi)
+	mux.HandleFunc(VersionPath, s.handleVersion)
+	return s.authorize(mux)
+}
+
+// Check the bearer token of a request if a token is configured
+func (s *apiServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				writeError(w, http.StatusUnauthorized, errors.New("Unauthorized"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handle an API command request
+func (s *apiServer) handleApi(w http.ResponseWriter, r *http.Request) {
+
+	// Check method
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("Method %s not allowed", r.Method))
+		return
+	}
+
+	// Decode request; input data such as slashing protection history may exceed the size of other requests
+	var request api.ServerRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestSize+MaxInputSize)).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Could not decode API request: %w", err))
+		return
+	}
+	if len(request.Args) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("No API command specified"))
+		return
+	}
+
+	// Run the command and return its response
+	response := s.runCommand(request)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+
+}
+
+// Handle a version request
+func (s *apiServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	response := api.ServerVersionResponse{Version: shared.RocketPoolVersion}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(apiutils.EncodeResponse(&response, nil))
+}
+
+// Run an API command through the daemon's command tree and capture its response
+func (s *apiServer) runCommand(request api.ServerRequest) []byte {
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Capture API responses
+	var buffer bytes.Buffer
+	previous := apiutils.SetOutput(&buffer)
+	defer apiutils.SetOutput(previous)
+	previousInput := apiutils.SetInput(strings.NewReader(request.Input))
+	defer apiutils.SetInput(previousInput)
+
+	// Apply the requested gas settings to the node wallet
+	if err := s.applyGasSettings(request); err != nil {
+		apiutils.PrintErrorResponse(err)
+		return buffer.Bytes()
+	}
+
+	// Build the command arguments, inheriting the daemon's global flags
+	args := []string{s.c.App.Name}
+	for _, name := range s.c.GlobalFlagNames() {
+		if requestFlags[name] || !s.c.GlobalIsSet(name) {
+			continue
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, s.c.GlobalString(name)))
+	}
+	args = append(args,
+		fmt.Sprintf("--maxFee=%f", request.MaxFee),
+		fmt.Sprintf("--maxPrioFee=%f", request.MaxPrioFee),
+		fmt.Sprintf("--gasLimit=%d", request.GasLimit))
+	if request.Nonce != "" {
+		args = append(args, fmt.Sprintf("--nonce=%s", request.Nonce))
+	}
+	if request.Account != "" {
+		args = append(args, fmt.Sprintf("--account=%s", request.Account))
+	}
+	if request.DryRun {
+		args = append(args, "--dry-run")
+	}
+	if request.Unsigned {
+		args = append(args, "--unsigned")
+	}
+	args = append(args, "api")
+	args = append(args, request.Args...)
+
+	// Run the command, skipping the daemon's global setup which already ran on startup
+	before := s.c.App.Before
+	s.c.App.Before = nil
+	defer func() { s.c.App.Before = before }()
+	if err := s.c.App.Run(args); err != nil {
+		apiutils.PrintErrorResponse(err)
+	}
+	if buffer.Len() == 0 {
+		apiutils.PrintErrorResponse(fmt.Errorf("API command '%s' did not return a response", strings.Join(request.Args, " ")))
+	}
+	return buffer.Bytes()
+
+}
+
+// Set the wallet's gas settings from the request, falling back to the configured defaults
+func (s *apiServer) applyGasSettings(request api.ServerRequest) error {
+
+	// Get services
+	cfg, err := services.GetConfig(s.c)
+	if err != nil {
+		return err
+	}
+	w, err := services.GetWallet(s.c)
+	if err != nil {
+		return err
+	}
+
+	// Get settings
+	maxFee, err := cfg.GetMaxFee()
+	if err != nil {
+		return err
+	}
+	if request.MaxFee != 0 {
+		maxFee = eth.GweiToWei(request.MaxFee)
+	}
+	maxPriorityFee, err := cfg.GetMaxPriorityFee()
+	if err != nil {
+		return err
+	}
+	if request.MaxPrioFee != 0 {
+		maxPriorityFee = eth.GweiToWei(request.MaxPrioFee)
+	}
+	gasLimit, err := cfg.GetGasLimit()
+	if err != nil {
+		return err
+	}
+	if request.GasLimit != 0 {
+		gasLimit = request.GasLimit
+	}
+
+	// Apply
+	w.SetGasSettings(maxFee, maxPriorityFee, gasLimit)
+	return nil
+
+}
+
+// Write an API error response with a HTTP status code
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(apiutils.EncodeErrorResponse(err))
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestServerRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Write a config shared by the server & client
+	socketPath := filepath.Join(dir, "api.sock")
+	configPath := filepath.Join(dir, rocketpool.GlobalConfigFile)
+	settingsPath := filepath.Join(dir, rocketpool.UserConfigFile)
+	cfg := fmt.Sprintf("smartnode:\n  walletPath: %s\n  passwordPath: %s\nchains:\n  platform:\n    chainID: \"1\"\napiServer:\n  clientUrl: unix://%s\n", filepath.Join(dir, "wallet"), filepath.Join(dir, "password"), socketPath)
+	if err := ioutil.WriteFile(configPath, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(settingsPath, []byte("{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a daemon app with API commands which return the requested node account and read input data
+	input := bytes.Repeat([]byte("a"), MaxRequestSize*2)
+	befores := 0
+	started := make(chan *cli.Context)
+	app := cli.NewApp()
+	app.Name = "rocketpool"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "config"},
+		cli.StringFlag{Name: "settings"},
+		cli.Float64Flag{Name: "maxFee"},
+		cli.Float64Flag{Name: "maxPrioFee"},
+		cli.Uint64Flag{Name: "gasLimit"},
+		cli.StringFlag{Name: "nonce"},
+		cli.StringFlag{Name: "account"},
+		cli.BoolFlag{Name: "dry-run"},
+		cli.BoolFlag{Name: "unsigned"},
+	}
+	app.Before = func(c *cli.Context) error {
+		befores++
+		return nil
+	}
+	app.Commands = []cli.Command{
+		{
+			Name: "server",
+			Action: func(c *cli.Context) error {
+				started <- c
+				<-started
+				return nil
+			},
+		},
+		{
+			Name: "api",
+			Subcommands: []cli.Command{
+				{
+					Name: "wallet",
+					Subcommands: []cli.Command{
+						{
+							Name: "import-slashing-protection",
+							Action: func(c *cli.Context) error {
+								data, err := apiutils.ReadInput()
+								if err == nil && len(data) != len(input) {
+									err = fmt.Errorf("Read %d bytes of input, expected %d", len(data), len(input))
+								}
+								apiutils.PrintResponse(&api.ImportSlashingProtectionResponse{}, err)
+								return nil
+							},
+						},
+						{
+							Name: "status",
+							Action: func(c *cli.Context) error {
+								apiutils.PrintResponse(&api.WalletStatusResponse{AccountName: c.GlobalString("account")}, nil)
+								return nil
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	go app.Run([]string{"rocketpool", "--config", configPath, "--settings", settingsPath, "server"})
+	c := <-started
+	defer close(started)
+
+	// Serve on a socket
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &apiServer{c: c, log: log.NewColorLogger(ServerColor), errLog: log.NewColorLogger(ErrorColor)}
+	server := &http.Server{Handler: s.handler()}
+	go server.Serve(listener)
+	defer server.Close()
+
+	// Run a command through the client
+	rp, err := rocketpool.NewClient(dir, "", "", "", "", "", "", 0, 0, 0, "", "operator", false, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := rp.WalletStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "success" || status.AccountName != "operator" {
+		t.Errorf("Unexpected API response %+v", status)
+	}
+
+	// Input data larger than other requests is passed to the command
+	if _, err := rp.ImportSlashingProtection(input); err != nil {
+		t.Error(err)
+	}
+
+	// The global setup only ran when the daemon started
+	if befores != 1 {
+		t.Errorf("Expected the app's Before to run once, ran %d times", befores)
+	}
+}
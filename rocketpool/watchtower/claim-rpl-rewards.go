@@ -1,6 +1,7 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -26,6 +28,7 @@ type claimRplRewards struct {
 	cfg            config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	gasThreshold   float64
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -33,18 +36,18 @@ type claimRplRewards struct {
 }
 
 // Create claim RPL rewards task
-func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplRewards, error) {
+func newClaimRplRewards(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*claimRplRewards, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +87,7 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
@@ -93,7 +97,7 @@ func newClaimRplRewards(c *cli.Context, logger log.ColorLogger) (*claimRplReward
 }
 
 // Claim RPL rewards
-func (t *claimRplRewards) run() error {
+func (t *claimRplRewards) run(ctx context.Context) error {
 
 	// Check to see if autoclaim is disabled
 	if t.gasThreshold == 0 {
@@ -101,7 +105,7 @@ func (t *claimRplRewards) run() error {
 	}
 
 	// Wait for eth client to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -156,7 +160,11 @@ func (t *claimRplRewards) run() error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -178,7 +186,7 @@ func (t *claimRplRewards) run() error {
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "claimRplRewards", t.log)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the withdrawal processing metrics
+type WithdrawalsCollector struct {
+
+	// The number of minipools in withdrawable status
+	withdrawableMinipoolsDesc *prometheus.Desc
+
+	// The number of withdrawable minipools whose validator balances have been returned and need processing
+	pendingWithdrawalsDesc *prometheus.Desc
+
+	// The total number of withdrawals processed by this node
+	processedWithdrawalsDesc *prometheus.Desc
+
+	// The total number of withdrawals this node failed to process
+	failedWithdrawalsDesc *prometheus.Desc
+
+	// Counters
+	WithdrawableMinipools float64
+	PendingWithdrawals    float64
+	ProcessedWithdrawals  float64
+	FailedWithdrawals     float64
+
+	// Mutex
+	UpdateLock sync.Mutex
+}
+
+// Create a new WithdrawalsCollector instance
+func NewWithdrawalsCollector() *WithdrawalsCollector {
+	subsystem := "withdrawals"
+	return &WithdrawalsCollector{
+		withdrawableMinipoolsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "withdrawable_minipools"),
+			"The number of minipools in withdrawable status",
+			nil, nil,
+		),
+		pendingWithdrawalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "pending"),
+			"The number of withdrawable minipools whose validator balances have been returned and need processing",
+			nil, nil,
+		),
+		processedWithdrawalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "processed_total"),
+			"The total number of withdrawals processed by this node",
+			nil, nil,
+		),
+		failedWithdrawalsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failed_total"),
+			"The total number of withdrawals this node failed to process",
+			nil, nil,
+		),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *WithdrawalsCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.withdrawableMinipoolsDesc
+	channel <- collector.pendingWithdrawalsDesc
+	channel <- collector.processedWithdrawalsDesc
+	channel <- collector.failedWithdrawalsDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *WithdrawalsCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.withdrawableMinipoolsDesc, prometheus.GaugeValue, collector.WithdrawableMinipools)
+	channel <- prometheus.MustNewConstMetric(
+		collector.pendingWithdrawalsDesc, prometheus.GaugeValue, collector.PendingWithdrawals)
+	channel <- prometheus.MustNewConstMetric(
+		collector.processedWithdrawalsDesc, prometheus.CounterValue, collector.ProcessedWithdrawals)
+	channel <- prometheus.MustNewConstMetric(
+		collector.failedWithdrawalsDesc, prometheus.CounterValue, collector.FailedWithdrawals)
+
+}
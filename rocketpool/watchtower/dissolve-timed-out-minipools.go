@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -36,28 +37,29 @@ type dissolveTimedOutMinipools struct {
 	w              *wallet.Wallet
 	ec             *client.EthClientProxy
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 }
 
 // Create dissolve timed out minipools task
-func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*dissolveTimedOutMinipools, error) {
+func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*dissolveTimedOutMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClientProxy(c)
 	if err != nil {
 		return nil, err
 	}
-	ec, err := services.GetEthClientProxy(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +94,7 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 		w:              w,
 		ec:             ec,
 		rp:             rp,
+		txm:            txm,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
 		gasLimit:       gasLimit,
@@ -100,10 +103,10 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 }
 
 // Dissolve timed out minipools
-func (t *dissolveTimedOutMinipools) run() error {
+func (t *dissolveTimedOutMinipools) run(ctx context.Context) error {
 
 	// Wait for eth client to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -139,7 +142,7 @@ func (t *dissolveTimedOutMinipools) run() error {
 
 	// Dissolve minipools
 	for _, mp := range minipools {
-		if err := t.dissolveMinipool(mp); err != nil {
+		if err := t.dissolveMinipool(ctx, mp); err != nil {
 			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.Address.Hex(), err))
 		}
 	}
@@ -239,7 +242,7 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools() ([]*minipool.Minipool
 }
 
 // Dissolve a minipool
-func (t *dissolveTimedOutMinipools) dissolveMinipool(mp *minipool.Minipool) error {
+func (t *dissolveTimedOutMinipools) dissolveMinipool(ctx context.Context, mp *minipool.Minipool) error {
 
 	// Log
 	t.log.Printlnf("Dissolving minipool %s...", mp.Address.Hex())
@@ -265,7 +268,11 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp *minipool.Minipool) erro
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -287,7 +294,7 @@ func (t *dissolveTimedOutMinipools) dissolveMinipool(mp *minipool.Minipool) erro
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "dissolveTimedOutMinipools", t.log)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -8,11 +9,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector) error {
+// Metrics collectors for a node account
+type nodeAccountCollectors struct {
+	scrub       *collectors.ScrubCollector
+	withdrawals *collectors.WithdrawalsCollector
+}
+
+func runMetricsServer(ctx context.Context, c *cli.Context, logger log.ColorLogger, accountCollectors map[*wallet.Wallet]nodeAccountCollectors) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -25,9 +33,17 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 		return nil
 	}
 
-	// Set up Prometheus
+	// Set up Prometheus, labelling each node account's collectors with its address
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(scrubCollector)
+	for w, ac := range accountCollectors {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return fmt.Errorf("Error getting node account: %w", err)
+		}
+		accountRegistry := prometheus.WrapRegistererWith(prometheus.Labels{"node": nodeAccount.Address.Hex()}, registry)
+		accountRegistry.MustRegister(ac.scrub)
+		accountRegistry.MustRegister(ac.withdrawals)
+	}
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -46,8 +62,21 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
             </html>`,
 		))
 	})
-	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
-	if err != nil {
+	server := &http.Server{Addr: fmt.Sprintf("%s:%d", metricsAddress, metricsPort)}
+
+	// Stop the HTTP server on shutdown
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-stopped:
+		}
+	}()
+
+	err = server.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("Error running HTTP server: %w", err)
 	}
 
@@ -1,27 +1,63 @@
 package watchtower
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/client"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+// Settings
+const DistributeBalanceOwnerWindow = 14 * 24 * time.Hour
+const MinNonOwnerDistributeBalance = 4 // ETH
+
 // Process withdrawals task
 type processWithdrawals struct {
-	c   *cli.Context
-	log log.ColorLogger
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            config.RocketPoolConfig
+	w              *wallet.Wallet
+	ec             *client.EthClientProxy
+	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
+	bc             beacon.Client
+	coll           *collectors.WithdrawalsCollector
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
 }
 
 // Create process withdrawals task
-func newProcessWithdrawals(c *cli.Context, logger log.ColorLogger) (*processWithdrawals, error) {
+func newProcessWithdrawals(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet, coll *collectors.WithdrawalsCollector) (*processWithdrawals, error) {
 
 	// Get services
-	w, err := services.GetWallet(c)
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClientProxy(c)
 	if err != nil {
 		return nil, err
 	}
@@ -29,24 +65,375 @@ func newProcessWithdrawals(c *cli.Context, logger log.ColorLogger) (*processWith
 	if err != nil {
 		return nil, err
 	}
+	txm, err := services.GetTransactionManager(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFee, err := cfg.GetMaxFee()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting max fee in configuration: %w", err)
+	}
+
+	// Get the user-requested max fee
+	maxPriorityFee, err := cfg.GetMaxPriorityFee()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting max priority fee in configuration: %w", err)
+	}
+	if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		maxPriorityFee = big.NewInt(2)
+	}
+
+	// Get the user-requested gas limit
+	gasLimit, err := cfg.GetGasLimit()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting gas limit in configuration: %w", err)
+	}
 
 	// Return task
 	return &processWithdrawals{
-		c:   c,
-		log: logger,
-		w:   w,
-		rp:  rp,
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		ec:             ec,
+		rp:             rp,
+		txm:            txm,
+		bc:             bc,
+		coll:           coll,
+		maxFee:         maxFee,
+		maxPriorityFee: maxPriorityFee,
+		gasLimit:       gasLimit,
 	}, nil
 
 }
 
 // Process withdrawals
-func (t *processWithdrawals) run() error {
+func (t *processWithdrawals) run(ctx context.Context) error {
+
+	// Wait for eth clients to sync
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if !nodeTrusted {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for withdrawn minipools to process...")
+
+	// Get withdrawn minipools
+	withdrawableCount, minipools, err := t.getWithdrawnMinipools(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Process withdrawals
-	// TODO: implement
+	processedCount := 0
+	failedCount := 0
+	if len(minipools) > 0 {
+		t.log.Printlnf("%d minipool(s) have withdrawn balances to process...", len(minipools))
+		for _, mp := range minipools {
+			processed, err := t.processWithdrawal(ctx, mp, nodeAccount.Address)
+			if err != nil {
+				t.log.Println(fmt.Errorf("Could not process withdrawal for minipool %s: %w", mp.Address.Hex(), err))
+				failedCount++
+			} else if processed {
+				processedCount++
+			}
+		}
+	}
+
+	// Update the metrics collector
+	if t.coll != nil {
+		t.coll.UpdateLock.Lock()
+		defer t.coll.UpdateLock.Unlock()
+
+		t.coll.WithdrawableMinipools = float64(withdrawableCount)
+		t.coll.PendingWithdrawals = float64(len(minipools))
+		t.coll.ProcessedWithdrawals += float64(processedCount)
+		t.coll.FailedWithdrawals += float64(failedCount)
+	}
 
 	// Return
 	return nil
 
 }
+
+// Get the number of withdrawable minipools, and those whose validator balances have been returned to the minipool
+func (t *processWithdrawals) getWithdrawnMinipools(ctx context.Context) (int, []*minipool.Minipool, error) {
+
+	// Data
+	var wg1 errgroup.Group
+	var addresses []common.Address
+	var beaconHead beacon.BeaconHead
+
+	// Get minipool addresses
+	wg1.Go(func() error {
+		var err error
+		addresses, err = minipool.GetMinipoolAddresses(t.rp, nil)
+		return err
+	})
+
+	// Get beacon head
+	wg1.Go(func() error {
+		var err error
+		beaconHead, err = t.bc.GetBeaconHead(ctx)
+		return err
+	})
+
+	// Wait for data
+	if err := wg1.Wait(); err != nil {
+		return 0, []*minipool.Minipool{}, err
+	}
+
+	// Create minipool contracts
+	minipools := make([]*minipool.Minipool, len(addresses))
+	for mi, address := range addresses {
+		mp, err := minipool.NewMinipool(t.rp, address)
+		if err != nil {
+			return 0, []*minipool.Minipool{}, err
+		}
+		minipools[mi] = mp
+	}
+
+	// Load minipool statuses in batches
+	statuses := make([]rptypes.MinipoolStatus, len(minipools))
+	for bsi := 0; bsi < len(minipools); bsi += MinipoolStatusBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolStatusBatchSize
+		if mei > len(minipools) {
+			mei = len(minipools)
+		}
+
+		// Load statuses
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				status, err := minipools[mi].GetStatus(nil)
+				if err == nil {
+					statuses[mi] = status
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return 0, []*minipool.Minipool{}, err
+		}
+
+	}
+
+	// Filter minipools by withdrawable status
+	withdrawableMinipools := []*minipool.Minipool{}
+	withdrawableAddresses := []common.Address{}
+	for mi, mp := range minipools {
+		if statuses[mi] == rptypes.Withdrawable {
+			withdrawableMinipools = append(withdrawableMinipools, mp)
+			withdrawableAddresses = append(withdrawableAddresses, mp.Address)
+		}
+	}
+	if len(withdrawableMinipools) == 0 {
+		return 0, []*minipool.Minipool{}, nil
+	}
+
+	// Get minipool validator statuses
+	validators, err := rp.GetMinipoolValidators(ctx, t.rp, t.bc, withdrawableAddresses, nil, nil)
+	if err != nil {
+		return 0, []*minipool.Minipool{}, err
+	}
+
+	// Load minipool contract balances in batches
+	balances := make([]*big.Int, len(withdrawableMinipools))
+	for bsi := 0; bsi < len(withdrawableMinipools); bsi += MinipoolStatusBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolStatusBatchSize
+		if mei > len(withdrawableMinipools) {
+			mei = len(withdrawableMinipools)
+		}
+
+		// Load balances
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				balance, err := t.ec.BalanceAt(ctx, withdrawableMinipools[mi].Address, nil)
+				if err == nil {
+					balances[mi] = balance
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return 0, []*minipool.Minipool{}, err
+		}
+
+	}
+
+	// Filter minipools whose validators have reached their withdrawable epoch and whose balances have been returned
+	withdrawnMinipools := []*minipool.Minipool{}
+	for mi, mp := range withdrawableMinipools {
+		validator := validators[mp.Address]
+		if !validator.Exists || validator.WithdrawableEpoch > beaconHead.Epoch {
+			continue
+		}
+		if balances[mi].Cmp(big.NewInt(0)) == 0 {
+			continue
+		}
+		withdrawnMinipools = append(withdrawnMinipools, mp)
+	}
+
+	// Return
+	return len(withdrawableMinipools), withdrawnMinipools, nil
+
+}
+
+// Check whether the node may distribute a minipool's balance yet
+// The minipool's owner may distribute it at any time; other nodes must wait for the owner-only window to pass
+func (t *processWithdrawals) canDistributeBalance(ctx context.Context, mp *minipool.Minipool, nodeAddress common.Address) (bool, error) {
+
+	// Check if the balance has already been distributed
+	finalised, err := mp.GetFinalised(nil)
+	if err != nil {
+		return false, err
+	}
+	if finalised {
+		return false, nil
+	}
+
+	// Check if the node owns the minipool
+	ownerAddress, err := mp.GetNodeAddress(nil)
+	if err != nil {
+		return false, err
+	}
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(t.rp, ownerAddress, nil)
+	if err != nil {
+		return false, err
+	}
+	if nodeAddress == ownerAddress || nodeAddress == withdrawalAddress {
+		return true, nil
+	}
+
+	// Check if the owner-only window has passed
+	statusTime, err := mp.GetStatusTime(nil)
+	if err != nil {
+		return false, err
+	}
+	header, err := t.ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	if !time.Unix(int64(header.Time), 0).After(statusTime.Add(DistributeBalanceOwnerWindow)) {
+		return false, nil
+	}
+
+	// Check the minipool balance
+	balance, err := t.ec.BalanceAt(ctx, mp.Address, nil)
+	if err != nil {
+		return false, err
+	}
+	return balance.Cmp(eth.EthToWei(MinNonOwnerDistributeBalance)) >= 0, nil
+
+}
+
+// Process a minipool's withdrawal by distributing its balance
+// Returns false if the withdrawal was skipped
+func (t *processWithdrawals) processWithdrawal(ctx context.Context, mp *minipool.Minipool, nodeAddress common.Address) (bool, error) {
+
+	// Check if the node can distribute the balance
+	canDistribute, err := t.canDistributeBalance(ctx, mp, nodeAddress)
+	if err != nil {
+		return false, fmt.Errorf("Could not check if the minipool balance can be distributed: %w", err)
+	}
+	if !canDistribute {
+		t.log.Printlnf("Minipool %s balance cannot be distributed by this node yet, skipping...", mp.Address.Hex())
+		return false, nil
+	}
+
+	// Log
+	t.log.Printlnf("Processing withdrawal for minipool %s...", mp.Address.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := mp.EstimateDistributeBalanceGas(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to process the withdrawal: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return false, err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Distribute balance
+	hash, err := mp.DistributeBalance(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be mined
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "processWithdrawals", t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully processed withdrawal for minipool %s.", mp.Address.Hex())
+
+	// Return
+	return true, nil
+
+}
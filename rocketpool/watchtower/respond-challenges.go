@@ -1,6 +1,7 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -23,24 +25,25 @@ type respondChallenges struct {
 	cfg            config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 }
 
 // Create respond to challenges task
-func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChallenges, error) {
+func newRespondChallenges(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*respondChallenges, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +77,7 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChall
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
 		gasLimit:       gasLimit,
@@ -82,10 +86,10 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChall
 }
 
 // Respond to challenges
-func (t *respondChallenges) run() error {
+func (t *respondChallenges) run(ctx context.Context) error {
 
 	// Wait for eth client to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -140,7 +144,11 @@ func (t *respondChallenges) run() error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -162,7 +170,7 @@ func (t *respondChallenges) run() error {
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "respondChallenges", t.log)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
@@ -46,6 +47,7 @@ type submitNetworkBalances struct {
 	w              *wallet.Wallet
 	ec             *client.EthClientProxy
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	bc             beacon.Client
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -67,22 +69,22 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClientProxy(c)
 	if err != nil {
 		return nil, err
 	}
-	ec, err := services.GetEthClientProxy(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +123,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 		w:              w,
 		ec:             ec,
 		rp:             rp,
+		txm:            txm,
 		bc:             bc,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
@@ -130,13 +133,13 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger) (*submitNe
 }
 
 // Submit network balances
-func (t *submitNetworkBalances) run() error {
+func (t *submitNetworkBalances) run(ctx context.Context) error {
 
 	// Wait for eth clients to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
-	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -183,7 +186,7 @@ func (t *submitNetworkBalances) run() error {
 	}
 
 	// Allow some blocks to pass in case of a short reorg
-	currentBlockNumber, err := t.ec.BlockNumber(context.Background())
+	currentBlockNumber, err := t.ec.BlockNumber(ctx)
 	if err != nil {
 		return err
 	}
@@ -215,7 +218,7 @@ func (t *submitNetworkBalances) run() error {
 	t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
 
 	// Get network balances at block
-	balances, err := t.getNetworkBalances(blockNumber)
+	balances, err := t.getNetworkBalances(ctx, blockNumber)
 	if err != nil {
 		return err
 	}
@@ -249,7 +252,7 @@ func (t *submitNetworkBalances) run() error {
 	t.log.Println("Submitting balances...")
 
 	// Submit balances
-	if err := t.submitBalances(balances); err != nil {
+	if err := t.submitBalances(ctx, balances); err != nil {
 		return fmt.Errorf("Could not submit network balances: %w", err)
 	}
 
@@ -309,7 +312,7 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 }
 
 // Get the network balances at a specific block
-func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkBalances, error) {
+func (t *submitNetworkBalances) getNetworkBalances(ctx context.Context, blockNumber uint64) (networkBalances, error) {
 
 	// Initialize call options
 	opts := &bind.CallOpts{
@@ -333,7 +336,7 @@ func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkB
 	// Get minipool balance details
 	wg.Go(func() error {
 		var err error
-		minipoolBalanceDetails, err = t.getNetworkMinipoolBalanceDetails(opts)
+		minipoolBalanceDetails, err = t.getNetworkMinipoolBalanceDetails(ctx, opts)
 		return err
 	})
 
@@ -343,7 +346,7 @@ func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkB
 		if err != nil {
 			return err
 		}
-		rethContractBalance, err = t.ec.BalanceAt(context.Background(), *rethContractAddress, opts.BlockNumber)
+		rethContractBalance, err = t.ec.BalanceAt(ctx, *rethContractAddress, opts.BlockNumber)
 		return err
 	})
 
@@ -383,7 +386,7 @@ func (t *submitNetworkBalances) getNetworkBalances(blockNumber uint64) (networkB
 }
 
 // Get all minipool balance details
-func (t *submitNetworkBalances) getNetworkMinipoolBalanceDetails(opts *bind.CallOpts) ([]minipoolBalanceDetails, error) {
+func (t *submitNetworkBalances) getNetworkMinipoolBalanceDetails(ctx context.Context, opts *bind.CallOpts) ([]minipoolBalanceDetails, error) {
 
 	// Data
 	var wg1 errgroup.Group
@@ -402,20 +405,20 @@ func (t *submitNetworkBalances) getNetworkMinipoolBalanceDetails(opts *bind.Call
 	// Get eth2 config
 	wg1.Go(func() error {
 		var err error
-		eth2Config, err = t.bc.GetEth2Config()
+		eth2Config, err = t.bc.GetEth2Config(ctx)
 		return err
 	})
 
 	// Get beacon head
 	wg1.Go(func() error {
 		var err error
-		beaconHead, err = t.bc.GetBeaconHead()
+		beaconHead, err = t.bc.GetBeaconHead(ctx)
 		return err
 	})
 
 	// Get block time
 	wg1.Go(func() error {
-		header, err := t.ec.HeaderByNumber(context.Background(), opts.BlockNumber)
+		header, err := t.ec.HeaderByNumber(ctx, opts.BlockNumber)
 		if err == nil {
 			blockTime = header.Time
 		}
@@ -434,7 +437,7 @@ func (t *submitNetworkBalances) getNetworkMinipoolBalanceDetails(opts *bind.Call
 	}
 
 	// Get minipool validator statuses
-	validators, err := rp.GetMinipoolValidators(t.rp, t.bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: blockEpoch})
+	validators, err := rp.GetMinipoolValidators(ctx, t.rp, t.bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: blockEpoch})
 	if err != nil {
 		return []minipoolBalanceDetails{}, err
 	}
@@ -584,7 +587,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(minipoolAddress common
 }
 
 // Submit network balances
-func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
+func (t *submitNetworkBalances) submitBalances(ctx context.Context, balances networkBalances) error {
 
 	// Log
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
@@ -616,7 +619,11 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -638,7 +645,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "submitNetworkBalances", t.log)
 	if err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -40,6 +41,7 @@ type submitRplPrice struct {
 	ec             *client.EthClientProxy
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	oio            *contracts.OneInchOracle
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -47,22 +49,22 @@ type submitRplPrice struct {
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClientProxy(c)
 	if err != nil {
 		return nil, err
 	}
-	ec, err := services.GetEthClientProxy(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +103,7 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 		ec:             ec,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		oio:            oio,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
@@ -110,10 +113,10 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger) (*submitRplPrice,
 }
 
 // Submit RPL price
-func (t *submitRplPrice) run() error {
+func (t *submitRplPrice) run(ctx context.Context) error {
 
 	// Wait for eth client to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -160,7 +163,7 @@ func (t *submitRplPrice) run() error {
 	}
 
 	// Allow some blocks to pass in case of a short reorg
-	currentBlockNumber, err := t.ec.BlockNumber(context.Background())
+	currentBlockNumber, err := t.ec.BlockNumber(ctx)
 	if err != nil {
 		return err
 	}
@@ -229,7 +232,7 @@ func (t *submitRplPrice) run() error {
 	t.log.Println("Submitting RPL price...")
 
 	// Submit RPL price
-	if err := t.submitRplPrice(blockNumber, rplPrice, effectiveRplStake); err != nil {
+	if err := t.submitRplPrice(ctx, blockNumber, rplPrice, effectiveRplStake); err != nil {
 		return fmt.Errorf("Could not submit RPL price: %w", err)
 	}
 
@@ -307,7 +310,7 @@ func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
 }
 
 // Submit RPL price and total effective RPL stake
-func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveRplStake *big.Int) error {
+func (t *submitRplPrice) submitRplPrice(ctx context.Context, blockNumber uint64, rplPrice, effectiveRplStake *big.Int) error {
 
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
@@ -333,7 +336,11 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -355,7 +362,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "submitRplPrice", t.log)
 	if err != nil {
 		return err
 	}
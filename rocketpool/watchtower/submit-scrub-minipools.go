@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -44,6 +45,7 @@ type submitScrubMinipools struct {
 	cfg            config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	ec             *client.EthClientProxy
 	bc             beacon.Client
 	it             *iterationData
@@ -81,22 +83,22 @@ type minipoolDetails struct {
 }
 
 // Create submit scrub minipools task
-func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
+func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	ec, err := services.GetEthClientProxy(c)
 	if err != nil {
 		return nil, err
 	}
-	ec, err := services.GetEthClientProxy(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +136,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, coll *colle
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		ec:             ec,
 		bc:             bc,
 		coll:           coll,
@@ -145,13 +148,13 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, coll *colle
 }
 
 // Submit scrub minipools
-func (t *submitScrubMinipools) run() error {
+func (t *submitScrubMinipools) run(ctx context.Context) error {
 
 	// Wait for eth clients to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
-	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -190,7 +193,7 @@ func (t *submitScrubMinipools) run() error {
 	pubkeys := t.initializeMinipoolDetails(minipoolAddresses)
 
 	// Step 1: Verify the Beacon credentials if they exist
-	err = t.verifyBeaconWithdrawalCredentials(pubkeys)
+	err = t.verifyBeaconWithdrawalCredentials(ctx, pubkeys)
 	if err != nil {
 		return err
 	}
@@ -202,13 +205,13 @@ func (t *submitScrubMinipools) run() error {
 	}
 
 	// Get various elements needed to do eth1 prestake and deposit contract searches
-	err = t.getEth1SearchArtifacts()
+	err = t.getEth1SearchArtifacts(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Step 2: Verify the MinipoolPrestaked events
-	t.verifyPrestakeEvents()
+	t.verifyPrestakeEvents(ctx)
 
 	// If there aren't any minipools left to check, print the final tally and exit
 	if len(t.it.minipools) == 0 {
@@ -217,7 +220,7 @@ func (t *submitScrubMinipools) run() error {
 	}
 
 	// Step 3: Verify the deposit data of the remaining minipools
-	err = t.verifyDeposits()
+	err = t.verifyDeposits(ctx)
 	if err != nil {
 		return err
 	}
@@ -229,7 +232,7 @@ func (t *submitScrubMinipools) run() error {
 	}
 
 	// Step 4: Scrub all of the undeposited minipools after half the scrub period for safety
-	err = t.checkSafetyScrub()
+	err = t.checkSafetyScrub(ctx)
 	if err != nil {
 		return err
 	}
@@ -281,12 +284,12 @@ func (t *submitScrubMinipools) initializeMinipoolDetails(minipoolAddresses []com
 }
 
 // Step 1: Verify the Beacon Chain credentials for a minipool if they're present
-func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types.ValidatorPubkey) error {
+func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(ctx context.Context, pubkeys []types.ValidatorPubkey) error {
 
 	minipoolsToScrub := []*minipool.Minipool{}
 
 	// Get the status of the validators on the Beacon chain
-	statuses, err := t.bc.GetValidatorStatuses(pubkeys, nil)
+	statuses, err := t.bc.GetValidatorStatuses(ctx, pubkeys, nil)
 	if err != nil {
 		return err
 	}
@@ -321,7 +324,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err = t.submitVoteScrubMinipool(minipool)
+		err = t.submitVoteScrubMinipool(ctx, minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
 		}
@@ -332,27 +335,27 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(pubkeys []types
 }
 
 // Get various elements needed to do eth1 prestake and deposit contract searches
-func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
+func (t *submitScrubMinipools) getEth1SearchArtifacts(ctx context.Context) error {
 
 	// Get the starting eth1 block to search from
 	/*
-	   data, err := t.bc.GetEth1DataForEth2Block("finalized")
+	   data, err := t.bc.GetEth1DataForEth2Block(ctx, "finalized")
 	   if err != nil {
 	       return nil, err
 	   }
 
-	   latestEth1Block, err := t.ec.BlockByHash(context.Background(), data.BlockHash)
+	   latestEth1Block, err := t.ec.BlockByHash(ctx, data.BlockHash)
 	   if err != nil {
 	       return nil, err
 	   }
 	*/
-	latestEth1Block, err := t.ec.HeaderByNumber(context.Background(), nil)
+	latestEth1Block, err := t.ec.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return err
 	}
 	t.it.latestBlockTime = time.Unix(int64(latestEth1Block.Time), 0)
 	targetBlockNumber := big.NewInt(0).Sub(latestEth1Block.Number, big.NewInt(BlockStartOffset))
-	targetBlock, err := t.ec.HeaderByNumber(context.Background(), targetBlockNumber)
+	targetBlock, err := t.ec.HeaderByNumber(ctx, targetBlockNumber)
 	if err != nil {
 		return err
 	}
@@ -366,7 +369,7 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
 	t.it.eventLogInterval = eventLogInterval
 
 	// Put together the signature validation data
-	eth2Config, err := t.bc.GetEth2Config()
+	eth2Config, err := t.bc.GetEth2Config(ctx)
 	if err != nil {
 		return err
 	}
@@ -381,7 +384,7 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts() error {
 }
 
 // Step 2: Verify the MinipoolPrestaked event of each minipool
-func (t *submitScrubMinipools) verifyPrestakeEvents() {
+func (t *submitScrubMinipools) verifyPrestakeEvents(ctx context.Context) {
 
 	minipoolsToScrub := []*minipool.Minipool{}
 
@@ -425,7 +428,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(ctx, minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
 		}
@@ -436,7 +439,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 }
 
 // Step 3: Verify minipools by their deposits
-func (t *submitScrubMinipools) verifyDeposits() error {
+func (t *submitScrubMinipools) verifyDeposits(ctx context.Context) error {
 
 	minipoolsToScrub := []*minipool.Minipool{}
 
@@ -505,7 +508,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(ctx, minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
 		}
@@ -517,7 +520,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 
 // Step 4: Catch-all safety mechanism that scrubs minipools without valid deposits after a certain period of time
 // This should never be used, it's simply here as a redundant check
-func (t *submitScrubMinipools) checkSafetyScrub() error {
+func (t *submitScrubMinipools) checkSafetyScrub(ctx context.Context) error {
 
 	minipoolsToScrub := []*minipool.Minipool{}
 
@@ -572,7 +575,7 @@ func (t *submitScrubMinipools) checkSafetyScrub() error {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(ctx, minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.Address.Hex(), err.Error())
 		}
@@ -583,7 +586,7 @@ func (t *submitScrubMinipools) checkSafetyScrub() error {
 }
 
 // Submit minipool scrub status
-func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) error {
+func (t *submitScrubMinipools) submitVoteScrubMinipool(ctx context.Context, mp *minipool.Minipool) error {
 
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.Address.Hex())
@@ -609,7 +612,11 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -631,7 +638,7 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp *minipool.Minipool) er
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "submitScrubMinipools", t.log)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
@@ -37,6 +38,7 @@ type submitWithdrawableMinipools struct {
 	cfg            config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	txm            *transactions.Manager
 	bc             beacon.Client
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -52,18 +54,18 @@ type minipoolWithdrawableDetails struct {
 }
 
 // Create submit withdrawable minipools task
-func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*submitWithdrawableMinipools, error) {
+func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger, w *wallet.Wallet) (*submitWithdrawableMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	txm, err := services.GetTransactionManager(c)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +103,7 @@ func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*su
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		txm:            txm,
 		bc:             bc,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
@@ -110,13 +113,13 @@ func newSubmitWithdrawableMinipools(c *cli.Context, logger log.ColorLogger) (*su
 }
 
 // Submit withdrawable minipools
-func (t *submitWithdrawableMinipools) run() error {
+func (t *submitWithdrawableMinipools) run(ctx context.Context) error {
 
 	// Wait for eth clients to sync
-	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+	if err := services.WaitEthClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
-	if err := services.WaitBeaconClientSynced(t.c, true); err != nil {
+	if err := services.WaitBeaconClientSynced(ctx, t.c, true); err != nil {
 		return err
 	}
 
@@ -157,7 +160,7 @@ func (t *submitWithdrawableMinipools) run() error {
 	t.log.Println("Checking for withdrawable minipools...")
 
 	// Get minipool withdrawable details
-	minipools, err := t.getNetworkMinipoolWithdrawableDetails(nodeAccount.Address)
+	minipools, err := t.getNetworkMinipoolWithdrawableDetails(ctx, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
@@ -170,7 +173,7 @@ func (t *submitWithdrawableMinipools) run() error {
 
 	// Submit minipools withdrawable status
 	for _, details := range minipools {
-		if err := t.submitWithdrawableMinipool(details); err != nil {
+		if err := t.submitWithdrawableMinipool(ctx, details); err != nil {
 			t.log.Println(fmt.Errorf("Could not submit minipool %s withdrawable status: %w", details.Address.Hex(), err))
 		}
 	}
@@ -181,7 +184,7 @@ func (t *submitWithdrawableMinipools) run() error {
 }
 
 // Get all minipool withdrawable details
-func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(nodeAddress common.Address) ([]minipoolWithdrawableDetails, error) {
+func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(ctx context.Context, nodeAddress common.Address) ([]minipoolWithdrawableDetails, error) {
 
 	// Data
 	var wg1 errgroup.Group
@@ -199,14 +202,14 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
 	// Get eth2 config
 	wg1.Go(func() error {
 		var err error
-		eth2Config, err = t.bc.GetEth2Config()
+		eth2Config, err = t.bc.GetEth2Config(ctx)
 		return err
 	})
 
 	// Get beacon head
 	wg1.Go(func() error {
 		var err error
-		beaconHead, err = t.bc.GetBeaconHead()
+		beaconHead, err = t.bc.GetBeaconHead(ctx)
 		return err
 	})
 
@@ -216,7 +219,7 @@ func (t *submitWithdrawableMinipools) getNetworkMinipoolWithdrawableDetails(node
 	}
 
 	// Get minipool validator statuses
-	validators, err := rp.GetMinipoolValidators(t.rp, t.bc, addresses, nil, nil)
+	validators, err := rp.GetMinipoolValidators(ctx, t.rp, t.bc, addresses, nil, nil)
 	if err != nil {
 		return []minipoolWithdrawableDetails{}, err
 	}
@@ -379,7 +382,7 @@ func (t *submitWithdrawableMinipools) getMinipoolWithdrawableDetails(nodeAddress
 }
 
 // Submit minipool withdrawable status
-func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoolWithdrawableDetails) error {
+func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(ctx context.Context, details minipoolWithdrawableDetails) error {
 
 	// Log
 	t.log.Printlnf("Submitting minipool %s withdrawable status...", details.Address.Hex())
@@ -405,7 +408,11 @@ func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoo
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		gasOracle, err := services.GetGasOracle(t.c)
+		if err != nil {
+			return err
+		}
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(gasOracle, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -427,7 +434,7 @@ func (t *submitWithdrawableMinipools) submitWithdrawableMinipool(details minipoo
 	}
 
 	// Print TX info and wait for it to be mined
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	err = api.PrintAndWaitForTransaction(ctx, t.cfg, hash, t.txm, opts, "submitWithdrawableMinipools", t.log)
 	if err != nil {
 		return err
 	}
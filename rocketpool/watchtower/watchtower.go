@@ -1,7 +1,6 @@
 package watchtower
 
 import (
-	"math/rand"
 	"net/http"
 	"sync"
 	"time"
@@ -11,13 +10,18 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/scheduler"
+	"github.com/rocket-pool/smartnode/shared/utils/shutdown"
 )
 
 // Config
 var minTasksInterval, _ = time.ParseDuration("4m")
 var maxTasksInterval, _ = time.ParseDuration("6m")
 var taskCooldown, _ = time.ParseDuration("10s")
+var maxTaskBackoff, _ = time.ParseDuration("30m")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -52,111 +56,135 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
-	// Wait until node is registered
-	if err := services.WaitNodeRegistered(c, true); err != nil {
-		return err
-	}
+	// Initialize error logger
+	errorLog := log.NewColorLogger(ErrorColor)
 
-	// Initialize the scrub metrics reporter
-	scrubCollector := collectors.NewScrubCollector()
+	// Stop on interrupt or termination
+	ctx := shutdown.Context(errorLog)
 
-	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
-	if err != nil {
-		return err
-	}
-	claimRplRewards, err := newClaimRplRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
-	if err != nil {
-		return err
-	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor))
-	if err != nil {
-		return err
-	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
-	if err != nil {
+	// Wait until node is registered
+	if err := services.WaitNodeRegistered(ctx, c, true); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
 		return err
 	}
-	submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, log.NewColorLogger(SubmitWithdrawableMinipoolsColor))
+
+	// Get services
+	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	shutdownTimeout, err := cfg.GetShutdownTimeout()
 	if err != nil {
 		return err
 	}
-	processWithdrawals, err := newProcessWithdrawals(c, log.NewColorLogger(ProcessWithdrawalsColor))
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return err
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), scrubCollector)
+
+	// Get a wallet for each node account
+	wallets, err := services.GetNodeAccountWallets(c)
 	if err != nil {
 		return err
 	}
 
-	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
+	// Register tasks with the scheduler for each node account, running some early on beacon events
+	taskScheduler := scheduler.NewScheduler(errorLog)
+	eventTrigger := scheduler.NewEventTrigger()
+	accountCollectors := map[*wallet.Wallet]nodeAccountCollectors{}
+	for _, w := range wallets {
+
+		// Initialize the metrics reporters
+		scrubCollector := collectors.NewScrubCollector()
+		withdrawalsCollector := collectors.NewWithdrawalsCollector()
+		accountCollectors[w] = nodeAccountCollectors{scrub: scrubCollector, withdrawals: withdrawalsCollector}
+
+		// Initialize tasks
+		respondChallenges, err := newRespondChallenges(c, services.NewNodeAccountLogger(w, RespondChallengesColor), w)
+		if err != nil {
+			return err
+		}
+		claimRplRewards, err := newClaimRplRewards(c, services.NewNodeAccountLogger(w, ClaimRplRewardsColor), w)
+		if err != nil {
+			return err
+		}
+		submitRplPrice, err := newSubmitRplPrice(c, services.NewNodeAccountLogger(w, SubmitRplPriceColor), w)
+		if err != nil {
+			return err
+		}
+		submitNetworkBalances, err := newSubmitNetworkBalances(c, services.NewNodeAccountLogger(w, SubmitNetworkBalancesColor), w)
+		if err != nil {
+			return err
+		}
+		submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, services.NewNodeAccountLogger(w, SubmitWithdrawableMinipoolsColor), w)
+		if err != nil {
+			return err
+		}
+		dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, services.NewNodeAccountLogger(w, DissolveTimedOutMinipoolsColor), w)
+		if err != nil {
+			return err
+		}
+		processWithdrawals, err := newProcessWithdrawals(c, services.NewNodeAccountLogger(w, ProcessWithdrawalsColor), w, withdrawalsCollector)
+		if err != nil {
+			return err
+		}
+		submitScrubMinipools, err := newSubmitScrubMinipools(c, services.NewNodeAccountLogger(w, SubmitScrubMinipoolsColor), w, scrubCollector)
+		if err != nil {
+			return err
+		}
+
+		// Register tasks; their nonces come from the shared allocator, so they don't need to run one at a time
+		tasks := []scheduler.Task{
+			{Name: "respondChallenges", Run: respondChallenges.run},
+			{Name: "claimRplRewards", Run: claimRplRewards.run},
+			{Name: "submitRplPrice", Run: submitRplPrice.run},
+			{Name: "submitNetworkBalances", Run: submitNetworkBalances.run},
+			{Name: "submitWithdrawableMinipools", Run: submitWithdrawableMinipools.run, Trigger: eventTrigger.Subscribe(beacon.EventVoluntaryExit, beacon.EventFinalizedCheckpoint)},
+			{Name: "dissolveTimedOutMinipools", Run: dissolveTimedOutMinipools.run},
+			{Name: "processWithdrawals", Run: processWithdrawals.run, Trigger: eventTrigger.Subscribe(beacon.EventFinalizedCheckpoint)},
+			{Name: "submitScrubMinipools", Run: submitScrubMinipools.run},
+		}
+		for ti, task := range tasks {
+			task.Name, task.ConfigName = services.GetNodeAccountTaskName(w, task.Name), task.Name
+			task.Interval = minTasksInterval
+			task.Jitter = maxTasksInterval - minTasksInterval
+			task.StartDelay = time.Duration(ti) * taskCooldown
+			task.MaxBackoff = maxTaskBackoff
+			if err := taskScheduler.Register(task, cfg.Tasks.Watchtower); err != nil {
+				return err
+			}
+		}
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(3)
 
-	// Run task loop
+	// Run task loop until shutdown
 	go func() {
-		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
+		taskScheduler.Run(ctx, shutdownTimeout)
+		wg.Done()
+	}()
 
-			if err := respondChallenges.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := claimRplRewards.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := submitRplPrice.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := submitNetworkBalances.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := submitWithdrawableMinipools.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := dissolveTimedOutMinipools.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := processWithdrawals.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-			if err := submitScrubMinipools.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(interval)
-		}
+	// Trigger tasks from beacon events until shutdown
+	go func() {
+		eventTrigger.Run(ctx, bc, errorLog)
 		wg.Done()
 	}()
 
-	// Run metrics loop
+	// Run metrics loop until shutdown
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(ctx, c, log.NewColorLogger(MetricsColor), accountCollectors)
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Wait for all threads to stop
 	wg.Wait()
 	return nil
 }
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Get a wallet view for every node account, for daemons which run tasks on behalf of all accounts
+func GetNodeAccountWallets(c *cli.Context) ([]*wallet.Wallet, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := getPasswordManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	w, err := getWallet(cfg, pm)
+	if err != nil {
+		return nil, err
+	}
+	names, err := w.GetNodeAccountNames()
+	if err != nil {
+		return nil, err
+	}
+	wallets := []*wallet.Wallet{}
+	for _, name := range names {
+		aw, err := w.ForNodeAccount(name)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, aw)
+	}
+	return wallets, nil
+}
+
+// Create a logger for a daemon task, prefixed with the node account name unless it runs for the default account
+func NewNodeAccountLogger(w *wallet.Wallet, colorAttr color.Attribute) log.ColorLogger {
+	logger := log.NewColorLogger(colorAttr)
+	if w.GetNodeAccountName() == wallet.DefaultNodeAccount {
+		return logger
+	}
+	return logger.WithPrefix(fmt.Sprintf("[%s]", w.GetNodeAccountName()))
+}
+
+// Get the name of a daemon task run for a node account; tasks for the default account keep their own name
+func GetNodeAccountTaskName(w *wallet.Wallet, name string) string {
+	if w.GetNodeAccountName() == wallet.DefaultNodeAccount {
+		return name
+	}
+	return fmt.Sprintf("%s@%s", name, w.GetNodeAccountName())
+}
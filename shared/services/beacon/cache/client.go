@@ -0,0 +1,337 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Config
+const (
+	DefaultTTL      = 12 * time.Second
+	MaxCachedEpochs = 8
+)
+
+// A cached value and the time it was fetched
+type entry struct {
+	value     interface{}
+	fetchedAt time.Time
+}
+
+// Beacon client which caches responses from an underlying client
+// Sync status, the chain head and head validator state are cached for a short TTL
+// Spec config, validator indices and validator state at finalized epochs are immutable, so are cached permanently
+type Client struct {
+	client beacon.Client
+	ttl    time.Duration
+
+	eth2Config       *beacon.Eth2Config
+	depositContract  *beacon.Eth2DepositContract
+	syncStatus       entry
+	beaconHead       entry
+	headValidators   map[types.ValidatorPubkey]entry
+	epochValidators  map[uint64]map[types.ValidatorPubkey]beacon.ValidatorStatus
+	validatorIndices map[types.ValidatorPubkey]uint64
+
+	lock sync.Mutex
+}
+
+// Create new caching client
+func NewClient(client beacon.Client, ttl time.Duration) *Client {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Client{
+		client:           client,
+		ttl:              ttl,
+		headValidators:   make(map[types.ValidatorPubkey]entry),
+		epochValidators:  make(map[uint64]map[types.ValidatorPubkey]beacon.ValidatorStatus),
+		validatorIndices: make(map[types.ValidatorPubkey]uint64),
+	}
+}
+
+// Close the underlying client connection
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+// Get the underlying client type
+func (c *Client) GetClientType() beacon.BeaconClientType {
+	return c.client.GetClientType()
+}
+
+// Get the node's sync status
+func (c *Client) GetSyncStatus(ctx context.Context) (beacon.SyncStatus, error) {
+	c.lock.Lock()
+	if c.isFresh(c.syncStatus) {
+		defer c.lock.Unlock()
+		return c.syncStatus.value.(beacon.SyncStatus), nil
+	}
+	c.lock.Unlock()
+	syncStatus, err := c.client.GetSyncStatus(ctx)
+	if err != nil {
+		return beacon.SyncStatus{}, err
+	}
+	c.lock.Lock()
+	c.syncStatus = entry{value: syncStatus, fetchedAt: time.Now()}
+	c.lock.Unlock()
+	return syncStatus, nil
+}
+
+// Get the eth2 config
+func (c *Client) GetEth2Config(ctx context.Context) (beacon.Eth2Config, error) {
+	c.lock.Lock()
+	if c.eth2Config != nil {
+		defer c.lock.Unlock()
+		return *c.eth2Config, nil
+	}
+	c.lock.Unlock()
+	eth2Config, err := c.client.GetEth2Config(ctx)
+	if err != nil {
+		return beacon.Eth2Config{}, err
+	}
+	c.lock.Lock()
+	c.eth2Config = &eth2Config
+	c.lock.Unlock()
+	return eth2Config, nil
+}
+
+// Get the eth2 deposit contract info
+func (c *Client) GetEth2DepositContract(ctx context.Context) (beacon.Eth2DepositContract, error) {
+	c.lock.Lock()
+	if c.depositContract != nil {
+		defer c.lock.Unlock()
+		return *c.depositContract, nil
+	}
+	c.lock.Unlock()
+	depositContract, err := c.client.GetEth2DepositContract(ctx)
+	if err != nil {
+		return beacon.Eth2DepositContract{}, err
+	}
+	c.lock.Lock()
+	c.depositContract = &depositContract
+	c.lock.Unlock()
+	return depositContract, nil
+}
+
+// Get the beacon head
+func (c *Client) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+	c.lock.Lock()
+	if c.isFresh(c.beaconHead) {
+		defer c.lock.Unlock()
+		return c.beaconHead.value.(beacon.BeaconHead), nil
+	}
+	c.lock.Unlock()
+	head, err := c.client.GetBeaconHead(ctx)
+	if err != nil {
+		return beacon.BeaconHead{}, err
+	}
+	c.lock.Lock()
+	c.beaconHead = entry{value: head, fetchedAt: time.Now()}
+	c.lock.Unlock()
+	return head, nil
+}
+
+// Get a validator's status
+func (c *Client) GetValidatorStatus(ctx context.Context, pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	statuses, err := c.GetValidatorStatuses(ctx, []types.ValidatorPubkey{pubkey}, opts)
+	if err != nil {
+		return beacon.ValidatorStatus{}, err
+	}
+	return statuses[pubkey], nil
+}
+
+// Get multiple validators' statuses
+// Only validators missing from the cache are requested from the underlying client
+func (c *Client) GetValidatorStatuses(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+
+	// Check whether the requested epoch is finalized
+	finalized := false
+	if opts != nil {
+		head, err := c.GetBeaconHead(ctx)
+		if err != nil {
+			return nil, err
+		}
+		finalized = (opts.Epoch <= head.FinalizedEpoch)
+		if !finalized {
+			return c.client.GetValidatorStatuses(ctx, pubkeys, opts)
+		}
+	}
+
+	// Get cached statuses
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	missing := []types.ValidatorPubkey{}
+	c.lock.Lock()
+	for _, pubkey := range pubkeys {
+		if finalized {
+			if status, ok := c.epochValidators[opts.Epoch][pubkey]; ok {
+				statuses[pubkey] = status
+				continue
+			}
+		} else if cached, ok := c.headValidators[pubkey]; ok && c.isFresh(cached) {
+			statuses[pubkey] = cached.value.(beacon.ValidatorStatus)
+			continue
+		}
+		missing = append(missing, pubkey)
+	}
+	c.lock.Unlock()
+	if len(missing) == 0 {
+		return statuses, nil
+	}
+
+	// Get missing statuses
+	missingStatuses, err := c.client.GetValidatorStatuses(ctx, missing, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache missing statuses
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	cacheStatuses := true
+	if finalized {
+		cacheStatuses = c.cacheEpoch(opts.Epoch)
+	} else {
+		c.pruneHeadValidators()
+	}
+	for pubkey, status := range missingStatuses {
+		statuses[pubkey] = status
+		if finalized && cacheStatuses {
+			c.epochValidators[opts.Epoch][pubkey] = status
+		} else if !finalized {
+			c.headValidators[pubkey] = entry{value: status, fetchedAt: now}
+		}
+		if status.Exists {
+			c.validatorIndices[pubkey] = status.Index
+		}
+	}
+	return statuses, nil
+
+}
+
+// Get a validator's index
+func (c *Client) GetValidatorIndex(ctx context.Context, pubkey types.ValidatorPubkey) (uint64, error) {
+	c.lock.Lock()
+	if index, ok := c.validatorIndices[pubkey]; ok {
+		defer c.lock.Unlock()
+		return index, nil
+	}
+	c.lock.Unlock()
+	index, err := c.client.GetValidatorIndex(ctx, pubkey)
+	if err != nil {
+		return 0, err
+	}
+	c.lock.Lock()
+	c.validatorIndices[pubkey] = index
+	c.lock.Unlock()
+	return index, nil
+}
+
+// Get the validators' sync committee duties
+func (c *Client) GetValidatorSyncDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	return c.client.GetValidatorSyncDuties(ctx, indices, epoch)
+}
+
+// Get the validators' proposer duties
+func (c *Client) GetValidatorProposerDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	return c.client.GetValidatorProposerDuties(ctx, indices, epoch)
+}
+
+// Get validators' attester duties
+func (c *Client) GetValidatorAttesterDuties(ctx context.Context, indices []uint64, epoch uint64) ([]beacon.AttesterDuty, error) {
+	return c.client.GetValidatorAttesterDuties(ctx, indices, epoch)
+}
+
+// Get the fork at the beacon head
+func (c *Client) GetFork(ctx context.Context) (beacon.Fork, error) {
+	return c.client.GetFork(ctx)
+}
+
+// Get domain data for a domain type at a given epoch
+func (c *Client) GetDomainData(ctx context.Context, domainType []byte, epoch uint64) ([]byte, error) {
+	return c.client.GetDomainData(ctx, domainType, epoch)
+}
+
+// Perform a voluntary exit on a validator
+func (c *Client) ExitValidator(ctx context.Context, validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return c.client.ExitValidator(ctx, validatorIndex, epoch, signature)
+}
+
+// Get the ETH1 data for the target beacon block
+func (c *Client) GetEth1DataForEth2Block(ctx context.Context, blockId string) (beacon.Eth1Data, error) {
+	return c.client.GetEth1DataForEth2Block(ctx, blockId)
+}
+
+// Get a beacon block's proposer and attestations
+func (c *Client) GetBeaconBlock(ctx context.Context, blockId string) (beacon.BeaconBlock, bool, error) {
+	return c.client.GetBeaconBlock(ctx, blockId)
+}
+
+// Subscribe to beacon events
+// New heads, finalized checkpoints and reorgs expire the cached sync status and head
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan beacon.Event, error) {
+	upstream, err := c.client.SubscribeEvents(ctx, topics)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan beacon.Event, cap(upstream))
+	go func() {
+		defer close(events)
+		for event := range upstream {
+			switch event.Topic {
+			case beacon.EventHead, beacon.EventFinalizedCheckpoint, beacon.EventChainReorg:
+				c.lock.Lock()
+				c.syncStatus = entry{}
+				c.beaconHead = entry{}
+				c.lock.Unlock()
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// Check whether a cached entry is still within its TTL
+func (c *Client) isFresh(e entry) bool {
+	return e.value != nil && time.Since(e.fetchedAt) < c.ttl
+}
+
+// Make room for an epoch's validator statuses, dropping the oldest cached epochs
+// Returns false if the cache is full of newer epochs, in which case the epoch isn't cached
+func (c *Client) cacheEpoch(epoch uint64) bool {
+	if _, ok := c.epochValidators[epoch]; ok {
+		return true
+	}
+	for len(c.epochValidators) >= MaxCachedEpochs {
+		oldest := epoch
+		for cachedEpoch := range c.epochValidators {
+			if cachedEpoch < oldest {
+				oldest = cachedEpoch
+			}
+		}
+		if oldest == epoch {
+			return false
+		}
+		delete(c.epochValidators, oldest)
+	}
+	c.epochValidators[epoch] = make(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	return true
+}
+
+// Drop head validator statuses which have outlived their TTL
+func (c *Client) pruneHeadValidators() {
+	for pubkey, cached := range c.headValidators {
+		if !c.isFresh(cached) {
+			delete(c.headValidators, pubkey)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+type countingClient struct {
+	beacon.Client
+	headCalls     int
+	statusCalls   int
+	requestedKeys int
+}
+
+func (f *countingClient) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+	f.headCalls++
+	return beacon.BeaconHead{Epoch: 12, FinalizedEpoch: 10}, nil
+}
+func (f *countingClient) GetValidatorStatuses(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	f.statusCalls++
+	f.requestedKeys += len(pubkeys)
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	for _, pubkey := range pubkeys {
+		statuses[pubkey] = beacon.ValidatorStatus{Pubkey: pubkey, Exists: true}
+	}
+	return statuses, nil
+}
+
+func TestCachesFinalizedValidatorStatuses(t *testing.T) {
+	underlying := &countingClient{}
+	client := NewClient(underlying, time.Hour)
+	pubkeys := []types.ValidatorPubkey{{1}, {2}}
+
+	// Finalized epochs are fetched once, with only missing validators requested afterwards
+	finalized := &beacon.ValidatorStatusOptions{Epoch: 10}
+	if _, err := client.GetValidatorStatuses(context.Background(), pubkeys[:1], finalized); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := client.GetValidatorStatuses(context.Background(), pubkeys, finalized)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || underlying.statusCalls != 2 || underlying.requestedKeys != 2 {
+		t.Errorf("expected each finalized status to be fetched once, got %d calls for %d validators", underlying.statusCalls, underlying.requestedKeys)
+	}
+	if underlying.headCalls != 1 {
+		t.Errorf("expected the beacon head to be cached, got %d calls", underlying.headCalls)
+	}
+
+	// Unfinalized epochs are always fetched
+	unfinalized := &beacon.ValidatorStatusOptions{Epoch: 11}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetValidatorStatuses(context.Background(), pubkeys, unfinalized); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if underlying.statusCalls != 4 {
+		t.Errorf("expected unfinalized statuses not to be cached, got %d calls", underlying.statusCalls)
+	}
+}
+
+func TestBoundsCachedValidatorStatuses(t *testing.T) {
+	underlying := &countingClient{}
+	client := NewClient(underlying, time.Millisecond)
+	pubkeys := []types.ValidatorPubkey{{1}}
+
+	// Epochs older than a full cache aren't cached
+	for epoch := uint64(1); epoch <= MaxCachedEpochs; epoch++ {
+		if _, err := client.GetValidatorStatuses(context.Background(), pubkeys, &beacon.ValidatorStatusOptions{Epoch: epoch + 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.GetValidatorStatuses(context.Background(), pubkeys, &beacon.ValidatorStatusOptions{Epoch: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.epochValidators[1]; ok || len(client.epochValidators) != MaxCachedEpochs {
+		t.Errorf("expected %d cached epochs without the oldest, got %d", MaxCachedEpochs, len(client.epochValidators))
+	}
+
+	// Expired head statuses are dropped
+	if _, err := client.GetValidatorStatuses(context.Background(), []types.ValidatorPubkey{{2}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if _, err := client.GetValidatorStatuses(context.Background(), []types.ValidatorPubkey{{3}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.headValidators[types.ValidatorPubkey{2}]; ok || len(client.headValidators) != 1 {
+		t.Errorf("expected only the fresh head status to be cached, got %d", len(client.headValidators))
+	}
+}
@@ -1,10 +1,27 @@
 package beacon
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 )
 
+// Returned when the beacon node doesn't know the requested validator or block
+var ErrNotFound = errors.New("not found")
+
+// Error for a request which the beacon node responded to with an unexpected HTTP status
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP status %d; response body: '%s'", e.StatusCode, e.Body)
+}
+
 // API request options
 type ValidatorStatusOptions struct {
 	Epoch uint64
@@ -21,6 +38,7 @@ type Eth2Config struct {
 	GenesisEpoch                 uint64
 	GenesisTime                  uint64
 	SecondsPerEpoch              uint64
+	SlotsPerEpoch                uint64
 	EpochsPerSyncCommitteePeriod uint64
 }
 type Eth2DepositContract struct {
@@ -51,6 +69,44 @@ type Eth1Data struct {
 	DepositCount uint64
 	BlockHash    common.Hash
 }
+type AttesterDuty struct {
+	ValidatorIndex          uint64
+	Slot                    uint64
+	CommitteeIndex          uint64
+	CommitteeLength         uint64
+	ValidatorCommitteeIndex uint64
+}
+type BeaconBlock struct {
+	Slot          uint64
+	ProposerIndex uint64
+	Attestations  []AttestationInfo
+}
+type AttestationInfo struct {
+	Slot            uint64
+	CommitteeIndex  uint64
+	AggregationBits []byte
+}
+type Fork struct {
+	PreviousVersion []byte
+	CurrentVersion  []byte
+	Epoch           uint64
+}
+type Event struct {
+	Topic          string
+	Slot           uint64
+	Epoch          uint64
+	Block          common.Hash
+	Depth          uint64
+	ValidatorIndex uint64
+}
+
+// Beacon event topics
+const (
+	EventHead                = "head"
+	EventFinalizedCheckpoint = "finalized_checkpoint"
+	EventChainReorg          = "chain_reorg"
+	EventVoluntaryExit       = "voluntary_exit"
+)
 
 // Beacon client type
 type BeaconClientType int
@@ -70,17 +126,30 @@ const (
 // Beacon client interface
 type Client interface {
 	GetClientType() BeaconClientType
-	GetSyncStatus() (SyncStatus, error)
-	GetEth2Config() (Eth2Config, error)
-	GetEth2DepositContract() (Eth2DepositContract, error)
-	GetBeaconHead() (BeaconHead, error)
-	GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
-	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
-	GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error)
-	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
-	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
-	GetDomainData(domainType []byte, epoch uint64) ([]byte, error)
-	ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error
+	GetSyncStatus(ctx context.Context) (SyncStatus, error)
+	GetEth2Config(ctx context.Context) (Eth2Config, error)
+	GetEth2DepositContract(ctx context.Context) (Eth2DepositContract, error)
+	GetBeaconHead(ctx context.Context) (BeaconHead, error)
+	GetValidatorStatus(ctx context.Context, pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
+	GetValidatorStatuses(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
+	GetValidatorIndex(ctx context.Context, pubkey types.ValidatorPubkey) (uint64, error)
+	GetValidatorSyncDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]bool, error)
+	GetValidatorProposerDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]uint64, error)
+	GetValidatorAttesterDuties(ctx context.Context, indices []uint64, epoch uint64) ([]AttesterDuty, error)
+	GetFork(ctx context.Context) (Fork, error)
+	GetDomainData(ctx context.Context, domainType []byte, epoch uint64) ([]byte, error)
+	ExitValidator(ctx context.Context, validatorIndex, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
-	GetEth1DataForEth2Block(blockId string) (Eth1Data, error)
+	GetEth1DataForEth2Block(ctx context.Context, blockId string) (Eth1Data, error)
+	GetBeaconBlock(ctx context.Context, blockId string) (BeaconBlock, bool, error)
+	SubscribeEvents(ctx context.Context, topics []string) (<-chan Event, error)
+}
+
+// Check whether a validator's bit is set in an attestation's aggregation bits
+func (a AttestationInfo) HasAttested(validatorCommitteeIndex uint64) bool {
+	byteIndex := validatorCommitteeIndex / 8
+	if byteIndex >= uint64(len(a.AggregationBits)) {
+		return false
+	}
+	return a.AggregationBits[byteIndex]&(1<<(validatorCommitteeIndex%8)) != 0
 }
@@ -0,0 +1,475 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Config
+const DefaultHealthCheckInterval = 30 * time.Second
+
+// A beacon endpoint and its last known health
+type endpoint struct {
+	index     int
+	name      string
+	client    beacon.Client
+	healthy   bool
+	checkedAt time.Time
+}
+
+// Beacon client which fails over between several beacon endpoints
+// Endpoints are used in order of preference, skipping those which are erroring or syncing
+type Client struct {
+	endpoints           []*endpoint
+	healthCheckInterval time.Duration
+	quorum              int
+	eventEndpoint       int
+	lock                sync.Mutex
+}
+
+// Create new failover client
+// Endpoints are named by their providers and used in the given order of preference
+// If quorum is greater than 1, validator statuses must be returned identically by at least that many endpoints
+func NewClient(providers []string, clients []beacon.Client, healthCheckInterval time.Duration, quorum int) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("At least one beacon endpoint is required")
+	}
+	if len(providers) != len(clients) {
+		return nil, errors.New("Each beacon endpoint must have a provider")
+	}
+	if quorum > len(clients) {
+		return nil, fmt.Errorf("A beacon quorum of %d requires at least %d endpoints, but only %d are configured", quorum, quorum, len(clients))
+	}
+	if healthCheckInterval <= 0 {
+		healthCheckInterval = DefaultHealthCheckInterval
+	}
+	endpoints := make([]*endpoint, len(clients))
+	for ei, client := range clients {
+		endpoints[ei] = &endpoint{
+			index:  ei,
+			name:   providers[ei],
+			client: client,
+		}
+	}
+	return &Client{
+		endpoints:           endpoints,
+		healthCheckInterval: healthCheckInterval,
+		quorum:              quorum,
+		eventEndpoint:       -1,
+	}, nil
+}
+
+// Close the endpoint connections
+func (c *Client) Close() error {
+	var closeErr error
+	for _, ep := range c.endpoints {
+		if err := ep.client.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	return closeErr
+}
+
+// Get the beacon client type of the primary endpoint
+func (c *Client) GetClientType() beacon.BeaconClientType {
+	return c.endpoints[0].client.GetClientType()
+}
+
+// Get the sync status of the first synced endpoint, or of the primary endpoint if none are synced
+func (c *Client) GetSyncStatus(ctx context.Context) (beacon.SyncStatus, error) {
+	for _, ep := range c.getEndpoints(ctx) {
+		if ep.healthy {
+			return ep.client.GetSyncStatus(ctx)
+		}
+	}
+	return c.endpoints[0].client.GetSyncStatus(ctx)
+}
+
+// Get the eth2 config
+func (c *Client) GetEth2Config(ctx context.Context) (beacon.Eth2Config, error) {
+	var result beacon.Eth2Config
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetEth2Config(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Get the eth2 deposit contract info
+func (c *Client) GetEth2DepositContract(ctx context.Context) (beacon.Eth2DepositContract, error) {
+	var result beacon.Eth2DepositContract
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetEth2DepositContract(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Get the beacon head
+func (c *Client) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+	var result beacon.BeaconHead
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetBeaconHead(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Get a validator's status
+// Requires a quorum of endpoints to agree if configured
+func (c *Client) GetValidatorStatus(ctx context.Context, pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	result, err := c.runWithQuorum(ctx, func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatus(ctx, pubkey, opts)
+	}, func(a, b interface{}) bool {
+		return statusesAgree(a.(beacon.ValidatorStatus), b.(beacon.ValidatorStatus), opts != nil)
+	})
+	if err != nil {
+		return beacon.ValidatorStatus{}, err
+	}
+	return result.(beacon.ValidatorStatus), nil
+}
+
+// Get multiple validators' statuses
+// Requires a quorum of endpoints to agree if configured
+func (c *Client) GetValidatorStatuses(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	result, err := c.runWithQuorum(ctx, func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatuses(ctx, pubkeys, opts)
+	}, func(a, b interface{}) bool {
+		statusesA := a.(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+		statusesB := b.(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+		if len(statusesA) != len(statusesB) {
+			return false
+		}
+		for pubkey, statusA := range statusesA {
+			statusB, ok := statusesB[pubkey]
+			if !ok || !statusesAgree(statusA, statusB, opts != nil) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
+}
+
+// Get a validator's index
+func (c *Client) GetValidatorIndex(ctx context.Context, pubkey types.ValidatorPubkey) (uint64, error) {
+	var result uint64
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetValidatorIndex(ctx, pubkey)
+		return err
+	})
+	return result, err
+}
+
+// Get the validators' sync committee duties for an epoch
+func (c *Client) GetValidatorSyncDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]bool, error) {
+	var result map[uint64]bool
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetValidatorSyncDuties(ctx, indices, epoch)
+		return err
+	})
+	return result, err
+}
+
+// Get the validators' proposer duties for an epoch
+func (c *Client) GetValidatorProposerDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	var result map[uint64]uint64
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetValidatorProposerDuties(ctx, indices, epoch)
+		return err
+	})
+	return result, err
+}
+
+// Get validators' attester duties
+func (c *Client) GetValidatorAttesterDuties(ctx context.Context, indices []uint64, epoch uint64) ([]beacon.AttesterDuty, error) {
+	var result []beacon.AttesterDuty
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetValidatorAttesterDuties(ctx, indices, epoch)
+		return err
+	})
+	return result, err
+}
+
+// Get the fork at the beacon head
+func (c *Client) GetFork(ctx context.Context) (beacon.Fork, error) {
+	var result beacon.Fork
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetFork(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Get domain data for a domain type at a given epoch
+func (c *Client) GetDomainData(ctx context.Context, domainType []byte, epoch uint64) ([]byte, error) {
+	var result []byte
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetDomainData(ctx, domainType, epoch)
+		return err
+	})
+	return result, err
+}
+
+// Perform a voluntary exit on a validator
+func (c *Client) ExitValidator(ctx context.Context, validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return c.run(ctx, func(client beacon.Client) error {
+		return client.ExitValidator(ctx, validatorIndex, epoch, signature)
+	})
+}
+
+// Get the ETH1 data for the target beacon block
+func (c *Client) GetEth1DataForEth2Block(ctx context.Context, blockId string) (beacon.Eth1Data, error) {
+	var result beacon.Eth1Data
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, err = client.GetEth1DataForEth2Block(ctx, blockId)
+		return err
+	})
+	return result, err
+}
+
+// Get a beacon block's proposer and attestations
+func (c *Client) GetBeaconBlock(ctx context.Context, blockId string) (beacon.BeaconBlock, bool, error) {
+	var result beacon.BeaconBlock
+	var exists bool
+	err := c.run(ctx, func(client beacon.Client) error {
+		var err error
+		result, exists, err = client.GetBeaconBlock(ctx, blockId)
+		return err
+	})
+	return result, exists, err
+}
+
+// Subscribe to beacon events on the first endpoint which accepts the subscription
+// The subscription stays on that endpoint, which reconnects to it if the stream drops
+// The endpoint used by the previous subscription is tried last, so resubscribing moves on to the next endpoint
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan beacon.Event, error) {
+
+	// Move the previous subscription's endpoint to the back
+	c.lock.Lock()
+	previous := c.eventEndpoint
+	c.lock.Unlock()
+	endpoints := []*endpoint{}
+	var previousEndpoint *endpoint
+	for _, ep := range c.getEndpoints(ctx) {
+		if ep.index == previous {
+			previousEndpoint = ep
+		} else {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	if previousEndpoint != nil {
+		endpoints = append(endpoints, previousEndpoint)
+	}
+
+	// Subscribe
+	errorStrings := []string{}
+	for _, ep := range endpoints {
+		events, err := ep.client.SubscribeEvents(ctx, topics)
+		if err == nil {
+			c.lock.Lock()
+			c.eventEndpoint = ep.index
+			c.lock.Unlock()
+			return events, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		c.setUnhealthy(ep)
+		errorStrings = append(errorStrings, fmt.Sprintf("%s: %s", ep.name, err.Error()))
+	}
+	return nil, fmt.Errorf("All beacon endpoints failed:\n%s", strings.Join(errorStrings, "\n"))
+
+}
+
+// Run a request on each endpoint in turn until one succeeds
+func (c *Client) run(ctx context.Context, request func(client beacon.Client) error) error {
+	errorStrings := []string{}
+	for _, ep := range c.getEndpoints(ctx) {
+		err := request(ep.client)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || !isEndpointError(err) {
+			return err
+		}
+		c.setUnhealthy(ep)
+		errorStrings = append(errorStrings, fmt.Sprintf("%s: %s", ep.name, err.Error()))
+	}
+	return fmt.Errorf("All beacon endpoints failed:\n%s", strings.Join(errorStrings, "\n"))
+}
+
+// Run a request on every healthy endpoint and check that a quorum of them agree
+// Runs on a single endpoint with failover if no quorum is configured
+func (c *Client) runWithQuorum(ctx context.Context, request func(client beacon.Client) (interface{}, error), agree func(a, b interface{}) bool) (interface{}, error) {
+
+	// Run with failover
+	if c.quorum <= 1 {
+		var result interface{}
+		err := c.run(ctx, func(client beacon.Client) error {
+			var err error
+			result, err = request(client)
+			return err
+		})
+		return result, err
+	}
+
+	// Get healthy endpoints
+	endpoints := []*endpoint{}
+	for _, ep := range c.getEndpoints(ctx) {
+		if ep.healthy {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	if len(endpoints) < c.quorum {
+		return nil, fmt.Errorf("Only %d beacon endpoint(s) are healthy, but a quorum of %d is required", len(endpoints), c.quorum)
+	}
+
+	// Run the request on each endpoint
+	results := make([]interface{}, len(endpoints))
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	wg.Add(len(endpoints))
+	for ei, ep := range endpoints {
+		go func(ei int, ep *endpoint) {
+			results[ei], errs[ei] = request(ep.client)
+			wg.Done()
+		}(ei, ep)
+	}
+	wg.Wait()
+
+	// Group the successful results by agreement
+	groups := [][]int{}
+	errorStrings := []string{}
+	for ei, ep := range endpoints {
+		if errs[ei] != nil {
+			if isEndpointError(errs[ei]) {
+				c.setUnhealthy(ep)
+			}
+			errorStrings = append(errorStrings, fmt.Sprintf("%s: %s", ep.name, errs[ei].Error()))
+			continue
+		}
+		grouped := false
+		for gi, group := range groups {
+			if agree(results[group[0]], results[ei]) {
+				groups[gi] = append(group, ei)
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			groups = append(groups, []int{ei})
+		}
+	}
+
+	// Return the result a quorum of endpoints agree on
+	for _, group := range groups {
+		if len(group) >= c.quorum {
+			return results[group[0]], nil
+		}
+	}
+	if len(groups) > 1 {
+		return nil, fmt.Errorf("Beacon endpoints returned different results, and no %d of them agree", c.quorum)
+	}
+	responded := 0
+	if len(groups) == 1 {
+		responded = len(groups[0])
+	}
+	return nil, fmt.Errorf("Only %d beacon endpoint(s) responded, but a quorum of %d is required:\n%s", responded, c.quorum, strings.Join(errorStrings, "\n"))
+
+}
+
+// Get the endpoints in order of preference, with healthy endpoints first
+// Endpoint health is refreshed if it hasn't been checked recently
+func (c *Client) getEndpoints(ctx context.Context) []*endpoint {
+
+	// Refresh stale health statuses
+	c.lock.Lock()
+	stale := []*endpoint{}
+	for _, ep := range c.endpoints {
+		if time.Since(ep.checkedAt) >= c.healthCheckInterval {
+			stale = append(stale, ep)
+		}
+	}
+	c.lock.Unlock()
+	for _, ep := range stale {
+		syncStatus, err := ep.client.GetSyncStatus(ctx)
+		c.lock.Lock()
+		ep.healthy = (err == nil && !syncStatus.Syncing)
+		ep.checkedAt = time.Now()
+		c.lock.Unlock()
+	}
+
+	// Order copies of the endpoints so their health can be read without locking
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	healthy := []*endpoint{}
+	unhealthy := []*endpoint{}
+	for _, ep := range c.endpoints {
+		epCopy := *ep
+		if ep.healthy {
+			healthy = append(healthy, &epCopy)
+		} else {
+			unhealthy = append(unhealthy, &epCopy)
+		}
+	}
+	return append(healthy, unhealthy...)
+
+}
+
+// Mark an endpoint as unhealthy until its next health check
+func (c *Client) setUnhealthy(ep *endpoint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.endpoints[ep.index].healthy = false
+	c.endpoints[ep.index].checkedAt = time.Now()
+}
+
+// Check whether an error means the endpoint is unavailable, rather than the request being for something it doesn't know
+// Only transport errors, server errors and errors from syncing endpoints count against an endpoint's health
+func isEndpointError(err error) bool {
+	var httpErr *beacon.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	return !errors.Is(err, beacon.ErrNotFound)
+}
+
+// Check whether two validator statuses agree
+// Balances at the chain head may differ between endpoints by a slot, so are only compared for a specific epoch
+func statusesAgree(a, b beacon.ValidatorStatus, compareBalances bool) bool {
+	if compareBalances && (a.Balance != b.Balance || a.EffectiveBalance != b.EffectiveBalance) {
+		return false
+	}
+	return a.Pubkey == b.Pubkey &&
+		a.Index == b.Index &&
+		a.WithdrawalCredentials == b.WithdrawalCredentials &&
+		a.Slashed == b.Slashed &&
+		a.ActivationEligibilityEpoch == b.ActivationEligibilityEpoch &&
+		a.ActivationEpoch == b.ActivationEpoch &&
+		a.ExitEpoch == b.ExitEpoch &&
+		a.WithdrawableEpoch == b.WithdrawableEpoch &&
+		a.Exists == b.Exists
+}
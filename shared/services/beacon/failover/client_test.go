@@ -0,0 +1,135 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+type fakeClient struct {
+	beacon.Client
+	syncing   bool
+	err       error
+	head      beacon.BeaconHead
+	status    beacon.ValidatorStatus
+	statusErr error
+	events    chan beacon.Event
+}
+
+func (f *fakeClient) GetSyncStatus(ctx context.Context) (beacon.SyncStatus, error) {
+	return beacon.SyncStatus{Syncing: f.syncing}, f.err
+}
+func (f *fakeClient) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+	return f.head, f.err
+}
+func (f *fakeClient) GetValidatorStatus(ctx context.Context, pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+	if f.statusErr != nil {
+		return f.status, f.statusErr
+	}
+	return f.status, f.err
+}
+
+func (f *fakeClient) SubscribeEvents(ctx context.Context, topics []string) (<-chan beacon.Event, error) {
+	return f.events, f.err
+}
+
+func TestFailoverSkipsUnhealthyEndpoints(t *testing.T) {
+	erroring := &fakeClient{err: errors.New("connection refused"), head: beacon.BeaconHead{Epoch: 1}}
+	syncing := &fakeClient{syncing: true, head: beacon.BeaconHead{Epoch: 2}}
+	healthy := &fakeClient{head: beacon.BeaconHead{Epoch: 3}}
+	client, err := NewClient([]string{"a", "b", "c"}, []beacon.Client{erroring, syncing, healthy}, time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	head, err := client.GetBeaconHead(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Epoch != 3 {
+		t.Errorf("expected the healthy endpoint to be used, got head epoch %d", head.Epoch)
+	}
+
+	erroring.err = errors.New("still down")
+	healthy.err = errors.New("now down")
+	if _, err := client.GetBeaconHead(context.Background()); err != nil {
+		t.Errorf("expected the syncing endpoint to be used as a last resort, got %v", err)
+	}
+}
+
+func TestQuorumRejectsDisagreement(t *testing.T) {
+	a := &fakeClient{status: beacon.ValidatorStatus{Exists: true, ExitEpoch: 10}}
+	b := &fakeClient{status: beacon.ValidatorStatus{Exists: true, ExitEpoch: 10}}
+	client, err := NewClient([]string{"a", "b"}, []beacon.Client{a, b}, time.Minute, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetValidatorStatus(context.Background(), types.ValidatorPubkey{}, nil); err != nil {
+		t.Fatalf("expected matching statuses to reach quorum, got %v", err)
+	}
+
+	b.status.ExitEpoch = 20
+	if _, err := client.GetValidatorStatus(context.Background(), types.ValidatorPubkey{}, nil); err == nil {
+		t.Error("expected an error when endpoints disagree")
+	}
+
+	// A single dissenting endpoint doesn't prevent a quorum
+	c := &fakeClient{status: beacon.ValidatorStatus{Exists: true, ExitEpoch: 10}}
+	client, err = NewClient([]string{"a", "b", "c"}, []beacon.Client{a, b, c}, time.Minute, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, err := client.GetValidatorStatus(context.Background(), types.ValidatorPubkey{}, nil); err != nil || status.ExitEpoch != 10 {
+		t.Errorf("expected the statuses of the agreeing endpoints, got %+v, %v", status, err)
+	}
+}
+
+func TestNotFoundKeepsEndpointHealthy(t *testing.T) {
+	primary := &fakeClient{head: beacon.BeaconHead{Epoch: 1}}
+	secondary := &fakeClient{head: beacon.BeaconHead{Epoch: 2}}
+	client, err := NewClient([]string{"a", "b"}, []beacon.Client{primary, secondary}, time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests for unknown validators are answered by the primary without failing over
+	primary.statusErr = &beacon.HTTPError{StatusCode: 404, Body: "Unknown validator"}
+	if _, err := client.GetValidatorStatus(context.Background(), types.ValidatorPubkey{}, nil); err == nil {
+		t.Error("expected an error for an unknown validator")
+	}
+	if head, err := client.GetBeaconHead(context.Background()); err != nil || head.Epoch != 1 {
+		t.Errorf("expected the primary endpoint to stay in use, got head epoch %d, %v", head.Epoch, err)
+	}
+
+	// Server errors fail over
+	primary.err = &beacon.HTTPError{StatusCode: 503, Body: "Syncing"}
+	if head, err := client.GetBeaconHead(context.Background()); err != nil || head.Epoch != 2 {
+		t.Errorf("expected the secondary endpoint to be used, got head epoch %d, %v", head.Epoch, err)
+	}
+}
+
+func TestSubscribeEventsRotatesEndpoints(t *testing.T) {
+	a := &fakeClient{events: make(chan beacon.Event)}
+	b := &fakeClient{events: make(chan beacon.Event)}
+	client, err := NewClient([]string{"a", "b"}, []beacon.Client{a, b}, time.Minute, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []chan beacon.Event{a.events, b.events, a.events}
+	for si, endpointEvents := range expected {
+		events, err := client.SubscribeEvents(context.Background(), []string{beacon.EventHead})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if events != (<-chan beacon.Event)(endpointEvents) {
+			t.Errorf("subscription %d used the wrong endpoint", si)
+		}
+	}
+}
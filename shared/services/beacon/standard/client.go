@@ -0,0 +1,739 @@
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestUrlFormat   = "%s%s"
+	RequestContentType = "application/json"
+
+	RequestSyncStatusPath            = "/eth/v1/node/syncing"
+	RequestEth2ConfigPath            = "/eth/v1/config/spec"
+	RequestEth2DepositContractMethod = "/eth/v1/config/deposit_contract"
+	RequestGenesisPath               = "/eth/v1/beacon/genesis"
+	RequestFinalityCheckpointsPath   = "/eth/v1/beacon/states/%s/finality_checkpoints"
+	RequestForkPath                  = "/eth/v1/beacon/states/%s/fork"
+	RequestValidatorsPath            = "/eth/v1/beacon/states/%s/validators"
+	RequestVoluntaryExitPath         = "/eth/v1/beacon/pool/voluntary_exits"
+	RequestBeaconBlockPath           = "/eth/v2/beacon/blocks/%s"
+	RequestValidatorSyncDuties       = "/eth/v1/validator/duties/sync/%s"
+	RequestValidatorProposerDuties   = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorAttesterDuties   = "/eth/v1/validator/duties/attester/%s"
+
+	MaxRequestValidatorsCount = 600
+)
+
+// Beacon client using the standard Beacon Node API
+// Client-specific behaviour is handled by wrapping this client
+type Client struct {
+	providerAddress string
+	clientType      beacon.BeaconClientType
+}
+
+// Create new standard Beacon Node API client
+func NewClient(providerAddress string, clientType beacon.BeaconClientType) *Client {
+	return &Client{
+		providerAddress: providerAddress,
+		clientType:      clientType,
+	}
+}
+
+// Close the client connection
+func (c *Client) Close() error {
+	return nil
+}
+
+// Get the beacon client type
+func (c *Client) GetClientType() beacon.BeaconClientType {
+	return c.clientType
+}
+
+// Get the node's sync status
+func (c *Client) GetSyncStatus(ctx context.Context) (beacon.SyncStatus, error) {
+
+	// Get sync status
+	syncStatus, err := c.getSyncStatus(ctx)
+	if err != nil {
+		return beacon.SyncStatus{}, err
+	}
+
+	// Calculate the progress
+	progress := float64(syncStatus.Data.HeadSlot) / float64(syncStatus.Data.HeadSlot+syncStatus.Data.SyncDistance)
+
+	// Return response
+	return beacon.SyncStatus{
+		Syncing:  syncStatus.Data.IsSyncing,
+		Progress: progress,
+	}, nil
+
+}
+
+// Get the eth2 config
+func (c *Client) GetEth2Config(ctx context.Context) (beacon.Eth2Config, error) {
+
+	// Data
+	var wg errgroup.Group
+	var eth2Config Eth2ConfigResponse
+	var genesis GenesisResponse
+
+	// Get eth2 config
+	wg.Go(func() error {
+		var err error
+		eth2Config, err = c.getEth2Config(ctx)
+		return err
+	})
+
+	// Get genesis
+	wg.Go(func() error {
+		var err error
+		genesis, err = c.getGenesis(ctx)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return beacon.Eth2Config{}, err
+	}
+
+	// Return response
+	return beacon.Eth2Config{
+		GenesisForkVersion:           genesis.Data.GenesisForkVersion,
+		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot,
+		GenesisEpoch:                 0,
+		GenesisTime:                  uint64(genesis.Data.GenesisTime),
+		SecondsPerEpoch:              uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
+		SlotsPerEpoch:                uint64(eth2Config.Data.SlotsPerEpoch),
+		EpochsPerSyncCommitteePeriod: uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
+	}, nil
+
+}
+
+// Get the eth2 deposit contract info
+func (c *Client) GetEth2DepositContract(ctx context.Context) (beacon.Eth2DepositContract, error) {
+
+	// Get the deposit contract
+	depositContract, err := c.getEth2DepositContract(ctx)
+	if err != nil {
+		return beacon.Eth2DepositContract{}, err
+	}
+
+	// Return response
+	return beacon.Eth2DepositContract{
+		ChainID: uint64(depositContract.Data.ChainID),
+		Address: depositContract.Data.Address,
+	}, nil
+}
+
+// Get the beacon head
+func (c *Client) GetBeaconHead(ctx context.Context) (beacon.BeaconHead, error) {
+
+	// Data
+	var wg errgroup.Group
+	var eth2Config beacon.Eth2Config
+	var finalityCheckpoints FinalityCheckpointsResponse
+
+	// Get eth2 config
+	wg.Go(func() error {
+		var err error
+		eth2Config, err = c.GetEth2Config(ctx)
+		return err
+	})
+
+	// Get finality checkpoints
+	wg.Go(func() error {
+		var err error
+		finalityCheckpoints, err = c.getFinalityCheckpoints(ctx, "head")
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return beacon.BeaconHead{}, err
+	}
+
+	// Return response
+	return beacon.BeaconHead{
+		Epoch:                  eth2.EpochAt(eth2Config, uint64(time.Now().Unix())),
+		FinalizedEpoch:         uint64(finalityCheckpoints.Data.Finalized.Epoch),
+		JustifiedEpoch:         uint64(finalityCheckpoints.Data.CurrentJustified.Epoch),
+		PreviousJustifiedEpoch: uint64(finalityCheckpoints.Data.PreviousJustified.Epoch),
+	}, nil
+
+}
+
+// Get a validator's status
+func (c *Client) GetValidatorStatus(ctx context.Context, pubkey types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (beacon.ValidatorStatus, error) {
+
+	// Get validator
+	validators, err := c.getValidatorsByOpts(ctx, []types.ValidatorPubkey{pubkey}, opts)
+	if err != nil {
+		return beacon.ValidatorStatus{}, err
+	}
+	if len(validators.Data) == 0 {
+		return beacon.ValidatorStatus{}, nil
+	}
+	validator := validators.Data[0]
+
+	// Return response
+	return beacon.ValidatorStatus{
+		Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+		Index:                      uint64(validator.Index),
+		WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+		Balance:                    uint64(validator.Balance),
+		EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+		Slashed:                    validator.Validator.Slashed,
+		ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+		ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+		ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+		WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+		Exists:                     true,
+	}, nil
+
+}
+
+// Get multiple validators' statuses
+func (c *Client) GetValidatorStatuses(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+
+	// Get validators
+	validators, err := c.getValidatorsByOpts(ctx, pubkeys, opts)
+	if err != nil {
+		return map[types.ValidatorPubkey]beacon.ValidatorStatus{}, err
+	}
+
+	// Build validator status map
+	statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	for _, validator := range validators.Data {
+
+		// Get validator pubkey
+		pubkey := types.BytesToValidatorPubkey(validator.Validator.Pubkey)
+
+		// Add status
+		statuses[pubkey] = beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      uint64(validator.Index),
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+
+	}
+
+	// Return
+	return statuses, nil
+
+}
+
+// Get whether validators have sync duties to perform at given epoch
+func (c *Client) GetValidatorSyncDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]bool, error) {
+
+	// Convert incoming uint64 validator indices into an array of string for the request
+	indicesStrings := make([]string, len(indices))
+
+	for i, index := range indices {
+		indicesStrings[i] = strconv.FormatUint(index, 10)
+	}
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(ctx, fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indicesStrings)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator sync duties: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+
+	var response SyncDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator sync duties data: %w", err)
+	}
+
+	// Map the results
+	validatorMap := make(map[uint64]bool)
+
+	for _, index := range indices {
+		validatorMap[index] = false
+		for _, duty := range response.Data {
+			if uint64(duty.ValidatorIndex) == index {
+				validatorMap[index] = true
+				break
+			}
+		}
+	}
+
+	return validatorMap, nil
+}
+
+// Sums proposer duties per validators for a given epoch
+func (c *Client) GetValidatorProposerDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+
+	// Perform the post request
+	responseBody, status, err := c.getRequest(ctx, fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator proposer duties: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+
+	var response ProposerDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator proposer duties data: %w", err)
+	}
+
+	// Map the results
+	proposerMap := make(map[uint64]uint64)
+
+	for _, index := range indices {
+		proposerMap[index] = 0
+		for _, duty := range response.Data {
+			if uint64(duty.ValidatorIndex) == index {
+				proposerMap[index]++
+			}
+		}
+	}
+
+	return proposerMap, nil
+}
+
+// Get validators' attester duties for a given epoch
+func (c *Client) GetValidatorAttesterDuties(ctx context.Context, indices []uint64, epoch uint64) ([]beacon.AttesterDuty, error) {
+
+	// Convert incoming uint64 validator indices into an array of string for the request
+	indicesStrings := make([]string, len(indices))
+	for i, index := range indices {
+		indicesStrings[i] = strconv.FormatUint(index, 10)
+	}
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(ctx, fmt.Sprintf(RequestValidatorAttesterDuties, strconv.FormatUint(epoch, 10)), indicesStrings)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator attester duties: %w", err)
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator attester duties: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var response AttesterDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator attester duties data: %w", err)
+	}
+
+	// Convert the results
+	duties := make([]beacon.AttesterDuty, len(response.Data))
+	for di, duty := range response.Data {
+		duties[di] = beacon.AttesterDuty{
+			ValidatorIndex:          uint64(duty.ValidatorIndex),
+			Slot:                    uint64(duty.Slot),
+			CommitteeIndex:          uint64(duty.CommitteeIndex),
+			CommitteeLength:         uint64(duty.CommitteeLength),
+			ValidatorCommitteeIndex: uint64(duty.ValidatorCommitteeIndex),
+		}
+	}
+	return duties, nil
+
+}
+
+// Get a validator's index
+func (c *Client) GetValidatorIndex(ctx context.Context, pubkey types.ValidatorPubkey) (uint64, error) {
+
+	// Get validator
+	validators, err := c.getValidatorsByOpts(ctx, []types.ValidatorPubkey{pubkey}, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(validators.Data) == 0 {
+		return 0, fmt.Errorf("Validator %s index %w", pubkey.Hex(), beacon.ErrNotFound)
+	}
+	validator := validators.Data[0]
+
+	// Return validator index
+	return uint64(validator.Index), nil
+
+}
+
+// Get the fork at the beacon head
+func (c *Client) GetFork(ctx context.Context) (beacon.Fork, error) {
+	fork, err := c.getFork(ctx, "head")
+	if err != nil {
+		return beacon.Fork{}, err
+	}
+	return beacon.Fork{
+		PreviousVersion: fork.Data.PreviousVersion,
+		CurrentVersion:  fork.Data.CurrentVersion,
+		Epoch:           uint64(fork.Data.Epoch),
+	}, nil
+}
+
+// Get domain data for a domain type at a given epoch
+func (c *Client) GetDomainData(ctx context.Context, domainType []byte, epoch uint64) ([]byte, error) {
+
+	// Data
+	var wg errgroup.Group
+	var genesis GenesisResponse
+	var fork ForkResponse
+
+	// Get genesis
+	wg.Go(func() error {
+		var err error
+		genesis, err = c.getGenesis(ctx)
+		return err
+	})
+
+	// Get fork
+	wg.Go(func() error {
+		var err error
+		fork, err = c.getFork(ctx, "head")
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return []byte{}, err
+	}
+
+	// Get fork version
+	var forkVersion []byte
+	if epoch < uint64(fork.Data.Epoch) {
+		forkVersion = fork.Data.PreviousVersion
+	} else {
+		forkVersion = fork.Data.CurrentVersion
+	}
+
+	// Compute & return domain
+	var dt [4]byte
+	copy(dt[:], domainType[:])
+	return eth2types.Domain(dt, forkVersion, genesis.Data.GenesisValidatorsRoot), nil
+
+}
+
+// Perform a voluntary exit on a validator
+func (c *Client) ExitValidator(ctx context.Context, validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+	return c.postVoluntaryExit(ctx, VoluntaryExitRequest{
+		Message: VoluntaryExitMessage{
+			Epoch:          uinteger(epoch),
+			ValidatorIndex: uinteger(validatorIndex),
+		},
+		Signature: signature.Bytes(),
+	})
+}
+
+// Get the ETH1 data for the target beacon block
+func (c *Client) GetEth1DataForEth2Block(ctx context.Context, blockId string) (beacon.Eth1Data, error) {
+
+	// Get the Beacon block
+	block, exists, err := c.getBeaconBlock(ctx, blockId)
+	if err != nil {
+		return beacon.Eth1Data{}, err
+	}
+	if !exists {
+		return beacon.Eth1Data{}, fmt.Errorf("Beacon block %s was %w", blockId, beacon.ErrNotFound)
+	}
+
+	// Convert the response to the eth1 data struct
+	return beacon.Eth1Data{
+		DepositRoot:  common.BytesToHash(block.Data.Message.Body.Eth1Data.DepositRoot),
+		DepositCount: uint64(block.Data.Message.Body.Eth1Data.DepositCount),
+		BlockHash:    common.BytesToHash(block.Data.Message.Body.Eth1Data.BlockHash),
+	}, nil
+
+}
+
+// Get a beacon block's proposer and attestations
+// Returns false if there is no block at the given slot
+func (c *Client) GetBeaconBlock(ctx context.Context, blockId string) (beacon.BeaconBlock, bool, error) {
+
+	// Get the Beacon block
+	block, exists, err := c.getBeaconBlock(ctx, blockId)
+	if err != nil || !exists {
+		return beacon.BeaconBlock{}, false, err
+	}
+
+	// Convert the response to the block struct
+	message := block.Data.Message
+	attestations := make([]beacon.AttestationInfo, len(message.Body.Attestations))
+	for ai, attestation := range message.Body.Attestations {
+		attestations[ai] = beacon.AttestationInfo{
+			Slot:            uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			AggregationBits: attestation.AggregationBits,
+		}
+	}
+	return beacon.BeaconBlock{
+		Slot:          uint64(message.Slot),
+		ProposerIndex: uint64(message.ProposerIndex),
+		Attestations:  attestations,
+	}, true, nil
+
+}
+
+// Get sync status
+func (c *Client) getSyncStatus(ctx context.Context) (SyncStatusResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, RequestSyncStatusPath)
+	if err != nil {
+		return SyncStatusResponse{}, fmt.Errorf("Could not get node sync status: %w", err)
+	} else if status != http.StatusOK {
+		return SyncStatusResponse{}, fmt.Errorf("Could not get node sync status: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var syncStatus SyncStatusResponse
+	if err := json.Unmarshal(responseBody, &syncStatus); err != nil {
+		return SyncStatusResponse{}, fmt.Errorf("Could not decode node sync status: %w", err)
+	}
+	return syncStatus, nil
+}
+
+// Get the eth2 config
+func (c *Client) getEth2Config(ctx context.Context) (Eth2ConfigResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, RequestEth2ConfigPath)
+	if err != nil {
+		return Eth2ConfigResponse{}, fmt.Errorf("Could not get eth2 config: %w", err)
+	} else if status != http.StatusOK {
+		return Eth2ConfigResponse{}, fmt.Errorf("Could not get eth2 config: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var eth2Config Eth2ConfigResponse
+	if err := json.Unmarshal(responseBody, &eth2Config); err != nil {
+		return Eth2ConfigResponse{}, fmt.Errorf("Could not decode eth2 config: %w", err)
+	}
+	return eth2Config, nil
+}
+
+// Get the eth2 deposit contract info
+func (c *Client) getEth2DepositContract(ctx context.Context) (Eth2DepositContractResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, RequestEth2DepositContractMethod)
+	if err != nil {
+		return Eth2DepositContractResponse{}, fmt.Errorf("Could not get eth2 deposit contract: %w", err)
+	} else if status != http.StatusOK {
+		return Eth2DepositContractResponse{}, fmt.Errorf("Could not get eth2 deposit contract: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var eth2DepositContract Eth2DepositContractResponse
+	if err := json.Unmarshal(responseBody, &eth2DepositContract); err != nil {
+		return Eth2DepositContractResponse{}, fmt.Errorf("Could not decode eth2 deposit contract: %w", err)
+	}
+	return eth2DepositContract, nil
+}
+
+// Get genesis information
+func (c *Client) getGenesis(ctx context.Context) (GenesisResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, RequestGenesisPath)
+	if err != nil {
+		return GenesisResponse{}, fmt.Errorf("Could not get genesis data: %w", err)
+	} else if status != http.StatusOK {
+		return GenesisResponse{}, fmt.Errorf("Could not get genesis data: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var genesis GenesisResponse
+	if err := json.Unmarshal(responseBody, &genesis); err != nil {
+		return GenesisResponse{}, fmt.Errorf("Could not decode genesis: %w", err)
+	}
+	return genesis, nil
+}
+
+// Get finality checkpoints
+func (c *Client) getFinalityCheckpoints(ctx context.Context, stateId string) (FinalityCheckpointsResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, fmt.Sprintf(RequestFinalityCheckpointsPath, stateId))
+	if err != nil {
+		return FinalityCheckpointsResponse{}, fmt.Errorf("Could not get finality checkpoints: %w", err)
+	} else if status != http.StatusOK {
+		return FinalityCheckpointsResponse{}, fmt.Errorf("Could not get finality checkpoints: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var finalityCheckpoints FinalityCheckpointsResponse
+	if err := json.Unmarshal(responseBody, &finalityCheckpoints); err != nil {
+		return FinalityCheckpointsResponse{}, fmt.Errorf("Could not decode finality checkpoints: %w", err)
+	}
+	return finalityCheckpoints, nil
+}
+
+// Get fork
+func (c *Client) getFork(ctx context.Context, stateId string) (ForkResponse, error) {
+	responseBody, status, err := c.getRequest(ctx, fmt.Sprintf(RequestForkPath, stateId))
+	if err != nil {
+		return ForkResponse{}, fmt.Errorf("Could not get fork data: %w", err)
+	} else if status != http.StatusOK {
+		return ForkResponse{}, fmt.Errorf("Could not get fork data: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var fork ForkResponse
+	if err := json.Unmarshal(responseBody, &fork); err != nil {
+		return ForkResponse{}, fmt.Errorf("Could not decode fork data: %w", err)
+	}
+	return fork, nil
+}
+
+// Get validators
+func (c *Client) getValidators(ctx context.Context, stateId string, pubkeys []string) (ValidatorsResponse, error) {
+	var query string
+	if len(pubkeys) > 0 {
+		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
+	}
+	responseBody, status, err := c.getRequest(ctx, fmt.Sprintf(RequestValidatorsPath, stateId)+query)
+	if err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
+	} else if status != http.StatusOK {
+		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return ValidatorsResponse{}, fmt.Errorf("Could not decode validators: %w", err)
+	}
+	return validators, nil
+}
+
+// Get validators by pubkeys and status options
+func (c *Client) getValidatorsByOpts(ctx context.Context, pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (ValidatorsResponse, error) {
+
+	// Get state ID
+	var stateId string
+	if opts == nil {
+		stateId = "head"
+	} else {
+
+		// Get eth2 config
+		eth2Config, err := c.getEth2Config(ctx)
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+
+		// Get slot nuimber
+		slot := opts.Epoch * uint64(eth2Config.Data.SlotsPerEpoch)
+		stateId = strconv.FormatInt(int64(slot), 10)
+
+	}
+
+	// Load validator data in batches & return
+	data := make([]Validator, 0, len(pubkeys))
+	for bsi := 0; bsi < len(pubkeys); bsi += MaxRequestValidatorsCount {
+
+		// Get batch start & end index
+		vsi := bsi
+		vei := bsi + MaxRequestValidatorsCount
+		if vei > len(pubkeys) {
+			vei = len(pubkeys)
+		}
+
+		// Get validator pubkeys for batch request
+		pubkeysHex := make([]string, vei-vsi)
+		for vi := vsi; vi < vei; vi++ {
+			pubkeysHex[vi-vsi] = hexutil.AddPrefix(pubkeys[vi].Hex())
+		}
+
+		// Get & add validators
+		validators, err := c.getValidators(ctx, stateId, pubkeysHex)
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		data = append(data, validators.Data...)
+
+	}
+	return ValidatorsResponse{Data: data}, nil
+
+}
+
+// Send voluntary exit request
+func (c *Client) postVoluntaryExit(ctx context.Context, request VoluntaryExitRequest) error {
+	responseBody, status, err := c.postRequest(ctx, RequestVoluntaryExitPath, request)
+	if err != nil {
+		return fmt.Errorf("Could not broadcast exit for validator at index %d: %w", request.Message.ValidatorIndex, err)
+	} else if status != http.StatusOK {
+		return fmt.Errorf("Could not broadcast exit for validator at index %d: %w", request.Message.ValidatorIndex, &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	return nil
+}
+
+// Get the target beacon block
+func (c *Client) getBeaconBlock(ctx context.Context, blockId string) (BeaconBlockResponse, bool, error) {
+	responseBody, status, err := c.getRequest(ctx, fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	if err != nil {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+	} else if status == http.StatusNotFound {
+		return BeaconBlockResponse{}, false, nil
+	} else if status != http.StatusOK {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", &beacon.HTTPError{StatusCode: status, Body: string(responseBody)})
+	}
+	var beaconBlock BeaconBlockResponse
+	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode beacon block data: %w", err)
+	}
+	return beaconBlock, true, nil
+}
+
+// Make a GET request to the beacon node
+func (c *Client) getRequest(ctx context.Context, requestPath string) ([]byte, int, error) {
+
+	// Send request
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	// Return
+	return body, response.StatusCode, nil
+
+}
+
+// Make a POST request to the beacon node
+func (c *Client) postRequest(ctx context.Context, requestPath string, requestBody interface{}) ([]byte, int, error) {
+
+	// Get request body
+	requestBodyBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	requestBodyReader := bytes.NewReader(requestBodyBytes)
+
+	// Send request
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), requestBodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Content-Type", RequestContentType)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	// Return
+	return body, response.StatusCode, nil
+
+}
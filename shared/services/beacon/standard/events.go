@@ -0,0 +1,175 @@
+package standard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Config
+const (
+	RequestEventsPath = "/eth/v1/events?topics=%s"
+
+	EventBufferSize        = 16
+	EventReconnectDelay    = 5 * time.Second
+	MaxEventReconnectDelay = 2 * time.Minute
+)
+
+// Subscribe to beacon node events on the given topics
+// The event stream is reconnected if it drops, and the channel is closed once ctx is cancelled
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string) (<-chan beacon.Event, error) {
+
+	// Get the slots per epoch to derive head event epochs
+	eth2Config, err := c.getEth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slotsPerEpoch := uint64(eth2Config.Data.SlotsPerEpoch)
+
+	// Open the event stream
+	stream, err := c.openEventStream(ctx, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read events until ctx is cancelled
+	events := make(chan beacon.Event, EventBufferSize)
+	go func() {
+		defer close(events)
+		for {
+			readEvents(ctx, stream, slotsPerEpoch, events)
+			_ = stream.Close()
+
+			// Reconnect, backing off while the beacon node is unavailable
+			reconnectDelay := EventReconnectDelay
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(reconnectDelay):
+				}
+				stream, err = c.openEventStream(ctx, topics)
+				if err == nil {
+					break
+				}
+				reconnectDelay *= 2
+				if reconnectDelay > MaxEventReconnectDelay {
+					reconnectDelay = MaxEventReconnectDelay
+				}
+			}
+		}
+	}()
+	return events, nil
+
+}
+
+// Open an event stream request
+func (c *Client) openEventStream(ctx context.Context, topics []string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, fmt.Sprintf(RequestEventsPath, strings.Join(topics, ","))), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Could not subscribe to beacon events: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("Could not subscribe to beacon events: %w", &beacon.HTTPError{StatusCode: response.StatusCode, Body: string(body)})
+	}
+	return response.Body, nil
+}
+
+// Read server-sent events from a stream until it ends or ctx is cancelled
+// Events which can't be decoded are skipped
+func readEvents(ctx context.Context, stream io.Reader, slotsPerEpoch uint64, events chan<- beacon.Event) {
+	reader := bufio.NewReader(stream)
+	var topic string
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		// Dispatch the event on a blank line
+		if line == "" {
+			if topic != "" && data.Len() > 0 {
+				if event, err := decodeEvent(topic, []byte(data.String()), slotsPerEpoch); err == nil {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			topic = ""
+			data.Reset()
+			continue
+		}
+
+		// Read event fields, ignoring comments
+		if strings.HasPrefix(line, "event:") {
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		} else if strings.HasPrefix(line, "data:") {
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+}
+
+// Decode an event's data by topic
+func decodeEvent(topic string, data []byte, slotsPerEpoch uint64) (beacon.Event, error) {
+	event := beacon.Event{Topic: topic}
+	switch topic {
+	case beacon.EventHead:
+		var head HeadEvent
+		if err := json.Unmarshal(data, &head); err != nil {
+			return beacon.Event{}, err
+		}
+		event.Slot = uint64(head.Slot)
+		event.Block = head.Block
+		if slotsPerEpoch > 0 {
+			event.Epoch = event.Slot / slotsPerEpoch
+		}
+	case beacon.EventFinalizedCheckpoint:
+		var checkpoint FinalizedCheckpointEvent
+		if err := json.Unmarshal(data, &checkpoint); err != nil {
+			return beacon.Event{}, err
+		}
+		event.Epoch = uint64(checkpoint.Epoch)
+		event.Block = checkpoint.Block
+	case beacon.EventChainReorg:
+		var reorg ChainReorgEvent
+		if err := json.Unmarshal(data, &reorg); err != nil {
+			return beacon.Event{}, err
+		}
+		event.Slot = uint64(reorg.Slot)
+		event.Epoch = uint64(reorg.Epoch)
+		event.Depth = uint64(reorg.Depth)
+		event.Block = reorg.NewHeadBlock
+	case beacon.EventVoluntaryExit:
+		var exit VoluntaryExitEvent
+		if err := json.Unmarshal(data, &exit); err != nil {
+			return beacon.Event{}, err
+		}
+		event.Epoch = uint64(exit.Message.Epoch)
+		event.ValidatorIndex = uint64(exit.Message.ValidatorIndex)
+	default:
+		return beacon.Event{}, fmt.Errorf("Unknown event topic '%s'", topic)
+	}
+	return event, nil
+}
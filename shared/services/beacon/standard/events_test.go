@@ -0,0 +1,37 @@
+package standard
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+func TestReadEvents(t *testing.T) {
+	stream := strings.NewReader(": keepalive\n\n" +
+		"event: head\ndata: {\"slot\":\"65\",\"block\":\"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf\",\"epoch_transition\":false}\n\n" +
+		"event: finalized_checkpoint\r\ndata: {\"block\":\"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf\",\"epoch\":\"2\"}\r\n\r\n" +
+		"event: voluntary_exit\ndata: {\"message\":{\"epoch\":\"3\",\"validator_index\":\"42\"},\"signature\":\"0x00\"}\n\n" +
+		"event: head\ndata: not json\n\n")
+	events := make(chan beacon.Event, 4)
+	readEvents(context.Background(), stream, 32, events)
+	close(events)
+
+	received := []beacon.Event{}
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(received))
+	}
+	if received[0].Topic != beacon.EventHead || received[0].Slot != 65 || received[0].Epoch != 2 {
+		t.Errorf("unexpected head event %+v", received[0])
+	}
+	if received[1].Topic != beacon.EventFinalizedCheckpoint || received[1].Epoch != 2 {
+		t.Errorf("unexpected finalized checkpoint event %+v", received[1])
+	}
+	if received[2].Topic != beacon.EventVoluntaryExit || received[2].ValidatorIndex != 42 || received[2].Epoch != 3 {
+		t.Errorf("unexpected voluntary exit event %+v", received[2])
+	}
+}
@@ -1,4 +1,4 @@
-package lighthouse
+package standard
 
 import (
 	"encoding/hex"
@@ -65,21 +65,31 @@ type ForkResponse struct {
 		PreviousVersion byteArray `json:"previous_version"`
 		CurrentVersion  byteArray `json:"current_version"`
 		Epoch           uinteger  `json:"epoch"`
-	}
+	} `json:"data"`
 }
 type BeaconBlockResponse struct {
 	Data struct {
 		Message struct {
-			Body struct {
+			Slot          uinteger `json:"slot"`
+			ProposerIndex uinteger `json:"proposer_index"`
+			Body          struct {
 				Eth1Data struct {
 					DepositRoot  byteArray `json:"deposit_root"`
 					DepositCount uinteger  `json:"deposit_count"`
 					BlockHash    byteArray `json:"block_hash"`
 				} `json:"eth1_data"`
+				Attestations []Attestation `json:"attestations"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
 }
+type Attestation struct {
+	AggregationBits byteArray `json:"aggregation_bits"`
+	Data            struct {
+		Slot  uinteger `json:"slot"`
+		Index uinteger `json:"index"`
+	} `json:"data"`
+}
 type ValidatorsResponse struct {
 	Data []Validator `json:"data"`
 }
@@ -112,6 +122,16 @@ type ProposerDutiesResponse struct {
 type ProposerDuty struct {
 	ValidatorIndex uinteger `json:"validator_index"`
 }
+type AttesterDutiesResponse struct {
+	Data []AttesterDuty `json:"data"`
+}
+type AttesterDuty struct {
+	ValidatorIndex          uinteger `json:"validator_index"`
+	Slot                    uinteger `json:"slot"`
+	CommitteeIndex          uinteger `json:"committee_index"`
+	CommitteeLength         uinteger `json:"committee_length"`
+	ValidatorCommitteeIndex uinteger `json:"validator_committee_index"`
+}
 
 // Unsigned integer type
 type uinteger uint64
@@ -164,3 +184,22 @@ func (b *byteArray) UnmarshalJSON(data []byte) error {
 	return nil
 
 }
+
+// Event types
+type HeadEvent struct {
+	Slot  uinteger    `json:"slot"`
+	Block common.Hash `json:"block"`
+}
+type FinalizedCheckpointEvent struct {
+	Block common.Hash `json:"block"`
+	Epoch uinteger    `json:"epoch"`
+}
+type ChainReorgEvent struct {
+	Slot         uinteger    `json:"slot"`
+	Depth        uinteger    `json:"depth"`
+	NewHeadBlock common.Hash `json:"new_head_block"`
+	Epoch        uinteger    `json:"epoch"`
+}
+type VoluntaryExitEvent struct {
+	Message VoluntaryExitMessage `json:"message"`
+}
@@ -0,0 +1,46 @@
+package teku
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/standard"
+)
+
+func TestGetSyncStatusUsesSyncDistance(t *testing.T) {
+
+	// Serve a sync status which isn't flagged as syncing but is behind the head of the chain
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != standard.RequestSyncStatusPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"data":{"is_syncing":false,"head_slot":"95","sync_distance":"5"}}`))
+	}))
+	defer server.Close()
+
+	// Standard clients use the syncing flag
+	syncStatus, err := standard.NewClient(server.URL, beacon.SplitProcess).GetSyncStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if syncStatus.Syncing {
+		t.Error("expected the standard client to report the node as synced")
+	}
+	if syncStatus.Progress != 0.95 {
+		t.Errorf("expected a sync progress of 0.95, got %f", syncStatus.Progress)
+	}
+
+	// Teku uses the sync distance
+	syncStatus, err = NewClient(server.URL).GetSyncStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !syncStatus.Syncing {
+		t.Error("expected the Teku client to report the node as syncing")
+	}
+
+}
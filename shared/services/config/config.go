@@ -5,7 +5,9 @@ import (
 	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/imdario/mergo"
 	"github.com/urfave/cli"
@@ -14,6 +16,10 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 )
 
+// Default time to wait for running tasks to finish when a daemon is stopped
+// Kept below docker's default stop timeout of 10 seconds
+const DefaultShutdownTimeout = 8 * time.Second
+
 // Rocket Pool config
 type RocketPoolConfig struct {
 	Rocketpool struct {
@@ -23,54 +29,87 @@ type RocketPoolConfig struct {
 		RPLFaucetAddress     string `yaml:"rplFaucetAddress,omitempty"`
 	} `yaml:"rocketpool,omitempty"`
 	Smartnode struct {
-		ProjectName               string  `yaml:"projectName,omitempty"`
-		GraffitiVersion           string  `yaml:"graffitiVersion,omitempty"`
-		Image                     string  `yaml:"image,omitempty"`
-		PasswordPath              string  `yaml:"passwordPath,omitempty"`
-		WalletPath                string  `yaml:"walletPath,omitempty"`
-		ValidatorKeychainPath     string  `yaml:"validatorKeychainPath,omitempty"`
-		ValidatorRestartCommand   string  `yaml:"validatorRestartCommand,omitempty"`
-		MaxFee                    float64 `yaml:"maxFee,omitempty"`
-		MaxPriorityFee            float64 `yaml:"maxPriorityFee,omitempty"`
-		GasLimit                  uint64  `yaml:"gasLimit,omitempty"`
-		RplClaimGasThreshold      float64 `yaml:"rplClaimGasThreshold,omitempty"`
-		MinipoolStakeGasThreshold float64 `yaml:"minipoolStakeGasThreshold,omitempty"`
-		TxWatchUrl                string  `yaml:"txWatchUrl,omitempty"`
-		StakeUrl                  string  `yaml:"stakeUrl,omitempty"`
+		ProjectName               string          `yaml:"projectName,omitempty"`
+		GraffitiVersion           string          `yaml:"graffitiVersion,omitempty"`
+		Image                     string          `yaml:"image,omitempty"`
+		PasswordPath              string          `yaml:"passwordPath,omitempty"`
+		PasswordBackend           PasswordBackend `yaml:"passwordBackend,omitempty"`
+		WalletPath                string          `yaml:"walletPath,omitempty"`
+		ValidatorKeychainPath     string          `yaml:"validatorKeychainPath,omitempty"`
+		PerformancePath           string          `yaml:"performancePath,omitempty"`
+		BalanceHistoryPath        string          `yaml:"balanceHistoryPath,omitempty"`
+		PendingTransactionsPath   string          `yaml:"pendingTransactionsPath,omitempty"`
+		ValidatorRestartCommand   string          `yaml:"validatorRestartCommand,omitempty"`
+		RemoteSigner              RemoteSigner    `yaml:"remoteSigner,omitempty"`
+		NodeSigner                NodeSigner      `yaml:"nodeSigner,omitempty"`
+		NodeAddress               string          `yaml:"nodeAddress,omitempty"`
+		KeymanagerApi             KeymanagerApi   `yaml:"keymanagerApi,omitempty"`
+		MaxFee                    float64         `yaml:"maxFee,omitempty"`
+		MaxPriorityFee            float64         `yaml:"maxPriorityFee,omitempty"`
+		GasLimit                  uint64          `yaml:"gasLimit,omitempty"`
+		GasOracle                 GasOracle       `yaml:"gasOracle,omitempty"`
+		Transactions              Transactions    `yaml:"transactions,omitempty"`
+		RplClaimGasThreshold      float64         `yaml:"rplClaimGasThreshold,omitempty"`
+		MinipoolStakeGasThreshold float64         `yaml:"minipoolStakeGasThreshold,omitempty"`
+		TxWatchUrl                string          `yaml:"txWatchUrl,omitempty"`
+		StakeUrl                  string          `yaml:"stakeUrl,omitempty"`
 	} `yaml:"smartnode,omitempty"`
+	Tasks struct {
+		Node            map[string]TaskConfig `yaml:"node,omitempty"`
+		Watchtower      map[string]TaskConfig `yaml:"watchtower,omitempty"`
+		ShutdownTimeout string                `yaml:"shutdownTimeout,omitempty"`
+	} `yaml:"tasks,omitempty"`
 	Chains struct {
 		Platform     Chain `yaml:"platform,omitempty"`
 		Eth1Fallback Chain `yaml:"eth1Fallback,omitempty"`
 	} `yaml:"chains,omitempty"`
-	Metrics Metrics `yaml:"metrics,omitempty"`
+	Metrics   Metrics   `yaml:"metrics,omitempty"`
+	ApiServer ApiServer `yaml:"apiServer,omitempty"`
 }
 type Chain struct {
-	Provider           string `yaml:"provider,omitempty"`
-	WsProvider         string `yaml:"wsProvider,omitempty"`
-	FallbackProvider   string `yaml:"fallbackProvider,omitempty"`
-	FallbackWsProvider string `yaml:"fallbackWsProvider,omitempty"`
-	ReconnectDelay     string `yaml:"reconnectDelay,omitempty"`
-	PruneProvisioner   string `yaml:"pruneProvisioner,omitempty"`
-	ChainID            string `yaml:"chainID,omitempty"`
+	Provider           string         `yaml:"provider,omitempty"`
+	WsProvider         string         `yaml:"wsProvider,omitempty"`
+	FallbackProvider   string         `yaml:"fallbackProvider,omitempty"`
+	FallbackWsProvider string         `yaml:"fallbackWsProvider,omitempty"`
+	ReconnectDelay     string         `yaml:"reconnectDelay,omitempty"`
+	PruneProvisioner   string         `yaml:"pruneProvisioner,omitempty"`
+	ChainID            string         `yaml:"chainID,omitempty"`
+	BeaconFailover     BeaconFailover `yaml:"beaconFailover,omitempty"`
+	BeaconCacheTTL     string         `yaml:"beaconCacheTTL,omitempty"`
 	Client             struct {
 		Options  []ClientOption `yaml:"options,omitempty"`
 		Selected string         `yaml:"selected,omitempty"`
 		Params   []UserParam    `yaml:"params,omitempty"`
 	} `yaml:"client,omitempty"`
 }
+type BeaconFailover struct {
+	Providers           []BeaconProvider `yaml:"providers,omitempty"`
+	HealthCheckInterval string           `yaml:"healthCheckInterval,omitempty"`
+	Quorum              int              `yaml:"quorum,omitempty"`
+}
+type BeaconProvider struct {
+	Client   string `yaml:"client,omitempty"`
+	Provider string `yaml:"provider,omitempty"`
+}
 type ClientOption struct {
-	ID                    string        `yaml:"id,omitempty"`
-	Name                  string        `yaml:"name,omitempty"`
-	Desc                  string        `yaml:"desc,omitempty"`
-	Image                 string        `yaml:"image,omitempty"`
-	BeaconImage           string        `yaml:"beaconImage,omitempty"`
-	ValidatorImage        string        `yaml:"validatorImage,omitempty"`
-	Link                  string        `yaml:"link,omitempty"`
-	CompatibleEth2Clients string        `yaml:"compatibleEth2Clients,omitempty"`
-	EventLogInterval      string        `yaml:"eventLogInterval,omitempty"`
-	Supermajority         bool          `yaml:"supermajority,omitempty"`
-	Params                []ClientParam `yaml:"params,omitempty"`
-	Fallback              bool          `yaml:"fallback,omitempty"`
+	ID                    string                     `yaml:"id,omitempty"`
+	Name                  string                     `yaml:"name,omitempty"`
+	Desc                  string                     `yaml:"desc,omitempty"`
+	Image                 string                     `yaml:"image,omitempty"`
+	BeaconImage           string                     `yaml:"beaconImage,omitempty"`
+	ValidatorImage        string                     `yaml:"validatorImage,omitempty"`
+	Link                  string                     `yaml:"link,omitempty"`
+	CompatibleEth2Clients string                     `yaml:"compatibleEth2Clients,omitempty"`
+	EventLogInterval      string                     `yaml:"eventLogInterval,omitempty"`
+	Supermajority         bool                       `yaml:"supermajority,omitempty"`
+	Params                []ClientParam              `yaml:"params,omitempty"`
+	Fallback              bool                       `yaml:"fallback,omitempty"`
+	SlashingProtection    SlashingProtectionCommands `yaml:"slashingProtection,omitempty"`
+}
+type SlashingProtectionCommands struct {
+	ContainerSuffix string `yaml:"containerSuffix,omitempty"`
+	ExportCommand   string `yaml:"exportCommand,omitempty"`
+	ImportCommand   string `yaml:"importCommand,omitempty"`
 }
 type ClientParam struct {
 	Name      string `yaml:"name,omitempty"`
@@ -93,6 +132,57 @@ type Metrics struct {
 	Params   []ClientParam `yaml:"params,omitempty"`
 	Settings []UserParam   `yaml:"settings,omitempty"`
 }
+type TaskConfig struct {
+	Disabled   bool   `yaml:"disabled,omitempty"`
+	Interval   string `yaml:"interval,omitempty"`
+	Jitter     string `yaml:"jitter,omitempty"`
+	Timeout    string `yaml:"timeout,omitempty"`
+	MaxBackoff string `yaml:"maxBackoff,omitempty"`
+}
+type PasswordBackend struct {
+	Type            string `yaml:"type,omitempty"`
+	UnlockPath      string `yaml:"unlockPath,omitempty"`
+	Env             string `yaml:"env,omitempty"`
+	Command         string `yaml:"command,omitempty"`
+	Url             string `yaml:"url,omitempty"`
+	TokenPath       string `yaml:"tokenPath,omitempty"`
+	Field           string `yaml:"field,omitempty"`
+	RequireExternal bool   `yaml:"requireExternal,omitempty"`
+}
+type GasOracle struct {
+	Providers          []string `yaml:"providers,omitempty"`
+	Blocks             uint64   `yaml:"blocks,omitempty"`
+	SlowPercentile     float64  `yaml:"slowPercentile,omitempty"`
+	StandardPercentile float64  `yaml:"standardPercentile,omitempty"`
+	FastPercentile     float64  `yaml:"fastPercentile,omitempty"`
+}
+type Transactions struct {
+	BumpBlocks  uint64  `yaml:"bumpBlocks,omitempty"`
+	BumpPercent float64 `yaml:"bumpPercent,omitempty"`
+	MaxFee      float64 `yaml:"maxFee,omitempty"`
+}
+type NodeSigner struct {
+	Url       string `yaml:"url,omitempty"`
+	Type      string `yaml:"type,omitempty"`
+	Address   string `yaml:"address,omitempty"`
+	TokenPath string `yaml:"tokenPath,omitempty"`
+}
+type RemoteSigner struct {
+	Url           string `yaml:"url,omitempty"`
+	ValidatorUrl  string `yaml:"validatorUrl,omitempty"`
+	AuthTokenPath string `yaml:"authTokenPath,omitempty"`
+}
+type KeymanagerApi struct {
+	Url       string `yaml:"url,omitempty"`
+	TokenPath string `yaml:"tokenPath,omitempty"`
+}
+type ApiServer struct {
+	SocketPath      string `yaml:"socketPath,omitempty"`
+	ListenAddress   string `yaml:"listenAddress,omitempty"`
+	TokenPath       string `yaml:"tokenPath,omitempty"`
+	ClientUrl       string `yaml:"clientUrl,omitempty"`
+	ClientTokenPath string `yaml:"clientTokenPath,omitempty"`
+}
 
 // Get the selected clients from a config
 func (config *RocketPoolConfig) GetSelectedEth1Client() *ClientOption {
@@ -163,6 +253,88 @@ func (client *ClientOption) GetValidatorImage() string {
 	}
 }
 
+// Get the Eth 2.0 network name of the platform chain
+func (config *RocketPoolConfig) GetEth2Network() string {
+	if config.Chains.Platform.ChainID == "5" {
+		return "prater"
+	}
+	return "mainnet"
+}
+
+// Get the slashing protection interchange commands for a client
+// Commands which aren't configured default to those of the supported validator clients
+func (config *RocketPoolConfig) GetSlashingProtectionCommands(client ClientOption) SlashingProtectionCommands {
+	commands := client.SlashingProtection
+	defaults := getDefaultSlashingProtectionCommands(client.ID, config.GetEth2Network())
+	if commands.ContainerSuffix == "" {
+		commands.ContainerSuffix = defaults.ContainerSuffix
+	}
+	if commands.ExportCommand == "" {
+		commands.ExportCommand = defaults.ExportCommand
+	}
+	if commands.ImportCommand == "" {
+		commands.ImportCommand = defaults.ImportCommand
+	}
+	return commands
+}
+
+// Get the default slashing protection interchange commands for a validator client on a network
+// The commands run in the client's validator image with the volumes of its container; the interchange file path is substituted for %s
+func getDefaultSlashingProtectionCommands(clientID string, network string) SlashingProtectionCommands {
+	switch clientID {
+	case "lighthouse":
+		return SlashingProtectionCommands{
+			ExportCommand: "lighthouse account validator slashing-protection export %s --datadir /validators/lighthouse --network " + network,
+			ImportCommand: "lighthouse account validator slashing-protection import %s --datadir /validators/lighthouse --network " + network,
+		}
+	case "nimbus":
+		return SlashingProtectionCommands{
+			ContainerSuffix: "_eth2",
+			ExportCommand:   "/home/user/nimbus-eth2/build/nimbus_beacon_node slashingdb export %s --data-dir=/ethclient/nimbus --validators-dir=/validators/nimbus/validators",
+			ImportCommand:   "/home/user/nimbus-eth2/build/nimbus_beacon_node slashingdb import %s --data-dir=/ethclient/nimbus --validators-dir=/validators/nimbus/validators",
+		}
+	case "prysm":
+		return SlashingProtectionCommands{
+			ExportCommand: "/app/cmd/validator/validator slashing-protection-history export --accept-terms-of-use --" + network + " --datadir=/validators/prysm-non-hd/direct --slashing-protection-export-dir=/tmp/prysm-export && mv /tmp/prysm-export/slashing_protection.json %s",
+			ImportCommand: "/app/cmd/validator/validator slashing-protection-history import --accept-terms-of-use --" + network + " --datadir=/validators/prysm-non-hd/direct --slashing-protection-json-file=%s",
+		}
+	case "teku":
+		return SlashingProtectionCommands{
+			ExportCommand: "/opt/teku/bin/teku slashing-protection export --data-path=/validators/teku --to=%s",
+			ImportCommand: "/opt/teku/bin/teku slashing-protection import --data-path=/validators/teku --from=%s",
+		}
+	}
+	return SlashingProtectionCommands{}
+}
+
+// Get the client option which runs a validator image
+func (chain *Chain) GetClientByValidatorImage(image string) *ClientOption {
+	for i := range chain.Client.Options {
+		if chain.Client.Options[i].GetValidatorImage() == image {
+			return &chain.Client.Options[i]
+		}
+	}
+	return nil
+}
+
+// Check that the selected validator client can sign through the remote signer, if one is configured
+// Only lighthouse & nimbus have their remote signer definitions written when validator keys are stored
+func (config *RocketPoolConfig) ValidateRemoteSigner() error {
+	if config.Smartnode.RemoteSigner.Url == "" {
+		return nil
+	}
+	client := config.GetSelectedEth2Client()
+	if client == nil {
+		return nil
+	}
+	switch client.ID {
+	case "lighthouse", "nimbus":
+		return nil
+	default:
+		return fmt.Errorf("The %s validator client can't be used with a remote signer; please select Lighthouse or Nimbus, or remove the remote signer from your settings", client.Name)
+	}
+}
+
 // Serialize a config to yaml bytes
 func (config *RocketPoolConfig) Serialize() ([]byte, error) {
 	bytes, err := yaml.Marshal(config)
@@ -365,3 +537,47 @@ func (config *RocketPoolConfig) GetGasLimit() (uint64, error) {
 	return config.Smartnode.GasLimit, nil
 
 }
+
+// Parse and return the time daemons wait for running tasks to finish when shutting down
+func (config *RocketPoolConfig) GetShutdownTimeout() (time.Duration, error) {
+
+	// No shutdown timeout specified
+	if config.Tasks.ShutdownTimeout == "" {
+		return DefaultShutdownTimeout, nil
+	}
+
+	// Parse
+	shutdownTimeout, err := time.ParseDuration(config.Tasks.ShutdownTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid shutdown timeout '%s': %w", config.Tasks.ShutdownTimeout, err)
+	}
+	return shutdownTimeout, nil
+
+}
+
+// Get the path of the validator performance file
+// Defaults to the wallet's directory
+func (config *RocketPoolConfig) GetPerformancePath() string {
+	if config.Smartnode.PerformancePath != "" {
+		return os.ExpandEnv(config.Smartnode.PerformancePath)
+	}
+	return filepath.Join(filepath.Dir(os.ExpandEnv(config.Smartnode.WalletPath)), "performance.json")
+}
+
+// Get the path of the validator balance history database
+// Defaults to the wallet's directory
+func (config *RocketPoolConfig) GetBalanceHistoryPath() string {
+	if config.Smartnode.BalanceHistoryPath != "" {
+		return os.ExpandEnv(config.Smartnode.BalanceHistoryPath)
+	}
+	return filepath.Join(filepath.Dir(os.ExpandEnv(config.Smartnode.WalletPath)), "balance-history")
+}
+
+// Get the path of the pending transaction database
+// Defaults to the wallet's directory
+func (config *RocketPoolConfig) GetPendingTransactionsPath() string {
+	if config.Smartnode.PendingTransactionsPath != "" {
+		return os.ExpandEnv(config.Smartnode.PendingTransactionsPath)
+	}
+	return filepath.Join(filepath.Dir(os.ExpandEnv(config.Smartnode.WalletPath)), "pending-transactions")
+}
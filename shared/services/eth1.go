@@ -6,7 +6,7 @@ import (
 	"math/big"
 )
 
-func GetEthClientLatestBlockTimestamp(c *cli.Context) (uint64, error) {
+func GetEthClientLatestBlockTimestamp(ctx context.Context, c *cli.Context) (uint64, error) {
 	// Get eth client
 	var err error
 	ec, err := GetEthClientProxy(c)
@@ -15,14 +15,14 @@ func GetEthClientLatestBlockTimestamp(c *cli.Context) (uint64, error) {
 	}
 
 	// Get latest block number
-	blockNumber, err := ec.BlockNumber(context.Background())
+	blockNumber, err := ec.BlockNumber(ctx)
 	if err != nil {
 		return 0, err
 	}
 	blockNumberBig := big.NewInt(0).SetUint64(blockNumber)
 
 	// Get latest block
-	header, err := ec.HeaderByNumber(context.Background(), blockNumberBig)
+	header, err := ec.HeaderByNumber(ctx, blockNumberBig)
 	if err != nil {
 		return 0, err
 	}
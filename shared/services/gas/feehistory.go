@@ -0,0 +1,166 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Config
+const (
+	DefaultFeeHistoryBlocks   = 20
+	DefaultSlowPercentile     = 20
+	DefaultStandardPercentile = 50
+	DefaultFastPercentile     = 90
+	RapidBaseFeeMultiplier    = 2
+	FeeHistoryTimeout         = 10 * time.Second
+)
+
+// Execution client RPC interface
+type RpcCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Gas price provider driven by the execution client's fee history
+// Tiers are percentiles of the recent base fees; the rapid tier allows for the next block's base fee to double
+type FeeHistoryProvider struct {
+	caller             RpcCaller
+	blocks             uint64
+	slowPercentile     float64
+	standardPercentile float64
+	fastPercentile     float64
+}
+
+// eth_feeHistory response
+type feeHistory struct {
+	OldestBlock   *hexutil.Big     `json:"oldestBlock"`
+	Reward        [][]*hexutil.Big `json:"reward"`
+	BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64        `json:"gasUsedRatio"`
+}
+
+// Create new fee history provider
+func NewFeeHistoryProvider(caller RpcCaller, oracleCfg config.GasOracle) (*FeeHistoryProvider, error) {
+	provider := &FeeHistoryProvider{
+		caller:             caller,
+		blocks:             oracleCfg.Blocks,
+		slowPercentile:     oracleCfg.SlowPercentile,
+		standardPercentile: oracleCfg.StandardPercentile,
+		fastPercentile:     oracleCfg.FastPercentile,
+	}
+	if provider.blocks == 0 {
+		provider.blocks = DefaultFeeHistoryBlocks
+	}
+	if provider.slowPercentile == 0 {
+		provider.slowPercentile = DefaultSlowPercentile
+	}
+	if provider.standardPercentile == 0 {
+		provider.standardPercentile = DefaultStandardPercentile
+	}
+	if provider.fastPercentile == 0 {
+		provider.fastPercentile = DefaultFastPercentile
+	}
+	for _, percentile := range []float64{provider.slowPercentile, provider.standardPercentile, provider.fastPercentile} {
+		if percentile < 0 || percentile > 100 {
+			return nil, fmt.Errorf("Invalid gas price percentile %f - must be between 0 and 100", percentile)
+		}
+	}
+	return provider, nil
+}
+
+// Get the provider name
+func (p *FeeHistoryProvider) GetName() string {
+	return FeeHistoryProviderName
+}
+
+// Get gas prices
+func (p *FeeHistoryProvider) GetGasPrices() (GasPrices, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), FeeHistoryTimeout)
+	defer cancel()
+
+	// Get fee history, including the standard percentile of priority fees paid
+	var history feeHistory
+	if err := p.caller.CallContext(ctx, &history, "eth_feeHistory", hexutil.Uint64(p.blocks), "latest", []float64{p.standardPercentile}); err != nil {
+		return GasPrices{}, fmt.Errorf("Could not get fee history: %w", err)
+	}
+	if len(history.BaseFeePerGas) == 0 {
+		return GasPrices{}, errors.New("The execution client returned an empty fee history")
+	}
+
+	// Get base fees; the last entry is the next block's base fee
+	baseFees := make([]*big.Int, len(history.BaseFeePerGas))
+	for bi, baseFee := range history.BaseFeePerGas {
+		if baseFee == nil {
+			return GasPrices{}, errors.New("The execution client returned a fee history without base fees")
+		}
+		baseFees[bi] = baseFee.ToInt()
+	}
+	nextBaseFee := baseFees[len(baseFees)-1]
+
+	// Get priority fee suggestion, falling back to the fee history
+	priorityFee := p.getPriorityFee(ctx, history)
+
+	// Return; no tier is below the next block's base fee, or its transactions couldn't be included
+	return GasPrices{
+		Tiers: []GasPriceTier{
+			{Name: "Rapid", MaxFeeWei: new(big.Int).Mul(nextBaseFee, big.NewInt(RapidBaseFeeMultiplier))},
+			{Name: "Fast", MaxFeeWei: maxBig(getPercentile(baseFees, p.fastPercentile), nextBaseFee)},
+			{Name: "Standard", MaxFeeWei: maxBig(getPercentile(baseFees, p.standardPercentile), nextBaseFee)},
+			{Name: "Slow", MaxFeeWei: maxBig(getPercentile(baseFees, p.slowPercentile), nextBaseFee)},
+		},
+		PriorityFeeWei: priorityFee,
+	}, nil
+
+}
+
+// Get the suggested priority fee from the execution client, or the median of the fee history's rewards
+func (p *FeeHistoryProvider) getPriorityFee(ctx context.Context, history feeHistory) *big.Int {
+	var priorityFee hexutil.Big
+	if err := p.caller.CallContext(ctx, &priorityFee, "eth_maxPriorityFeePerGas"); err == nil {
+		return priorityFee.ToInt()
+	}
+	rewards := []*big.Int{}
+	for bi, blockRewards := range history.Reward {
+		if bi < len(history.GasUsedRatio) && history.GasUsedRatio[bi] == 0 {
+			continue
+		}
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0].ToInt())
+		}
+	}
+	if len(rewards) == 0 {
+		return nil
+	}
+	return getPercentile(rewards, 50)
+}
+
+// Get a percentile of a set of values
+func getPercentile(values []*big.Int, percentile float64) *big.Int {
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	index := int(math.Ceil(percentile/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return new(big.Int).Set(sorted[index])
+}
+
+// Get the larger of two values
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return new(big.Int).Set(b)
+	}
+	return a
+}
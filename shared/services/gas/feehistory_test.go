@@ -0,0 +1,76 @@
+package gas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Execution client returning canned RPC responses
+type fakeCaller struct {
+	responses map[string]string
+}
+
+func (f *fakeCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	response, ok := f.responses[method]
+	if !ok {
+		return errors.New("method not found")
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+func TestFeeHistoryProvider(t *testing.T) {
+	caller := &fakeCaller{responses: map[string]string{
+		"eth_feeHistory": `{
+			"oldestBlock": "0x10",
+			"baseFeePerGas": ["0x1", "0x2", "0x3", "0x4", "0x3"],
+			"gasUsedRatio": [0.5, 0, 0.5, 0.5],
+			"reward": [["0x7"], ["0x0"], ["0x9"], ["0x8"]]
+		}`,
+	}}
+	oracle, err := NewOracle(config.GasOracle{SlowPercentile: 20, StandardPercentile: 60, FastPercentile: 100}, caller)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tiers are base fee percentiles floored at the next base fee, with the rapid tier doubling it
+	prices, provider, err := oracle.GetGasPrices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider != FeeHistoryProviderName {
+		t.Errorf("Unexpected provider %s", provider)
+	}
+	expected := map[string]int64{"Rapid": 6, "Fast": 4, "Standard": 3, "Slow": 3}
+	for _, tier := range prices.Tiers {
+		if tier.MaxFeeWei.Cmp(big.NewInt(expected[tier.Name])) != 0 {
+			t.Errorf("Unexpected %s max fee %s", tier.Name, tier.MaxFeeWei.String())
+		}
+	}
+	if prices.Tiers[0].Name != "Rapid" || prices.GetDefaultTier().Name != "Fast" {
+		t.Errorf("Unexpected tier order %v", prices.Tiers)
+	}
+
+	// The priority fee falls back to the median reward of non-empty blocks
+	if prices.PriorityFeeWei == nil || prices.PriorityFeeWei.Int64() != 8 {
+		t.Errorf("Unexpected fallback priority fee %v", prices.PriorityFeeWei)
+	}
+	caller.responses["eth_maxPriorityFeePerGas"] = `"0x3b9aca00"`
+	prices, _, err = oracle.GetGasPrices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prices.PriorityFeeWei.Int64() != 1000000000 {
+		t.Errorf("Unexpected priority fee %s", prices.PriorityFeeWei.String())
+	}
+
+	// Errors are reported when no provider succeeds
+	delete(caller.responses, "eth_feeHistory")
+	if _, _, err := oracle.GetGasPrices(); err == nil {
+		t.Error("Expected an error without a fee history")
+	}
+}
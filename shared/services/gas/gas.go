@@ -7,8 +7,6 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
-	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
@@ -39,19 +37,30 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		}
 	}
 
-	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
+	// Get gas price suggestions from the gas oracle if no max fee was requested
+	var prices GasPrices
+	if maxFeeGwei == 0 {
+		prices, err = getGasPrices(rp)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get the priority fee - prioritize the CLI arguments, default to the config file setting, then the gas oracle's suggestion
 	if maxPriorityFeeGwei == 0 {
 		maxPriorityFee, err := cfg.GetMaxPriorityFee()
 		if err != nil {
-			fmt.Printf("%sWARNING: Couldn't get max priority fee - %w\n", colorYellow, err.Error())
+			fmt.Printf("%sWARNING: Couldn't get max priority fee - %s\n", colorYellow, err.Error())
 			fmt.Printf("Defaulting to a max priority fee of 2 gwei\n%s", colorReset)
 			maxPriorityFeeGwei = 2
-		}
-		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
+		} else if maxPriorityFee != nil && maxPriorityFee.Uint64() != 0 {
+			maxPriorityFeeGwei = eth.WeiToGwei(maxPriorityFee)
+		} else if prices.PriorityFeeWei != nil && prices.PriorityFeeWei.Uint64() != 0 {
+			maxPriorityFeeGwei = eth.WeiToGwei(prices.PriorityFeeWei)
+			fmt.Printf("%sNOTE: max priority fee not set, using the suggested priority fee of %.2f gwei%s\n", colorYellow, maxPriorityFeeGwei, colorReset)
+		} else {
 			fmt.Printf("%sNOTE: max priority fee not set or set to 0, defaulting to 2 gwei%s\n", colorYellow, colorReset)
 			maxPriorityFeeGwei = 2
-		} else {
-			maxPriorityFeeGwei = eth.WeiToGwei(maxPriorityFee)
 		}
 	}
 
@@ -80,29 +89,10 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 	} else {
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
-			if err != nil {
-				return err
-			}
-			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
+			maxFeeGwei = eth.WeiToGwei(prices.Tiers[0].MaxFeeWei) + maxPriorityFeeGwei
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
-			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, gasInfo, maxPriorityFeeGwei, gasLimit)
-
-			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-				etherscanData, err := etherscan.GetGasPrices()
-				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, gasInfo, maxPriorityFeeGwei, gasLimit)
-				} else {
-					return fmt.Errorf("Error getting gas price suggestions: %w", err)
-				}
-			}
+			// Print the suggested gas prices and ask for an amount
+			maxFeeGwei = handleGasPrices(prices, gasInfo, maxPriorityFeeGwei, gasLimit)
 		}
 		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", colorBlue, maxFeeGwei, maxPriorityFeeGwei, colorReset)
 	}
@@ -120,181 +110,74 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 }
 
-// Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
-	etherchainData, err := etherchain.GetGasPrices()
-	if err == nil {
-		return etherchainData.RapidWei, nil
-	} else {
-		fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-		etherscanData, err := etherscan.GetGasPrices()
-		if err == nil {
-			return eth.GweiToWei(etherscanData.FastGwei), nil
-		} else {
-			return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
-		}
+// Get the suggested max fee for service operations, using the fastest tier plus the max priority fee
+func GetHeadlessMaxFeeWei(oracle *Oracle, maxPriorityFee *big.Int) (*big.Int, error) {
+	prices, _, err := oracle.GetGasPrices()
+	if err != nil {
+		return nil, err
 	}
-}
-
-func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
-
-	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
-	rapidEth := eth.WeiToEth(gasSuggestion.RapidWei)
-
-	var rapidLowLimit float64
-	var rapidHighLimit float64
-	if gasLimit == 0 {
-		rapidLowLimit = rapidEth * float64(gasInfo.EstGasLimit)
-		rapidHighLimit = rapidEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		rapidLowLimit = rapidEth * float64(gasLimit)
-		rapidHighLimit = rapidLowLimit
+	maxFee := new(big.Int).Set(prices.Tiers[0].MaxFeeWei)
+	if maxPriorityFee != nil {
+		maxFee.Add(maxFee, maxPriorityFee)
 	}
+	return maxFee, nil
+}
 
-	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.FastWei)+priorityFee, 0)
-	fastEth := eth.WeiToEth(gasSuggestion.FastWei)
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
+// Get gas price suggestions from the node's gas oracle
+func getGasPrices(rp *rpsvc.Client) (GasPrices, error) {
+	response, err := rp.GasPrices()
+	if err != nil {
+		return GasPrices{}, err
 	}
-
-	standardGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.StandardWei)+priorityFee, 0)
-	standardEth := eth.WeiToEth(gasSuggestion.StandardWei)
-
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
+	if len(response.Tiers) == 0 {
+		return GasPrices{}, fmt.Errorf("The gas oracle did not return any gas price suggestions")
 	}
-
-	slowGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.SlowWei)+priorityFee, 0)
-	slowEth := eth.WeiToEth(gasSuggestion.SlowWei)
-
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
+	prices := GasPrices{PriorityFeeWei: response.PriorityFeeWei}
+	for _, tier := range response.Tiers {
+		prices.Tiers = append(prices.Tiers, GasPriceTier{Name: tier.Name, MaxFeeWei: tier.MaxFeeWei})
 	}
-
-	fmt.Printf("%s+============== Suggested Gas Prices ==============+\n", colorBlue)
-	fmt.Println("| Avg Wait Time |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.RapidTime, fmt.Sprintf("%d gwei", int(rapidGwei)), rapidLowLimit, rapidHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.FastTime, fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.StandardTime, fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
-	fmt.Printf("| %-13s | %-9s | %.4f to %.4f ETH |\n",
-		gasSuggestion.SlowTime, fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
-	fmt.Printf("+==================================================+\n\n%s", colorReset)
-
-	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
-
-	for {
-		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
-			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
-			"Not a valid gas price, try again:")
-
-		if desiredPrice == "" {
-			return fastGwei
-		}
-
-		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
-		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
-			continue
-		}
-		if desiredPriceFloat <= 0 {
-			fmt.Println("Max fee must be greater than zero.")
-			continue
-		}
-
-		return desiredPriceFloat
-	}
-
+	return prices, nil
 }
 
-func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
-
-	fastGwei := math.RoundUp(gasSuggestion.FastGwei+priorityFee, 0)
-	fastEth := gasSuggestion.FastGwei / eth.WeiPerGwei
-
-	var fastLowLimit float64
-	var fastHighLimit float64
-	if gasLimit == 0 {
-		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
-		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		fastLowLimit = fastEth * float64(gasLimit)
-		fastHighLimit = fastLowLimit
-	}
+func handleGasPrices(prices GasPrices, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
-	standardGwei := math.RoundUp(gasSuggestion.StandardGwei+priorityFee, 0)
-	standardEth := gasSuggestion.StandardGwei / eth.WeiPerGwei
-
-	var standardLowLimit float64
-	var standardHighLimit float64
-	if gasLimit == 0 {
-		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
-		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		standardLowLimit = standardEth * float64(gasLimit)
-		standardHighLimit = standardLowLimit
-	}
+	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
+	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
+	for _, tier := range prices.Tiers {
+		tierGwei := math.RoundUp(eth.WeiToGwei(tier.MaxFeeWei)+priorityFee, 0)
+		tierEth := tierGwei / eth.WeiPerGwei
 
-	slowGwei := math.RoundUp(gasSuggestion.SlowGwei+priorityFee, 0)
-	slowEth := gasSuggestion.SlowGwei / eth.WeiPerGwei
+		var lowLimit float64
+		var highLimit float64
+		if gasLimit == 0 {
+			lowLimit = tierEth * float64(gasInfo.EstGasLimit)
+			highLimit = tierEth * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowLimit = tierEth * float64(gasLimit)
+			highLimit = lowLimit
+		}
 
-	var slowLowLimit float64
-	var slowHighLimit float64
-	if gasLimit == 0 {
-		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
-		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
-	} else {
-		slowLowLimit = slowEth * float64(gasLimit)
-		slowHighLimit = slowLowLimit
+		fmt.Printf("| %-9s | %-9s | %.4f to %.4f ETH |\n",
+			tier.Name, fmt.Sprintf("%d gwei", int(tierGwei)), lowLimit, highLimit)
 	}
-
-	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
-	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
-	fmt.Printf("| Fast      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
-	fmt.Printf("| Standard  | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
-	fmt.Printf("| Slow      | %-9s | %.4f to %.4f ETH |\n",
-		fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
 	fmt.Printf("+==============================================+\n\n%s", colorReset)
 
 	fmt.Printf("These prices include a maximum priority fee of %.2f gwei.\n", priorityFee)
 
+	defaultGwei := math.RoundUp(eth.WeiToGwei(prices.GetDefaultTier().MaxFeeWei)+priorityFee, 0)
 	for {
 		desiredPrice := cliutils.Prompt(
-			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(defaultGwei)),
 			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
 			"Not a valid gas price, try again:")
 
 		if desiredPrice == "" {
-			return fastGwei
+			return defaultGwei
 		}
 
 		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
 		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
 			continue
 		}
 		if desiredPriceFloat <= 0 {
@@ -0,0 +1,100 @@
+package gas
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Config
+const (
+	FeeHistoryProviderName = "feeHistory"
+	EtherchainProviderName = "etherchain"
+	EtherscanProviderName  = "etherscan"
+)
+
+// Suggested max fee for a transaction speed, excluding the priority fee
+type GasPriceTier struct {
+	Name      string
+	MaxFeeWei *big.Int
+}
+
+// Gas price suggestions, with tiers ordered from fastest to slowest
+type GasPrices struct {
+	Tiers []GasPriceTier
+
+	// Suggested priority fee, or nil if the provider doesn't suggest one
+	PriorityFeeWei *big.Int
+}
+
+// Gas price provider
+type Provider interface {
+	GetName() string
+	GetGasPrices() (GasPrices, error)
+}
+
+// Gas oracle which tries its providers in order until one succeeds
+type Oracle struct {
+	providers []Provider
+}
+
+// Create new gas oracle from the configured providers
+// The fee history provider is used if no providers are configured, querying each execution client in order
+func NewOracle(oracleCfg config.GasOracle, callers ...RpcCaller) (*Oracle, error) {
+	names := oracleCfg.Providers
+	if len(names) == 0 {
+		names = []string{FeeHistoryProviderName}
+	}
+	providers := []Provider{}
+	for _, name := range names {
+		switch name {
+		case FeeHistoryProviderName:
+			if len(callers) == 0 {
+				return nil, errors.New("The fee history gas price provider requires an execution client")
+			}
+			for _, caller := range callers {
+				provider, err := NewFeeHistoryProvider(caller, oracleCfg)
+				if err != nil {
+					return nil, err
+				}
+				providers = append(providers, provider)
+			}
+		case EtherchainProviderName:
+			providers = append(providers, &EtherchainProvider{})
+		case EtherscanProviderName:
+			providers = append(providers, &EtherscanProvider{})
+		default:
+			return nil, fmt.Errorf("Unknown gas price provider '%s'", name)
+		}
+	}
+	return &Oracle{providers: providers}, nil
+}
+
+// Get gas price suggestions from the first provider which returns them
+func (o *Oracle) GetGasPrices() (GasPrices, string, error) {
+	failures := []string{}
+	for _, provider := range o.providers {
+		prices, err := provider.GetGasPrices()
+		if err == nil && len(prices.Tiers) > 0 {
+			return prices, provider.GetName(), nil
+		}
+		if err == nil {
+			err = errors.New("no gas price tiers returned")
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", provider.GetName(), err.Error()))
+	}
+	return GasPrices{}, "", fmt.Errorf("Error getting gas price suggestions (%s)", strings.Join(failures, "; "))
+}
+
+// Get the tier used by default, which is Fast if available
+func (p GasPrices) GetDefaultTier() GasPriceTier {
+	for _, tier := range p.Tiers {
+		if tier.Name == "Fast" {
+			return tier
+		}
+	}
+	return p.Tiers[0]
+}
@@ -0,0 +1,55 @@
+package gas
+
+import (
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
+	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+)
+
+// Gas price provider using the Etherchain web API; only meaningful for Ethereum mainnet
+type EtherchainProvider struct{}
+
+// Get the provider name
+func (p *EtherchainProvider) GetName() string {
+	return EtherchainProviderName
+}
+
+// Get gas prices
+func (p *EtherchainProvider) GetGasPrices() (GasPrices, error) {
+	suggestion, err := etherchain.GetGasPrices()
+	if err != nil {
+		return GasPrices{}, err
+	}
+	return GasPrices{
+		Tiers: []GasPriceTier{
+			{Name: "Rapid", MaxFeeWei: suggestion.RapidWei},
+			{Name: "Fast", MaxFeeWei: suggestion.FastWei},
+			{Name: "Standard", MaxFeeWei: suggestion.StandardWei},
+			{Name: "Slow", MaxFeeWei: suggestion.SlowWei},
+		},
+	}, nil
+}
+
+// Gas price provider using the Etherscan web API; only meaningful for Ethereum mainnet
+type EtherscanProvider struct{}
+
+// Get the provider name
+func (p *EtherscanProvider) GetName() string {
+	return EtherscanProviderName
+}
+
+// Get gas prices
+func (p *EtherscanProvider) GetGasPrices() (GasPrices, error) {
+	suggestion, err := etherscan.GetGasPrices()
+	if err != nil {
+		return GasPrices{}, err
+	}
+	return GasPrices{
+		Tiers: []GasPriceTier{
+			{Name: "Fast", MaxFeeWei: eth.GweiToWei(suggestion.FastGwei)},
+			{Name: "Standard", MaxFeeWei: eth.GweiToWei(suggestion.StandardGwei)},
+			{Name: "Slow", MaxFeeWei: eth.GweiToWei(suggestion.SlowGwei)},
+		},
+	}, nil
+}
@@ -0,0 +1,135 @@
+package history
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	dbutils "github.com/rocket-pool/smartnode/shared/utils/db"
+)
+
+// Database keys
+var (
+	balancePrefix = []byte("balance-")
+	lastEpochKey  = []byte("last-epoch")
+)
+
+// A validator balance sampled at the start of an epoch
+type BalanceSample struct {
+	Epoch   uint64
+	Balance uint64
+}
+
+// Validator balance history, stored in a LevelDB database
+// The database is only held open while it's used, so the node daemon and API can share it
+type Store struct {
+	path string
+}
+
+// Create new balance history store
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Record validator balances in gwei at an epoch
+func (s *Store) AddSamples(epoch uint64, balances map[uint64]uint64) error {
+
+	// Open database
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Write samples and the last epoch together
+	batch := new(leveldb.Batch)
+	for index, balance := range balances {
+		batch.Put(getBalanceKey(index, epoch), encodeUint64(balance))
+	}
+	batch.Put(lastEpochKey, encodeUint64(epoch))
+	if err := db.Write(batch, nil); err != nil {
+		return fmt.Errorf("Could not write balance history: %w", err)
+	}
+	return nil
+
+}
+
+// Get the last epoch balances were recorded at
+// Returns false if no balances have been recorded
+func (s *Store) GetLastEpoch() (uint64, bool, error) {
+
+	// Open database
+	db, err := s.open(true)
+	if err != nil || db == nil {
+		return 0, false, err
+	}
+	defer db.Close()
+
+	// Get the last epoch
+	value, err := db.Get(lastEpochKey, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("Could not read balance history: %w", err)
+	}
+	return binary.BigEndian.Uint64(value), true, nil
+
+}
+
+// Get a validator's balance samples in epoch order
+func (s *Store) GetSamples(index uint64) ([]BalanceSample, error) {
+
+	// Open database
+	db, err := s.open(true)
+	if err != nil || db == nil {
+		return []BalanceSample{}, err
+	}
+	defer db.Close()
+
+	// Iterate over the validator's samples
+	samples := []BalanceSample{}
+	iter := db.NewIterator(util.BytesPrefix(getBalanceKey(index, 0)[:len(balancePrefix)+8]), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		samples = append(samples, BalanceSample{
+			Epoch:   binary.BigEndian.Uint64(key[len(key)-8:]),
+			Balance: binary.BigEndian.Uint64(iter.Value()),
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("Could not read balance history: %w", err)
+	}
+	return samples, nil
+
+}
+
+// Open the database, retrying while it's locked by another process
+// Returns nil when opening read-only if the database doesn't exist yet
+func (s *Store) open(readOnly bool) (*leveldb.DB, error) {
+	db, err := dbutils.OpenLevelDB(s.path, readOnly)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open balance history at %s: %w", s.path, err)
+	}
+	return db, nil
+}
+
+// Get the key for a validator's balance at an epoch
+// Keys sort by validator, then epoch
+func getBalanceKey(index uint64, epoch uint64) []byte {
+	key := make([]byte, len(balancePrefix)+16)
+	copy(key, balancePrefix)
+	binary.BigEndian.PutUint64(key[len(balancePrefix):], index)
+	binary.BigEndian.PutUint64(key[len(balancePrefix)+8:], epoch)
+	return key
+}
+
+// Encode a value
+func encodeUint64(value uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, value)
+	return bytes
+}
@@ -0,0 +1,40 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreSamples(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := NewStore(filepath.Join(dir, "balance-history"))
+
+	// Reading before anything is recorded doesn't create the database
+	if _, found, err := store.GetLastEpoch(); err != nil || found {
+		t.Fatalf("expected no last epoch, got %v, %v", found, err)
+	}
+
+	if err := store.AddSamples(2, map[uint64]uint64{1: 32000000000, 256: 32000000100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddSamples(1, map[uint64]uint64{1: 31999999000}); err != nil {
+		t.Fatal(err)
+	}
+
+	samples, err := store.GetSamples(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 2 || samples[0] != (BalanceSample{Epoch: 1, Balance: 31999999000}) || samples[1] != (BalanceSample{Epoch: 2, Balance: 32000000000}) {
+		t.Errorf("unexpected samples for validator 1: %+v", samples)
+	}
+	if samples, err := store.GetSamples(256); err != nil || len(samples) != 1 {
+		t.Errorf("expected 1 sample for validator 256, got %+v, %v", samples, err)
+	}
+}
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"time"
+)
+
+// Yield reporting periods
+type Period string
+
+const (
+	Daily  Period = "daily"
+	Weekly Period = "weekly"
+)
+
+// A validator's balance change over a reporting period
+// Balances are in gwei; the start balance is the end balance of the previous period if there was one
+type PeriodYield struct {
+	Start        time.Time
+	StartEpoch   uint64
+	EndEpoch     uint64
+	StartBalance uint64
+	EndBalance   uint64
+}
+
+// Get the balance earned over the period in gwei
+// This is negative if the validator was penalised more than it earned
+func (p PeriodYield) Earned() int64 {
+	return int64(p.EndBalance) - int64(p.StartBalance)
+}
+
+// Group a validator's balance samples into reporting periods
+// Periods are aligned to UTC days, or UTC weeks starting on Monday
+func GetPeriodYields(samples []BalanceSample, genesisTime time.Time, secondsPerEpoch uint64, period Period) []PeriodYield {
+	yields := []PeriodYield{}
+	for _, sample := range samples {
+		sampleTime := genesisTime.Add(time.Duration(sample.Epoch*secondsPerEpoch) * time.Second)
+		start := getPeriodStart(sampleTime, period)
+
+		// Extend the current period
+		if len(yields) > 0 && yields[len(yields)-1].Start.Equal(start) {
+			current := &yields[len(yields)-1]
+			current.EndEpoch = sample.Epoch
+			current.EndBalance = sample.Balance
+			continue
+		}
+
+		// Start a new period from the end of the previous one
+		yield := PeriodYield{
+			Start:        start,
+			StartEpoch:   sample.Epoch,
+			EndEpoch:     sample.Epoch,
+			StartBalance: sample.Balance,
+			EndBalance:   sample.Balance,
+		}
+		if len(yields) > 0 {
+			previous := yields[len(yields)-1]
+			yield.StartEpoch = previous.EndEpoch
+			yield.StartBalance = previous.EndBalance
+		}
+		yields = append(yields, yield)
+	}
+	return yields
+}
+
+// Get the start of the period containing a time
+func getPeriodStart(t time.Time, period Period) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if period == Weekly {
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday)
+	}
+	return day
+}
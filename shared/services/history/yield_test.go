@@ -0,0 +1,44 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetPeriodYields(t *testing.T) {
+
+	// Genesis on a Sunday, with a day every 10 epochs
+	genesisTime := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)
+	secondsPerEpoch := uint64(24 * 60 * 60 / 10)
+	samples := []BalanceSample{
+		{Epoch: 0, Balance: 100},
+		{Epoch: 5, Balance: 110},
+		{Epoch: 10, Balance: 120},
+		{Epoch: 25, Balance: 115},
+	}
+
+	daily := GetPeriodYields(samples, genesisTime, secondsPerEpoch, Daily)
+	expected := []PeriodYield{
+		{Start: genesisTime, StartEpoch: 0, EndEpoch: 5, StartBalance: 100, EndBalance: 110},
+		{Start: genesisTime.AddDate(0, 0, 1), StartEpoch: 5, EndEpoch: 10, StartBalance: 110, EndBalance: 120},
+		{Start: genesisTime.AddDate(0, 0, 2), StartEpoch: 10, EndEpoch: 25, StartBalance: 120, EndBalance: 115},
+	}
+	if len(daily) != len(expected) {
+		t.Fatalf("expected %d daily periods, got %+v", len(expected), daily)
+	}
+	for pi, period := range expected {
+		if daily[pi] != period {
+			t.Errorf("expected daily period %+v, got %+v", period, daily[pi])
+		}
+	}
+	if daily[2].Earned() != -5 {
+		t.Errorf("expected a loss of 5, got %d", daily[2].Earned())
+	}
+
+	// Weeks start on Monday, so the Sunday genesis is in the previous week
+	weekly := GetPeriodYields(samples, genesisTime, secondsPerEpoch, Weekly)
+	if len(weekly) != 2 || weekly[1].Start != genesisTime.AddDate(0, 0, 1) || weekly[1].Earned() != 5 {
+		t.Errorf("unexpected weekly periods %+v", weekly)
+	}
+
+}
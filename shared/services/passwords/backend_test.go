@@ -0,0 +1,109 @@
+package passwords
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "passwords")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pm := NewPasswordManager(filepath.Join(dir, "password"))
+	if pm.IsPasswordSet() {
+		t.Fatal("Expected password to be unset")
+	}
+	if err := pm.SetPassword("short"); err == nil {
+		t.Error("Expected short password to be rejected")
+	}
+	if err := pm.SetPassword("correct horse battery"); err != nil {
+		t.Fatal(err)
+	}
+	if password, err := pm.GetPassword(); err != nil || password != "correct horse battery" {
+		t.Errorf("Incorrect password %q: %v", password, err)
+	}
+	if err := pm.SetPassword("another password"); err == nil {
+		t.Error("Expected password to be already set")
+	}
+	if err := pm.DeletePassword(); err != nil {
+		t.Fatal(err)
+	}
+	if pm.IsPasswordSet() {
+		t.Error("Expected password to be deleted")
+	}
+}
+
+func TestEnvBackend(t *testing.T) {
+	os.Setenv("RP_TEST_WALLET_PASSWORD", "correct horse battery")
+	defer os.Unsetenv("RP_TEST_WALLET_PASSWORD")
+
+	pm := NewPasswordManagerWithBackend(NewEnvBackend("RP_TEST_WALLET_PASSWORD"))
+	if password, err := pm.GetPassword(); err != nil || password != "correct horse battery" {
+		t.Errorf("Incorrect password %q: %v", password, err)
+	}
+	if err := pm.DeletePassword(); err != ErrExternallyManaged {
+		t.Errorf("Expected externally managed error, got %v", err)
+	}
+}
+
+func TestHttpBackend(t *testing.T) {
+	var stored string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if stored == "" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"data":{"data":{"password":"` + stored + `"},"metadata":{}}}`))
+		case http.MethodPost:
+			var request struct {
+				Data map[string]string `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			stored = request.Data["password"]
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			stored = ""
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	pm := NewPasswordManagerWithBackend(NewHttpBackend(server.URL, "token", ""))
+	if pm.IsPasswordSet() {
+		t.Fatal("Expected password to be unset")
+	}
+	if err := pm.SetPassword("correct horse battery"); err != nil {
+		t.Fatal(err)
+	}
+	if password, err := pm.GetPassword(); err != nil || password != "correct horse battery" {
+		t.Errorf("Incorrect password %q: %v", password, err)
+	}
+	if err := pm.DeletePassword(); err != nil {
+		t.Fatal(err)
+	}
+	if pm.IsPasswordSet() {
+		t.Error("Expected password to be deleted")
+	}
+
+	denied := NewHttpBackend(server.URL, "wrong", "")
+	if _, err := denied.GetPassword(); err == nil {
+		t.Error("Expected request with the wrong token to fail")
+	}
+}
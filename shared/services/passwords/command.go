@@ -0,0 +1,49 @@
+package passwords
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Reads the password from the output of an external command, such as a secret manager CLI
+type CommandBackend struct {
+	command string
+}
+
+// Create new command password backend
+func NewCommandBackend(command string) *CommandBackend {
+	return &CommandBackend{
+		command: command,
+	}
+}
+
+// Check if the password has been set
+func (b *CommandBackend) IsPasswordSet() bool {
+	_, err := b.GetPassword()
+	return (err == nil)
+}
+
+// Get the password
+func (b *CommandBackend) GetPassword() (string, error) {
+	output, err := exec.Command("sh", "-c", b.command).Output()
+	if err != nil {
+		return "", fmt.Errorf("Could not run password command: %w", err)
+	}
+	password := strings.TrimRight(string(output), "\r\n")
+	if password == "" {
+		return "", errors.New("The password command did not return a password")
+	}
+	return password, nil
+}
+
+// Set the password
+func (b *CommandBackend) SetPassword(password string) error {
+	return ErrExternallyManaged
+}
+
+// Delete the password
+func (b *CommandBackend) DeletePassword() error {
+	return ErrExternallyManaged
+}
@@ -0,0 +1,42 @@
+package passwords
+
+import (
+	"fmt"
+	"os"
+)
+
+// Reads the password from an environment variable
+type EnvBackend struct {
+	name string
+}
+
+// Create new environment variable password backend
+func NewEnvBackend(name string) *EnvBackend {
+	return &EnvBackend{
+		name: name,
+	}
+}
+
+// Check if the password has been set
+func (b *EnvBackend) IsPasswordSet() bool {
+	return os.Getenv(b.name) != ""
+}
+
+// Get the password
+func (b *EnvBackend) GetPassword() (string, error) {
+	password := os.Getenv(b.name)
+	if password == "" {
+		return "", fmt.Errorf("The password environment variable %s is not set", b.name)
+	}
+	return password, nil
+}
+
+// Set the password
+func (b *EnvBackend) SetPassword(password string) error {
+	return ErrExternallyManaged
+}
+
+// Delete the password
+func (b *EnvBackend) DeletePassword() error {
+	return ErrExternallyManaged
+}
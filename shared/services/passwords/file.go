@@ -0,0 +1,57 @@
+package passwords
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Stores the password in a plaintext file
+type FileBackend struct {
+	passwordPath string
+}
+
+// Create new file password backend
+func NewFileBackend(passwordPath string) *FileBackend {
+	return &FileBackend{
+		passwordPath: passwordPath,
+	}
+}
+
+// Check if the password has been set
+func (b *FileBackend) IsPasswordSet() bool {
+	_, err := ioutil.ReadFile(b.passwordPath)
+	return (err == nil)
+}
+
+// Get the password
+func (b *FileBackend) GetPassword() (string, error) {
+
+	// Read from disk
+	password, err := ioutil.ReadFile(b.passwordPath)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+
+	// Return
+	return string(password), nil
+
+}
+
+// Set the password
+func (b *FileBackend) SetPassword(password string) error {
+	if err := files.WriteFile(b.passwordPath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+	return nil
+}
+
+// Delete the password
+func (b *FileBackend) DeletePassword() error {
+	if err := os.Remove(b.passwordPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete password from disk: %w", err)
+	}
+	return nil
+}
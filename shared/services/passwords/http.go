@@ -0,0 +1,132 @@
+package passwords
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Config
+const HttpTimeout = 10 * time.Second
+
+// Reads the password from an HTTP secret store using the Vault KV API
+type HttpBackend struct {
+	url    string
+	token  string
+	field  string
+	client *http.Client
+}
+
+// Create new HTTP password backend
+func NewHttpBackend(url, token, field string) *HttpBackend {
+	if field == "" {
+		field = "password"
+	}
+	return &HttpBackend{
+		url:    url,
+		token:  token,
+		field:  field,
+		client: &http.Client{Timeout: HttpTimeout},
+	}
+}
+
+// Check if the password has been set
+func (b *HttpBackend) IsPasswordSet() bool {
+	_, err := b.GetPassword()
+	return (err == nil)
+}
+
+// Get the password
+func (b *HttpBackend) GetPassword() (string, error) {
+
+	// Request secret
+	body, status, err := b.request(http.MethodGet, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("Could not get password from secret store: HTTP status %d", status)
+	}
+
+	// Decode response; KV version 2 nests the secret under data.data
+	var response struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("Could not decode secret store response: %w", err)
+	}
+	data := response.Data
+	if nested, ok := data["data"]; ok {
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return "", fmt.Errorf("Could not decode secret store response: %w", err)
+		}
+	}
+	var password string
+	if raw, ok := data[b.field]; ok {
+		if err := json.Unmarshal(raw, &password); err != nil {
+			return "", fmt.Errorf("Could not decode secret store password field: %w", err)
+		}
+	}
+	if password == "" {
+		return "", fmt.Errorf("The secret store response does not contain the '%s' field", b.field)
+	}
+	return password, nil
+
+}
+
+// Set the password
+func (b *HttpBackend) SetPassword(password string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{b.field: password},
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode secret store request: %w", err)
+	}
+	_, status, err := b.request(http.MethodPost, payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return fmt.Errorf("Could not store password in secret store: HTTP status %d", status)
+	}
+	return nil
+}
+
+// Delete the password
+func (b *HttpBackend) DeletePassword() error {
+	_, status, err := b.request(http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return fmt.Errorf("Could not delete password from secret store: HTTP status %d", status)
+	}
+	return nil
+}
+
+// Make a request to the secret store
+func (b *HttpBackend) request(method string, payload []byte) ([]byte, int, error) {
+	request, err := http.NewRequest(method, b.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not create secret store request: %w", err)
+	}
+	if b.token != "" {
+		request.Header.Set("X-Vault-Token", b.token)
+	}
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := b.client.Do(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not reach secret store: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not read secret store response: %w", err)
+	}
+	return body, response.StatusCode, nil
+}
@@ -3,7 +3,6 @@ package passwords
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 )
 
 // Config
@@ -12,36 +11,42 @@ const (
 	FileMode          = 0600
 )
 
+// Password storage backend
+type Backend interface {
+	IsPasswordSet() bool
+	GetPassword() (string, error)
+	SetPassword(password string) error
+	DeletePassword() error
+}
+
+// Returned by backends whose password is managed outside of the node
+var ErrExternallyManaged = errors.New("The password is managed externally and can't be changed by the node")
+
 // Password manager
 type PasswordManager struct {
-	passwordPath string
+	backend Backend
 }
 
-// Create new password manager
+// Create new password manager which stores the password in a file
 func NewPasswordManager(passwordPath string) *PasswordManager {
+	return NewPasswordManagerWithBackend(NewFileBackend(passwordPath))
+}
+
+// Create new password manager with a password backend
+func NewPasswordManagerWithBackend(backend Backend) *PasswordManager {
 	return &PasswordManager{
-		passwordPath: passwordPath,
+		backend: backend,
 	}
 }
 
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
-	_, err := ioutil.ReadFile(pm.passwordPath)
-	return (err == nil)
+	return pm.backend.IsPasswordSet()
 }
 
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
-
-	// Read from disk
-	password, err := ioutil.ReadFile(pm.passwordPath)
-	if err != nil {
-		return "", fmt.Errorf("Could not read password from disk: %w", err)
-	}
-
-	// Return
-	return string(password), nil
-
+	return pm.backend.GetPassword()
 }
 
 // Set the password
@@ -57,12 +62,30 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
-	// Write to disk
-	if err := ioutil.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
-		return fmt.Errorf("Could not write password to disk: %w", err)
+	// Store password
+	return pm.backend.SetPassword(password)
+
+}
+
+// Replace an existing password
+func (pm *PasswordManager) ChangePassword(password string) error {
+
+	// Check password is set
+	if !pm.IsPasswordSet() {
+		return errors.New("Password is not set")
+	}
+
+	// Check password length
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
-	// Return
-	return nil
+	// Store password
+	return pm.backend.SetPassword(password)
+
+}
 
+// Delete the password
+func (pm *PasswordManager) DeletePassword() error {
+	return pm.backend.DeletePassword()
 }
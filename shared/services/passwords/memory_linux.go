@@ -0,0 +1,23 @@
+//go:build linux
+// +build linux
+
+package passwords
+
+import (
+	"syscall"
+)
+
+// Filesystem magic numbers
+const (
+	tmpfsMagic = 0x01021994
+	ramfsMagic = 0x858458f6
+)
+
+// Check whether a directory is on a memory-backed filesystem
+func isMemoryBacked(dir string) (bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return false, err
+	}
+	return stat.Type == tmpfsMagic || stat.Type == ramfsMagic, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package passwords
+
+// Memory-backed filesystems can only be detected on linux
+func isMemoryBacked(dir string) (bool, error) {
+	return false, nil
+}
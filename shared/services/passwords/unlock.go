@@ -0,0 +1,51 @@
+package passwords
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Keeps the password in a memory-backed file which is written when the wallet is unlocked
+// The daemon starts locked after a reboot, so the wallet can't be decrypted from disk alone
+type UnlockBackend struct {
+	file *FileBackend
+	path string
+}
+
+// Create new unlock password backend
+func NewUnlockBackend(unlockPath string) *UnlockBackend {
+	return &UnlockBackend{
+		file: NewFileBackend(unlockPath),
+		path: unlockPath,
+	}
+}
+
+// Check if the wallet has been unlocked
+func (b *UnlockBackend) IsPasswordSet() bool {
+	return b.file.IsPasswordSet()
+}
+
+// Get the password
+func (b *UnlockBackend) GetPassword() (string, error) {
+	if !b.file.IsPasswordSet() {
+		return "", fmt.Errorf("The wallet is locked; please run 'rocketpool wallet unlock' and try again")
+	}
+	return b.file.GetPassword()
+}
+
+// Unlock the wallet with a password
+func (b *UnlockBackend) SetPassword(password string) error {
+	memoryBacked, err := isMemoryBacked(filepath.Dir(b.path))
+	if err != nil {
+		return fmt.Errorf("Could not check the unlock path filesystem: %w", err)
+	}
+	if !memoryBacked {
+		return fmt.Errorf("The unlock path %s is not on a memory-backed filesystem", b.path)
+	}
+	return b.file.SetPassword(password)
+}
+
+// Lock the wallet
+func (b *UnlockBackend) DeletePassword() error {
+	return b.file.DeletePassword()
+}
@@ -0,0 +1,103 @@
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// Config
+const FileMode = 0644
+
+// A validator's duty performance, accumulated over the tracked epochs
+type ValidatorPerformance struct {
+	Index                  uint64 `json:"index"`
+	AttestationsExpected   uint64 `json:"attestationsExpected"`
+	AttestationsMissed     uint64 `json:"attestationsMissed"`
+	InclusionDistanceTotal uint64 `json:"inclusionDistanceTotal"`
+	ProposalsExpected      uint64 `json:"proposalsExpected"`
+	ProposalsMissed        uint64 `json:"proposalsMissed"`
+}
+
+// Get the average inclusion distance of the validator's included attestations
+func (p ValidatorPerformance) AverageInclusionDistance() float64 {
+	included := p.AttestationsExpected - p.AttestationsMissed
+	if included == 0 {
+		return 0
+	}
+	return float64(p.InclusionDistanceTotal) / float64(included)
+}
+
+// Add another period's performance to the validator's totals
+func (p *ValidatorPerformance) Add(other ValidatorPerformance) {
+	p.AttestationsExpected += other.AttestationsExpected
+	p.AttestationsMissed += other.AttestationsMissed
+	p.InclusionDistanceTotal += other.InclusionDistanceTotal
+	p.ProposalsExpected += other.ProposalsExpected
+	p.ProposalsMissed += other.ProposalsMissed
+}
+
+// Persisted validator performance, written by the node daemon and read by the API
+type Store struct {
+	FirstEpoch uint64                           `json:"firstEpoch"`
+	LastEpoch  uint64                           `json:"lastEpoch"`
+	Validators map[uint64]*ValidatorPerformance `json:"validators"`
+	path       string
+}
+
+// Load the performance store from a file
+// Returns an empty store if the file doesn't exist yet
+func Load(path string) (*Store, error) {
+	store := &Store{
+		Validators: make(map[uint64]*ValidatorPerformance),
+		path:       path,
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read validator performance file at %s: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, store); err != nil {
+		return nil, fmt.Errorf("Could not parse validator performance file at %s: %w", path, err)
+	}
+	if store.Validators == nil {
+		store.Validators = make(map[uint64]*ValidatorPerformance)
+	}
+	return store, nil
+}
+
+// Check whether any epochs have been recorded
+func (s *Store) IsEmpty() bool {
+	return s.LastEpoch == 0 && len(s.Validators) == 0
+}
+
+// Record an epoch's validator performance
+func (s *Store) AddEpoch(epoch uint64, epochPerformance map[uint64]ValidatorPerformance) {
+	if s.IsEmpty() {
+		s.FirstEpoch = epoch
+	}
+	for index, performance := range epochPerformance {
+		validator, ok := s.Validators[index]
+		if !ok {
+			validator = &ValidatorPerformance{Index: index}
+			s.Validators[index] = validator
+		}
+		validator.Add(performance)
+	}
+	s.LastEpoch = epoch
+}
+
+// Save the performance store to its file
+func (s *Store) Save() error {
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator performance: %w", err)
+	}
+	if err := ioutil.WriteFile(s.path, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write validator performance to %s: %w", s.path, err)
+	}
+	return nil
+}
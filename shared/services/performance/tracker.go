@@ -0,0 +1,136 @@
+package performance
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The number of epochs which must follow an epoch before its performance can be checked
+const EpochDelay = 2
+
+// A fetched beacon block, or a missed slot
+type slotBlock struct {
+	block  beacon.BeaconBlock
+	exists bool
+}
+
+// Checks validators' attestation and proposal duties against the blocks produced
+// Blocks are kept between epochs, as attestations for one epoch are included in the next
+type Tracker struct {
+	bc            beacon.Client
+	slotsPerEpoch uint64
+	blocks        map[uint64]slotBlock
+}
+
+// Create new performance tracker
+func NewTracker(bc beacon.Client, slotsPerEpoch uint64) *Tracker {
+	return &Tracker{
+		bc:            bc,
+		slotsPerEpoch: slotsPerEpoch,
+		blocks:        make(map[uint64]slotBlock),
+	}
+}
+
+// Get validators' performance at an epoch
+// Attestations not included within an epoch of their slot are counted as missed
+func (t *Tracker) GetEpochPerformance(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]ValidatorPerformance, error) {
+
+	// Forget blocks from earlier epochs
+	startSlot := epoch * t.slotsPerEpoch
+	for slot := range t.blocks {
+		if slot < startSlot {
+			delete(t.blocks, slot)
+		}
+	}
+
+	// Initialize performance
+	performance := make(map[uint64]ValidatorPerformance, len(indices))
+	for _, index := range indices {
+		performance[index] = ValidatorPerformance{Index: index}
+	}
+
+	// Check attestations
+	attesterDuties, err := t.bc.GetValidatorAttesterDuties(ctx, indices, epoch)
+	if err != nil {
+		return nil, err
+	}
+	for _, duty := range attesterDuties {
+		distance, included, err := t.getInclusionDistance(ctx, duty)
+		if err != nil {
+			return nil, err
+		}
+		validator := performance[duty.ValidatorIndex]
+		validator.AttestationsExpected++
+		if included {
+			validator.InclusionDistanceTotal += distance
+		} else {
+			validator.AttestationsMissed++
+		}
+		performance[duty.ValidatorIndex] = validator
+	}
+
+	// Check proposals
+	proposerDuties, err := t.bc.GetValidatorProposerDuties(ctx, indices, epoch)
+	if err != nil {
+		return nil, err
+	}
+	proposed := make(map[uint64]uint64)
+	for slot := startSlot; slot < startSlot+t.slotsPerEpoch; slot++ {
+		block, err := t.getBlock(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if block.exists {
+			proposed[block.block.ProposerIndex]++
+		}
+	}
+	for index, expected := range proposerDuties {
+		validator, ok := performance[index]
+		if !ok || expected == 0 {
+			continue
+		}
+		validator.ProposalsExpected += expected
+		if proposed[index] < expected {
+			validator.ProposalsMissed += expected - proposed[index]
+		}
+		performance[index] = validator
+	}
+
+	// Return
+	return performance, nil
+
+}
+
+// Find the first block including a validator's attestation and get its distance from the attestation slot
+func (t *Tracker) getInclusionDistance(ctx context.Context, duty beacon.AttesterDuty) (uint64, bool, error) {
+	for slot := duty.Slot + 1; slot <= duty.Slot+t.slotsPerEpoch; slot++ {
+		block, err := t.getBlock(ctx, slot)
+		if err != nil {
+			return 0, false, err
+		}
+		if !block.exists {
+			continue
+		}
+		for _, attestation := range block.block.Attestations {
+			if attestation.Slot == duty.Slot && attestation.CommitteeIndex == duty.CommitteeIndex && attestation.HasAttested(duty.ValidatorCommitteeIndex) {
+				return slot - duty.Slot, true, nil
+			}
+		}
+	}
+	return 0, false, nil
+}
+
+// Get the block at a slot
+func (t *Tracker) getBlock(ctx context.Context, slot uint64) (slotBlock, error) {
+	if block, ok := t.blocks[slot]; ok {
+		return block, nil
+	}
+	block, exists, err := t.bc.GetBeaconBlock(ctx, strconv.FormatUint(slot, 10))
+	if err != nil {
+		return slotBlock{}, err
+	}
+	t.blocks[slot] = slotBlock{block: block, exists: exists}
+	return t.blocks[slot], nil
+}
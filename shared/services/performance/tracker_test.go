@@ -0,0 +1,66 @@
+package performance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+type fakeClient struct {
+	beacon.Client
+	attesterDuties []beacon.AttesterDuty
+	proposerDuties map[uint64]uint64
+	blocks         map[uint64]beacon.BeaconBlock
+}
+
+func (f *fakeClient) GetValidatorAttesterDuties(ctx context.Context, indices []uint64, epoch uint64) ([]beacon.AttesterDuty, error) {
+	return f.attesterDuties, nil
+}
+func (f *fakeClient) GetValidatorProposerDuties(ctx context.Context, indices []uint64, epoch uint64) (map[uint64]uint64, error) {
+	return f.proposerDuties, nil
+}
+func (f *fakeClient) GetBeaconBlock(ctx context.Context, blockId string) (beacon.BeaconBlock, bool, error) {
+	slot, _ := strconv.ParseUint(blockId, 10, 64)
+	block, ok := f.blocks[slot]
+	return block, ok, nil
+}
+
+func TestGetEpochPerformance(t *testing.T) {
+
+	// Validator 1 attests at slot 4 with inclusion at slot 6, and proposes slot 5
+	// Validator 2's attestation at slot 2 is never included, and it misses its proposal at slot 3
+	bc := &fakeClient{
+		attesterDuties: []beacon.AttesterDuty{
+			{ValidatorIndex: 1, Slot: 4, CommitteeIndex: 1, ValidatorCommitteeIndex: 9},
+			{ValidatorIndex: 2, Slot: 2, CommitteeIndex: 0, ValidatorCommitteeIndex: 0},
+		},
+		proposerDuties: map[uint64]uint64{1: 1, 2: 1},
+		blocks: map[uint64]beacon.BeaconBlock{
+			5: {Slot: 5, ProposerIndex: 1, Attestations: []beacon.AttestationInfo{
+				{Slot: 4, CommitteeIndex: 1, AggregationBits: []byte{0xff, 0x00}},
+				{Slot: 2, CommitteeIndex: 0, AggregationBits: []byte{0xfe}},
+			}},
+			6: {Slot: 6, ProposerIndex: 3, Attestations: []beacon.AttestationInfo{
+				{Slot: 4, CommitteeIndex: 1, AggregationBits: []byte{0x00, 0x02}},
+			}},
+		},
+	}
+	tracker := NewTracker(bc, 8)
+
+	performance, err := tracker.GetEpochPerformance(context.Background(), []uint64{1, 2}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[uint64]ValidatorPerformance{
+		1: {Index: 1, AttestationsExpected: 1, InclusionDistanceTotal: 2, ProposalsExpected: 1},
+		2: {Index: 2, AttestationsExpected: 1, AttestationsMissed: 1, ProposalsExpected: 1, ProposalsMissed: 1},
+	}
+	for index, validator := range expected {
+		if performance[index] != validator {
+			t.Errorf("expected performance %+v for validator %d, got %+v", validator, index, performance[index])
+		}
+	}
+
+}
@@ -40,6 +40,16 @@ func RequireNodePassword(c *cli.Context) error {
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	// Unsigned transactions can be exported for a watch-only node address without the wallet
+	if c.GlobalBool("unsigned") {
+		cfg, err := getConfig(c)
+		if err != nil {
+			return err
+		}
+		if cfg.Smartnode.NodeAddress != "" {
+			return nil
+		}
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -54,7 +64,7 @@ func RequireNodeWallet(c *cli.Context) error {
 }
 
 func RequireEthClientSynced(c *cli.Context) error {
-	ethClientSynced, err := waitEthClientSynced(c, false, EthClientSyncTimeout)
+	ethClientSynced, err := waitEthClientSynced(context.Background(), c, false, EthClientSyncTimeout)
 	if err != nil {
 		return err
 	}
@@ -65,7 +75,7 @@ func RequireEthClientSynced(c *cli.Context) error {
 }
 
 func RequireBeaconClientSynced(c *cli.Context) error {
-	beaconClientSynced, err := waitBeaconClientSynced(c, false, BeaconClientSyncTimeout)
+	beaconClientSynced, err := waitBeaconClientSynced(context.Background(), c, false, BeaconClientSyncTimeout)
 	if err != nil {
 		return err
 	}
@@ -79,7 +89,7 @@ func RequireRocketStorage(c *cli.Context) error {
 	//if err := RequireEthClientSynced(c); err != nil {
 	//	return err
 	//}
-	rocketStorageLoaded, err := getRocketStorageLoaded(c)
+	rocketStorageLoaded, err := getRocketStorageLoaded(context.Background(), c)
 	if err != nil {
 		return err
 	}
@@ -153,9 +163,10 @@ func RequireNodeTrusted(c *cli.Context) error {
 
 //
 // Service synchronization
+// Waits return the context's error if it is cancelled before the service is ready
 //
 
-func WaitNodePassword(c *cli.Context, verbose bool) error {
+func WaitNodePassword(ctx context.Context, c *cli.Context, verbose bool) error {
 	for {
 		nodePasswordSet, err := getNodePasswordSet(c)
 		if err != nil {
@@ -167,12 +178,14 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 		if verbose {
 			log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
 		}
-		time.Sleep(checkNodePasswordInterval)
+		if err := sleepContext(ctx, checkNodePasswordInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitNodeWallet(c *cli.Context, verbose bool) error {
-	if err := WaitNodePassword(c, verbose); err != nil {
+func WaitNodeWallet(ctx context.Context, c *cli.Context, verbose bool) error {
+	if err := WaitNodePassword(ctx, c, verbose); err != nil {
 		return err
 	}
 	for {
@@ -186,26 +199,28 @@ func WaitNodeWallet(c *cli.Context, verbose bool) error {
 		if verbose {
 			log.Printf("The node wallet has not been initialized, retrying in %s...\n", checkNodeWalletInterval.String())
 		}
-		time.Sleep(checkNodeWalletInterval)
+		if err := sleepContext(ctx, checkNodeWalletInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitEthClientSynced(c *cli.Context, verbose bool) error {
-	_, err := waitEthClientSynced(c, verbose, 0)
+func WaitEthClientSynced(ctx context.Context, c *cli.Context, verbose bool) error {
+	_, err := waitEthClientSynced(ctx, c, verbose, 0)
 	return err
 }
 
-func WaitBeaconClientSynced(c *cli.Context, verbose bool) error {
-	_, err := waitBeaconClientSynced(c, verbose, 0)
+func WaitBeaconClientSynced(ctx context.Context, c *cli.Context, verbose bool) error {
+	_, err := waitBeaconClientSynced(ctx, c, verbose, 0)
 	return err
 }
 
-func WaitRocketStorage(c *cli.Context, verbose bool) error {
-	if err := WaitEthClientSynced(c, verbose); err != nil {
+func WaitRocketStorage(ctx context.Context, c *cli.Context, verbose bool) error {
+	if err := WaitEthClientSynced(ctx, c, verbose); err != nil {
 		return err
 	}
 	for {
-		rocketStorageLoaded, err := getRocketStorageLoaded(c)
+		rocketStorageLoaded, err := getRocketStorageLoaded(ctx, c)
 		if err != nil {
 			return err
 		}
@@ -215,15 +230,17 @@ func WaitRocketStorage(c *cli.Context, verbose bool) error {
 		if verbose {
 			log.Printf("The Rocket Pool storage contract was not found, retrying in %s...\n", checkRocketStorageInterval.String())
 		}
-		time.Sleep(checkRocketStorageInterval)
+		if err := sleepContext(ctx, checkRocketStorageInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitNodeRegistered(c *cli.Context, verbose bool) error {
-	if err := WaitNodeWallet(c, verbose); err != nil {
+func WaitNodeRegistered(ctx context.Context, c *cli.Context, verbose bool) error {
+	if err := WaitNodeWallet(ctx, c, verbose); err != nil {
 		return err
 	}
-	if err := WaitRocketStorage(c, verbose); err != nil {
+	if err := WaitRocketStorage(ctx, c, verbose); err != nil {
 		return err
 	}
 	for {
@@ -237,7 +254,9 @@ func WaitNodeRegistered(c *cli.Context, verbose bool) error {
 		if verbose {
 			log.Printf("The node is not registered with Rocket Pool, retrying in %s...\n", checkNodeRegisteredInterval.String())
 		}
-		time.Sleep(checkNodeRegisteredInterval)
+		if err := sleepContext(ctx, checkNodeRegisteredInterval); err != nil {
+			return err
+		}
 	}
 }
 
@@ -264,7 +283,7 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 }
 
 // Check if the RocketStorage contract is loaded
-func getRocketStorageLoaded(c *cli.Context) (bool, error) {
+func getRocketStorageLoaded(ctx context.Context, c *cli.Context) (bool, error) {
 	cfg, err := GetConfig(c)
 	if err != nil {
 		return false, err
@@ -273,7 +292,7 @@ func getRocketStorageLoaded(c *cli.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	code, err := ec.CodeAt(context.Background(), common.HexToAddress(cfg.Rocketpool.StorageAddress), nil)
+	code, err := ec.CodeAt(ctx, common.HexToAddress(cfg.Rocketpool.StorageAddress), nil)
 	if err != nil {
 		return false, err
 	}
@@ -352,7 +371,7 @@ func getNodeTrusted(c *cli.Context) (bool, error) {
 // timeout of 0 indicates no timeout
 var ethClientSyncLock sync.Mutex
 
-func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
+func waitEthClientSynced(ctx context.Context, c *cli.Context, verbose bool, timeout int64) (bool, error) {
 
 	// Prevent multiple waiting goroutines from requesting sync progress
 	ethClientSyncLock.Lock()
@@ -377,7 +396,7 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 		}
 
 		// Get sync progress
-		progress, err := ec.SyncProgress(context.Background())
+		progress, err := ec.SyncProgress(ctx)
 		if err != nil {
 			return false, err
 		}
@@ -395,7 +414,7 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 		} else {
 			// Eth 1 client is not in "syncing" state but may be behind head
 			// Get the latest block it knows about and make sure it's recent compared to system clock time
-			timestamp, err := GetEthClientLatestBlockTimestamp(c)
+			timestamp, err := GetEthClientLatestBlockTimestamp(ctx, c)
 			if err != nil {
 				return false, err
 			}
@@ -406,7 +425,9 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 		}
 
 		// Pause before next poll
-		time.Sleep(ethClientSyncPollInterval)
+		if err := sleepContext(ctx, ethClientSyncPollInterval); err != nil {
+			return false, err
+		}
 
 	}
 
@@ -416,7 +437,7 @@ func waitEthClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, err
 // timeout of 0 indicates no timeout
 var beaconClientSyncLock sync.Mutex
 
-func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool, error) {
+func waitBeaconClientSynced(ctx context.Context, c *cli.Context, verbose bool, timeout int64) (bool, error) {
 
 	// Prevent multiple waiting goroutines from requesting sync progress
 	beaconClientSyncLock.Lock()
@@ -440,7 +461,7 @@ func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool,
 		}
 
 		// Get sync status
-		syncStatus, err := bc.GetSyncStatus()
+		syncStatus, err := bc.GetSyncStatus(ctx)
 		if err != nil {
 			return false, err
 		}
@@ -455,8 +476,20 @@ func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool,
 		}
 
 		// Pause before next poll
-		time.Sleep(beaconClientSyncPollInterval)
+		if err := sleepContext(ctx, beaconClientSyncPollInterval); err != nil {
+			return false, err
+		}
 
 	}
 
 }
+
+// Pause for a duration, returning early if the context is cancelled
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(duration):
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -40,10 +41,13 @@ const (
 	FallbackComposeFile = "docker-compose-fallback.yml"
 	PrometheusTemplate  = "prometheus.tmpl"
 	PrometheusFile      = "prometheus.yml"
+	SlashingImportFile  = "slashing-protection-imported"
 
 	APIContainerSuffix = "_api"
 	APIBinPath         = "/go/bin/rocketpool"
 
+	SlashingProtectionPath = "/tmp/slashing-protection.json"
+
 	DebugColor = color.FgYellow
 )
 
@@ -55,6 +59,9 @@ type Client struct {
 	maxPrioFee         float64
 	gasLimit           uint64
 	customNonce        *big.Int
+	account            string
+	dryRun             bool
+	exportPath         string
 	client             *ssh.Client
 	originalMaxFee     float64
 	originalMaxPrioFee float64
@@ -75,11 +82,14 @@ func NewClientFromCtx(c *cli.Context) (*Client, error) {
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
+		c.GlobalString("account"),
+		c.GlobalBool("dry-run"),
+		c.GlobalString("export-unsigned"),
 		c.GlobalBool("debug"))
 }
 
 // Create new Rocket Pool client
-func NewClient(configPath string, daemonPath string, hostAddress string, user string, keyPath string, passphrasePath string, knownhostsFile string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, debug bool) (*Client, error) {
+func NewClient(configPath string, daemonPath string, hostAddress string, user string, keyPath string, passphrasePath string, knownhostsFile string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, account string, dryRun bool, exportPath string, debug bool) (*Client, error) {
 
 	// Initialize SSH client if configured for SSH
 	var sshClient *ssh.Client
@@ -166,6 +176,9 @@ func NewClient(configPath string, daemonPath string, hostAddress string, user st
 		originalMaxPrioFee: maxPrioFee,
 		originalGasLimit:   gasLimit,
 		customNonce:        customNonceBigInt,
+		account:            account,
+		dryRun:             dryRun,
+		exportPath:         exportPath,
 		client:             sshClient,
 		debugPrint:         debug,
 	}, nil
@@ -452,6 +465,23 @@ func (c *Client) PrintServiceStats(composeFiles []string) error {
 // Get the Rocket Pool service version
 func (c *Client) GetServiceVersion() (string, error) {
 
+	// Get the version from the API server if configured
+	connection, err := c.getAPIServerConnection()
+	if err != nil {
+		return "", err
+	}
+	if connection != nil {
+		versionString, err := c.getAPIServerVersion(connection)
+		if err != nil {
+			return "", fmt.Errorf("Could not get Rocket Pool service version: %w", err)
+		}
+		version, err := semver.Make(versionString)
+		if err != nil {
+			return "", fmt.Errorf("Could not parse Rocket Pool service version number '%s': %w", versionString, err)
+		}
+		return version.String(), nil
+	}
+
 	// Get service container version output
 	var cmd string
 	if c.daemonPath == "" {
@@ -506,6 +536,16 @@ func (c *Client) GetDockerImage(container string) (string, error) {
 
 }
 
+// Check whether a Docker container exists
+func (c *Client) HasDockerContainer(container string) (bool, error) {
+	cmd := fmt.Sprintf("docker container ls --all --quiet --filter name=%s", shellescape.Quote("^/"+container+"$"))
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return false, err
+	}
+	return (strings.TrimSpace(string(output)) != ""), nil
+}
+
 // Get the current Docker image used by the given container
 func (c *Client) GetDockerStatus(container string) (string, error) {
 
@@ -537,6 +577,64 @@ func (c *Client) GetDockerContainerShutdownTime(container string) (time.Time, er
 
 }
 
+// Export EIP-3076 slashing protection data using a validator image, with the volumes of the given container
+// The export command's output path is substituted for %s
+func (c *Client) ExportClientSlashingProtection(container, image, exportCommand string) ([]byte, error) {
+	script := fmt.Sprintf("%s >&2 && cat %s", fmt.Sprintf(exportCommand, SlashingProtectionPath), SlashingProtectionPath)
+	cmd := fmt.Sprintf("docker run --rm --volumes-from %s --entrypoint sh %s -c %s", shellescape.Quote(container), shellescape.Quote(image), shellescape.Quote(script))
+	data, err := c.readOutput(cmd)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not export slashing protection data from %s: %w", image, err)
+	}
+	return data, nil
+}
+
+// Import EIP-3076 slashing protection data using a validator image, with the volumes of the given container
+// The import command's input path is substituted for %s
+func (c *Client) ImportClientSlashingProtection(container, image, importCommand string, data []byte) error {
+	script := fmt.Sprintf("cat > %s && %s", SlashingProtectionPath, fmt.Sprintf(importCommand, SlashingProtectionPath))
+	cmdText := fmt.Sprintf("docker run --rm -i --volumes-from %s --entrypoint sh %s -c %s", shellescape.Quote(container), shellescape.Quote(image), shellescape.Quote(script))
+	cmd, err := c.newCommand(cmdText)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cmd.Close()
+	}()
+	cmd.SetStdin(bytes.NewReader(data))
+	if output, err := cmd.Output(); err != nil {
+		return fmt.Errorf("Could not import slashing protection data into %s: %w; output: '%s'", image, err, string(output))
+	}
+	return nil
+}
+
+// Get the validator image which slashing protection data was last imported into
+func (c *Client) GetSlashingProtectionImport() (string, error) {
+	path, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, SlashingImportFile))
+	if err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("Could not read slashing protection import record: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Record the validator image which slashing protection data was imported into
+func (c *Client) SetSlashingProtectionImport(image string) error {
+	path, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, SlashingImportFile))
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, []byte(image), 0644); err != nil {
+		return fmt.Errorf("Could not write slashing protection import record: %w", err)
+	}
+	return nil
+}
+
 // Shut down a container
 func (c *Client) StopContainer(container string) (string, error) {
 
@@ -870,6 +968,26 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	return c.callAPIWithInput(args, nil, otherArgs...)
+}
+
+// Call the Rocket Pool API, passing input data which is too large for its arguments over stdin
+func (c *Client) callAPIWithInput(args string, input []byte, otherArgs ...string) ([]byte, error) {
+	// Use the API server if configured
+	connection, err := c.getAPIServerConnection()
+	if err != nil {
+		return []byte{}, err
+	}
+	if connection != nil {
+		output, err := c.callAPIServer(connection, append(strings.Fields(args), otherArgs...), input)
+		c.debugPrintAPIOutput(output, err)
+		c.resetGasSettings()
+		if err == nil {
+			err = c.checkTransactions(output)
+		}
+		return output, err
+	}
+
 	// Sanitize arguments
 	var sanitizedArgs []string
 	for _, arg := range strings.Fields(args) {
@@ -891,14 +1009,21 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), c.getGasOpts(), c.getCustomNonce(), args)
+		interactive := ""
+		if input != nil {
+			interactive = "-i"
+		}
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", interactive, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), c.getGasOpts(), c.getCustomNonce(), c.getAccount(), c.getDryRun(), c.getUnsigned(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --config %s --settings %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --config %s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, GlobalConfigFile)),
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, UserConfigFile)),
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getAccount(),
+			c.getDryRun(),
+			c.getUnsigned(),
 			args)
 	}
 
@@ -907,8 +1032,18 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		fmt.Println(cmd)
 	}
 
-	output, err := c.readOutput(cmd)
+	output, err := c.readOutputWithInput(cmd, input)
+	c.debugPrintAPIOutput(output, err)
+	c.resetGasSettings()
+	if err == nil {
+		err = c.checkTransactions(output)
+	}
 
+	return output, err
+}
+
+// Print the output of an API call in debug mode
+func (c *Client) debugPrintAPIOutput(output []byte, err error) {
 	if c.debugPrint {
 		if output != nil {
 			fmt.Println("API Out:")
@@ -919,13 +1054,13 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 			fmt.Println(err.Error())
 		}
 	}
+}
 
-	// Reset the gas settings after the call
+// Reset the gas settings after an API call
+func (c *Client) resetGasSettings() {
 	c.maxFee = c.originalMaxFee
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
-
-	return output, err
 }
 
 // Get the API container name
@@ -958,6 +1093,33 @@ func (c *Client) getCustomNonce() string {
 	return nonce
 }
 
+// Get the node account selector flag
+func (c *Client) getAccount() string {
+	account := ""
+	if c.account != "" {
+		account = fmt.Sprintf("--account %s", shellescape.Quote(c.account))
+	}
+	return account
+}
+
+// Get the dry-run mode flag
+func (c *Client) getDryRun() string {
+	dryRun := ""
+	if c.dryRun {
+		dryRun = "--dry-run"
+	}
+	return dryRun
+}
+
+// Get the unsigned transaction export flag
+func (c *Client) getUnsigned() string {
+	unsigned := ""
+	if c.exportPath != "" {
+		unsigned = "--unsigned"
+	}
+	return unsigned
+}
+
 // Get the first downloader available to the system
 func (c *Client) getDownloader() (string, error) {
 
@@ -1056,3 +1218,23 @@ func (c *Client) readOutput(cmdText string) ([]byte, error) {
 	return cmd.Output()
 
 }
+
+// Run a command with the given stdin and return its output
+func (c *Client) readOutputWithInput(cmdText string, input []byte) ([]byte, error) {
+
+	// Initialize command
+	cmd, err := c.newCommand(cmdText)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer func() {
+		_ = cmd.Close()
+	}()
+
+	// Run command and return output
+	if input != nil {
+		cmd.SetStdin(bytes.NewReader(input))
+	}
+	return cmd.Output()
+
+}
@@ -77,6 +77,15 @@ func (c *command) Output() ([]byte, error) {
 	}
 }
 
+// Set the command's stdin
+func (c *command) SetStdin(stdin io.Reader) {
+	if c.cmd != nil {
+		c.cmd.Stdin = stdin
+	} else {
+		c.session.Stdin = stdin
+	}
+}
+
 // Get a pipe to the command's stdout
 func (c *command) StdoutPipe() (io.Reader, error) {
 	if c.cmd != nil {
@@ -0,0 +1,67 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Returned by API calls which signed transactions in dry-run mode instead of submitting them
+var ErrDryRun = errors.New("Dry run complete; no transactions were submitted")
+
+// API response fields added in dry-run mode
+type dryRunResponse struct {
+	DryRunTransactions []api.DryRunTransaction `json:"dryRunTransactions"`
+}
+
+// Handle the transactions which an API call didn't submit in dry-run or unsigned export mode
+func (c *Client) checkTransactions(responseBytes []byte) error {
+	if err := c.checkDryRun(responseBytes); err != nil {
+		return err
+	}
+	return c.checkUnsignedExport(responseBytes)
+}
+
+// Print the transactions simulated by an API call in dry-run mode
+// Returns ErrDryRun if there were any, so commands stop instead of waiting for transactions which weren't submitted
+func (c *Client) checkDryRun(responseBytes []byte) error {
+	if !c.dryRun {
+		return nil
+	}
+	var response dryRunResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return fmt.Errorf("Could not decode dry-run transactions: %w", err)
+	}
+	if len(response.DryRunTransactions) == 0 {
+		return nil
+	}
+	for _, tx := range response.DryRunTransactions {
+		fmt.Printf("Signed transaction %s (nonce %d):\n", tx.Hash.Hex(), tx.Nonce)
+		if tx.To != nil {
+			fmt.Printf("To: %s\n", tx.To.Hex())
+		}
+		fmt.Printf("Value: %.6f ETH\n", eth.WeiToEth(tx.Value))
+		fmt.Printf("Gas limit: %d\n", tx.GasLimit)
+		fmt.Printf("Max fee %.6f Gwei, max priority fee %.6f Gwei.\n", eth.WeiToGwei(tx.MaxFeeWei), eth.WeiToGwei(tx.MaxPriorityFeeWei))
+		if tx.Reverted {
+			fmt.Printf("%sSimulation: the transaction would revert: %s%s\n", colorRed, tx.RevertReason, colorReset)
+		} else {
+			fmt.Printf("%sSimulation: the transaction would succeed.%s\n", colorGreen, colorReset)
+		}
+		fmt.Printf("Raw transaction:\n%s\n\n", tx.RawTx.String())
+	}
+	return ErrDryRun
+}
+
+// Print a note if the first transaction of an operation with multiple transactions was only simulated
+// The remaining transactions depend on it being mined, so the operation stops there in dry-run mode
+func (c *Client) PrintMultiTxDryRunNote(err error) {
+	if !errors.Is(err, ErrDryRun) {
+		return
+	}
+	fmt.Printf("%sNOTE: This operation requires multiple transactions. Only the first one was simulated, as the rest depend on it being mined.%s\n", colorYellow, colorReset)
+}
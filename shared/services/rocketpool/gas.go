@@ -6,6 +6,8 @@ import (
 
 const colorReset string = "\033[0m"
 const colorYellow string = "\033[33m"
+const colorRed string = "\033[31m"
+const colorGreen string = "\033[32m"
 
 // Print a warning about the gas estimate for operations that have multiple transactions
 func (rp *Client) PrintMultiTxWarning() {
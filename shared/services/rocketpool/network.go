@@ -24,6 +24,22 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get gas price suggestions
+func (c *Client) GasPrices() (api.GasPricesResponse, error) {
+	responseBytes, err := c.callAPI("network gas-prices")
+	if err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not get gas prices: %w", err)
+	}
+	var response api.GasPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not decode gas prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasPricesResponse{}, fmt.Errorf("Could not get gas prices: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -572,6 +573,22 @@ func (c *Client) NodeRewards() (api.NodeRewardsResponse, error) {
 	return response, nil
 }
 
+// Get the node's minipool validator balance history, grouped by period
+func (c *Client) NodeRewardsHistory(period string) (api.NodeRewardsHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node rewards-history %s", period))
+	if err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %w", err)
+	}
+	var response api.NodeRewardsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not decode node rewards history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsHistoryResponse{}, fmt.Errorf("Could not get node rewards history: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the deposit contract info for Rocket Pool and the Beacon Client
 func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error) {
 	responseBytes, err := c.callAPI("node deposit-contract-info")
@@ -587,3 +604,51 @@ func (c *Client) DepositContractInfo() (api.DepositContractInfoResponse, error)
 	}
 	return response, nil
 }
+
+// Get the node's pending transactions
+func (c *Client) PendingTransactions() (api.PendingTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node pending-transactions")
+	if err != nil {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.PendingTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a pending transaction
+func (c *Client) CancelTransaction(hash common.Hash) (api.CancelTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-transaction %s", hash.Hex()))
+	if err != nil {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+	}
+	var response api.CancelTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not decode cancel transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a signed transaction
+func (c *Client) BroadcastTransaction(rawTx hexutil.Bytes) (api.BroadcastTransactionResponse, error) {
+	responseBytes, err := c.callAPI("node broadcast-transaction", rawTx.String())
+	if err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %w", err)
+	}
+	var response api.BroadcastTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not decode broadcast transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,53 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const UnsignedExportFileMode = 0644
+
+// Returned by API calls whose transactions were exported unsigned instead of being submitted
+var ErrUnsignedExport = errors.New("Transactions exported; no transactions were submitted")
+
+// API response fields added in unsigned export mode
+type unsignedExportResponse struct {
+	UnsignedTransactions []api.UnsignedTransaction `json:"unsignedTransactions"`
+}
+
+// Write the transactions exported by an API call in unsigned export mode to the export file
+// Returns ErrUnsignedExport if there were any, so commands stop instead of waiting for transactions which weren't submitted
+func (c *Client) checkUnsignedExport(responseBytes []byte) error {
+	if c.exportPath == "" {
+		return nil
+	}
+	var response unsignedExportResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return fmt.Errorf("Could not decode unsigned transactions: %w", err)
+	}
+	if len(response.UnsignedTransactions) == 0 {
+		return nil
+	}
+	fileBytes, err := json.MarshalIndent(api.UnsignedTransactionsFile{Transactions: response.UnsignedTransactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not encode unsigned transactions: %w", err)
+	}
+	if err := ioutil.WriteFile(c.exportPath, fileBytes, UnsignedExportFileMode); err != nil {
+		return fmt.Errorf("Could not write unsigned transactions to %s: %w", c.exportPath, err)
+	}
+	for _, tx := range response.UnsignedTransactions {
+		if tx.To != nil {
+			fmt.Printf("Exported unsigned transaction to %s (nonce %d).\n", tx.To.Hex(), tx.Nonce)
+		} else {
+			fmt.Printf("Exported unsigned transaction (nonce %d).\n", tx.Nonce)
+		}
+	}
+	fmt.Printf("Wrote %d unsigned transaction(s) to %s.\n", len(response.UnsignedTransactions), c.exportPath)
+	fmt.Println("Sign them on the machine with the node wallet using `rocketpool sign`, then submit them with `rocketpool broadcast`.")
+	return ErrUnsignedExport
+}
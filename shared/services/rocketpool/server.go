@@ -0,0 +1,178 @@
+package rocketpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const (
+	APIServerApiPath     = "/api"
+	APIServerVersionPath = "/version"
+	APIServerUnixScheme  = "unix"
+	APIServerUnixHost    = "rocketpool"
+)
+
+// Connection details for the Rocket Pool API server
+type apiServerConnection struct {
+	client  *http.Client
+	baseUrl string
+	token   string
+}
+
+// Get a connection to the API server if one is configured
+func (c *Client) getAPIServerConnection() (*apiServerConnection, error) {
+
+	// API server connections are unavailable in non-docker mode
+	if c.daemonPath != "" {
+		return nil, nil
+	}
+
+	// Load config
+	cfg, err := c.LoadMergedConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ApiServer.ClientUrl == "" {
+		return nil, nil
+	}
+	return newAPIServerConnection(cfg.ApiServer)
+
+}
+
+// Create a connection to the API server from its config
+func newAPIServerConnection(cfg config.ApiServer) (*apiServerConnection, error) {
+
+	// Parse the server URL
+	serverUrl, err := url.Parse(cfg.ClientUrl)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid API server URL '%s': %w", cfg.ClientUrl, err)
+	}
+
+	// Initialize the HTTP client; unix socket URLs are dialed directly
+	connection := &apiServerConnection{client: &http.Client{}}
+	switch serverUrl.Scheme {
+	case APIServerUnixScheme:
+		socketPath, err := homedir.Expand(serverUrl.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Could not expand API server socket path: %w", err)
+		}
+		connection.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		connection.baseUrl = fmt.Sprintf("http://%s", APIServerUnixHost)
+	case "http", "https":
+		connection.baseUrl = strings.TrimSuffix(serverUrl.String(), "/")
+	default:
+		return nil, fmt.Errorf("Unsupported API server URL scheme '%s'", serverUrl.Scheme)
+	}
+
+	// Load the auth token
+	if cfg.ClientTokenPath != "" {
+		tokenPath, err := homedir.Expand(cfg.ClientTokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("Could not expand API server token path: %w", err)
+		}
+		tokenBytes, err := ioutil.ReadFile(tokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read API server token at %s: %w", tokenPath, err)
+		}
+		connection.token = strings.TrimSpace(string(tokenBytes))
+	}
+
+	// Return
+	return connection, nil
+
+}
+
+// Run an API command on the API server
+func (c *Client) callAPIServer(connection *apiServerConnection, args []string, input []byte) ([]byte, error) {
+
+	// Encode request
+	request := api.ServerRequest{
+		Args:       args,
+		MaxFee:     c.maxFee,
+		MaxPrioFee: c.maxPrioFee,
+		GasLimit:   c.gasLimit,
+		Account:    c.account,
+		DryRun:     c.dryRun,
+		Unsigned:   c.exportPath != "",
+		Input:      string(input),
+	}
+	if c.customNonce != nil {
+		request.Nonce = c.customNonce.String()
+	}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not encode API server request: %w", err)
+	}
+
+	if c.debugPrint {
+		fmt.Println("To API server:")
+		fmt.Println(string(requestBytes))
+	}
+
+	// Send request
+	return connection.send(http.MethodPost, APIServerApiPath, requestBytes)
+
+}
+
+// Get the Rocket Pool service version from the API server
+func (c *Client) getAPIServerVersion(connection *apiServerConnection) (string, error) {
+	responseBytes, err := connection.send(http.MethodGet, APIServerVersionPath, nil)
+	if err != nil {
+		return "", err
+	}
+	var response api.ServerVersionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return "", fmt.Errorf("Could not decode API server version response: %w", err)
+	}
+	if response.Error != "" {
+		return "", fmt.Errorf("Could not get API server version: %s", response.Error)
+	}
+	return response.Version, nil
+}
+
+// Send a request to the API server and return the response body
+func (a *apiServerConnection) send(method string, path string, body []byte) ([]byte, error) {
+
+	// Create request
+	request, err := http.NewRequest(method, a.baseUrl+path, bytes.NewReader(body))
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not create API server request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.token))
+	}
+
+	// Send request
+	response, err := a.client.Do(request)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not reach the API server: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Read response; error responses still contain a JSON API response
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not read API server response: %w", err)
+	}
+	return responseBytes, nil
+
+}
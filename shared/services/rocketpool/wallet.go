@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/rocket-pool/rocketpool-go/types"
+
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -39,6 +41,38 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// Change wallet password
+func (c *Client) ChangePassword(currentPassword, newPassword string) (api.ChangePasswordResponse, error) {
+	responseBytes, err := c.callAPI("wallet change-password", currentPassword, newPassword)
+	if err != nil {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not change wallet password: %w", err)
+	}
+	var response api.ChangePasswordResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not decode change wallet password response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not change wallet password: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Unlock wallet
+func (c *Client) UnlockWallet(password string) (api.UnlockWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet unlock", password)
+	if err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %w", err)
+	}
+	var response api.UnlockWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not decode unlock wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Initialize wallet
 func (c *Client) InitWallet() (api.InitWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet init")
@@ -87,6 +121,70 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Import a validator keystore
+func (c *Client) ImportValidatorKey(keystore, password string) (api.ImportValidatorKeyResponse, error) {
+	responseBytes, err := c.callAPI("wallet import-validator-key", keystore, password)
+	if err != nil {
+		return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+	}
+	var response api.ImportValidatorKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not decode import validator key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportValidatorKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node accounts
+func (c *Client) NodeAccounts() (api.NodeAccountsResponse, error) {
+	responseBytes, err := c.callAPI("wallet accounts")
+	if err != nil {
+		return api.NodeAccountsResponse{}, fmt.Errorf("Could not get node accounts: %w", err)
+	}
+	var response api.NodeAccountsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeAccountsResponse{}, fmt.Errorf("Could not decode node accounts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeAccountsResponse{}, fmt.Errorf("Could not get node accounts: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Add a named node account
+func (c *Client) AddNodeAccount(name string, index uint) (api.AddNodeAccountResponse, error) {
+	responseBytes, err := c.callAPI("wallet add-account", name, fmt.Sprint(index))
+	if err != nil {
+		return api.AddNodeAccountResponse{}, fmt.Errorf("Could not add node account: %w", err)
+	}
+	var response api.AddNodeAccountResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AddNodeAccountResponse{}, fmt.Errorf("Could not decode add node account response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AddNodeAccountResponse{}, fmt.Errorf("Could not add node account: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Verify wallet
+func (c *Client) VerifyWallet() (api.VerifyWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet verify")
+	if err != nil {
+		return api.VerifyWalletResponse{}, fmt.Errorf("Could not verify wallet: %w", err)
+	}
+	var response api.VerifyWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyWalletResponse{}, fmt.Errorf("Could not decode verify wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyWalletResponse{}, fmt.Errorf("Could not verify wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Export wallet
 func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet export")
@@ -102,3 +200,73 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Delete a validator key from the validator client and get its slashing protection data
+func (c *Client) DeleteValidatorKey(pubkey types.ValidatorPubkey) (api.DeleteValidatorKeyResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet delete-validator-key %s", pubkey.Hex()))
+	if err != nil {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not delete validator key: %w", err)
+	}
+	var response api.DeleteValidatorKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not decode delete validator key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not delete validator key: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Delete validator keys from the validator client and export their slashing protection data
+// On error, the response holds the data of any keys which were deleted
+func (c *Client) ExportSlashingProtection() (api.ExportSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPI("wallet export-slashing-protection")
+	if err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not export slashing protection data: %w", err)
+	}
+	var response api.ExportSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportSlashingProtectionResponse{}, fmt.Errorf("Could not decode export slashing protection response: %w", err)
+	}
+	if response.Error != "" {
+		// The data of keys deleted before the error is returned with it
+		return response, fmt.Errorf("Could not export slashing protection data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Import validator keys into the validator client with their slashing protection data
+func (c *Client) ImportSlashingProtection(data []byte) (api.ImportSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPIWithInput("wallet import-slashing-protection", data)
+	if err != nil {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not import slashing protection data: %w", err)
+	}
+	var response api.ImportSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not decode import slashing protection response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportSlashingProtectionResponse{}, fmt.Errorf("Could not import slashing protection data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign exported node account transactions
+func (c *Client) SignTransactions(txs api.UnsignedTransactionsFile) (api.SignTransactionsResponse, error) {
+	txsBytes, err := json.Marshal(txs)
+	if err != nil {
+		return api.SignTransactionsResponse{}, fmt.Errorf("Could not encode transactions: %w", err)
+	}
+	responseBytes, err := c.callAPIWithInput("wallet sign-transactions", txsBytes)
+	if err != nil {
+		return api.SignTransactionsResponse{}, fmt.Errorf("Could not sign transactions: %w", err)
+	}
+	var response api.SignTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignTransactionsResponse{}, fmt.Errorf("Could not decode sign transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignTransactionsResponse{}, fmt.Errorf("Could not sign transactions: %s", response.Error)
+	}
+	return response, nil
+}
@@ -1,31 +1,42 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	uc "github.com/rocket-pool/rocketpool-go/utils/client"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/cache"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/failover"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/teku"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/external"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
 )
 
 // Config
@@ -42,18 +53,40 @@ var (
 	rplFaucet       *contracts.RPLFaucet
 	beaconClient    beacon.Client
 	docker          *client.Client
+	gasOracle       *gas.Oracle
+	txManager       *transactions.Manager
+	dryRun          *transactions.DryRun
+	txExport        *transactions.Export
 
-	initCfg             sync.Once
-	initPasswordManager sync.Once
-	initNodeWallet      sync.Once
-	initEthClientProxy  sync.Once
-	initRocketPool      sync.Once
-	initOneInchOracle   sync.Once
-	initRplFaucet       sync.Once
-	initBeaconClient    sync.Once
-	initDocker          sync.Once
+	initCfg             serviceInit
+	initPasswordManager serviceInit
+	initNodeWallet      serviceInit
+	initEthClientProxy  serviceInit
+	initRocketPool      serviceInit
+	initOneInchOracle   serviceInit
+	initRplFaucet       serviceInit
+	initBeaconClient    serviceInit
+	initDocker          serviceInit
+	initGasOracle       serviceInit
+	initTxManager       serviceInit
+	initDryRun          serviceInit
+	initTxExport        sync.Once
 )
 
+// Service initializer which runs once and keeps its error
+// Callers after a failed initialization get the error again rather than a nil service
+type serviceInit struct {
+	once sync.Once
+	err  error
+}
+
+func (i *serviceInit) Do(init func() error) error {
+	i.once.Do(func() {
+		i.err = init()
+	})
+	return i.err
+}
+
 //
 // Service providers
 //
@@ -67,7 +100,7 @@ func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	return getPasswordManager(cfg), nil
+	return getPasswordManager(cfg)
 }
 
 func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
@@ -75,8 +108,61 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	if err != nil {
 		return nil, err
 	}
-	pm := getPasswordManager(cfg)
-	return getWallet(cfg, pm)
+	pm, err := getPasswordManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	w, err := getWallet(cfg, pm)
+	if err != nil {
+		return nil, err
+	}
+	aw, err := w.ForNodeAccount(c.GlobalString("account"))
+	if err != nil {
+		return nil, err
+	}
+	if c.GlobalBool("unsigned") {
+		return aw.ForUnsignedExport(getTransactionExport()), nil
+	}
+	if c.GlobalBool("dry-run") {
+		dr, err := getDryRun(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return aw.ForDryRun(dr), nil
+	}
+	return aw, nil
+}
+
+func GetGasOracle(c *cli.Context) (*gas.Oracle, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getGasOracle(cfg)
+}
+
+func GetTransactionManager(c *cli.Context) (*transactions.Manager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClientProxy(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getTransactionManager(cfg, ec)
+}
+
+func GetDryRun(c *cli.Context) (*transactions.DryRun, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getDryRun(cfg)
+}
+
+func GetTransactionExport(c *cli.Context) *transactions.Export {
+	return getTransactionExport()
 }
 
 func GetEthClientProxy(c *cli.Context) (*uc.EthClientProxy, error) {
@@ -145,41 +231,102 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 //
 
 func getConfig(c *cli.Context) (config.RocketPoolConfig, error) {
-	var err error
-	initCfg.Do(func() {
+	err := initCfg.Do(func() error {
+		var err error
 		cfg, err = config.Load(c)
+		return err
 	})
 	return cfg, err
 }
 
-func getPasswordManager(cfg config.RocketPoolConfig) *passwords.PasswordManager {
-	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.PasswordPath))
+func getPasswordManager(cfg config.RocketPoolConfig) (*passwords.PasswordManager, error) {
+	err := initPasswordManager.Do(func() error {
+		var err error
+		var backend passwords.Backend
+		backend, err = getPasswordBackend(cfg)
+		if err == nil {
+			passwordManager = passwords.NewPasswordManagerWithBackend(backend)
+		}
+		return err
 	})
-	return passwordManager
+	return passwordManager, err
 }
 
 func getWallet(cfg config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
-	var err error
-	initNodeWallet.Do(func() {
+	err := initNodeWallet.Do(func() error {
+		var err error
 		var maxFee *big.Int
 		var maxPriorityFee *big.Int
 		var gasLimit uint64
 		maxFee, err = cfg.GetMaxFee()
 		if err != nil {
-			return
+			return err
 		}
 		maxPriorityFee, err = cfg.GetMaxPriorityFee()
 		if err != nil {
-			return
+			return err
 		}
 		gasLimit, err = cfg.GetGasLimit()
 		if err != nil {
-			return
+			return err
 		}
 		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.WalletPath), cfg.Chains.Platform.ChainID, maxFee, maxPriorityFee, gasLimit, pm)
 		if err != nil {
-			return
+			return err
+		}
+		if cfg.Smartnode.NodeAddress != "" {
+			// Unsigned transactions can be exported for the watch-only node address before the wallet is initialized
+			if !common.IsHexAddress(cfg.Smartnode.NodeAddress) {
+				err = fmt.Errorf("Invalid node address '%s'", cfg.Smartnode.NodeAddress)
+				return err
+			}
+			nodeWallet.SetWatchOnlyNodeAddress(common.HexToAddress(cfg.Smartnode.NodeAddress))
+		}
+		if cfg.Smartnode.NodeSigner.Url != "" {
+			// Node account transactions are approved by the external signer; validator keys still come from the seed
+			var nodeSigner *external.Signer
+			nodeSigner, err = newNodeSigner(cfg.Smartnode.NodeSigner)
+			if err != nil {
+				return err
+			}
+			nodeWallet.SetNodeSigner(nodeSigner)
+		}
+		// Node account nonces are allocated through the transaction database, which the daemons & API share
+		// It's only opened once a transactor is needed, so wallet commands don't depend on the execution client or database
+		nodeWallet.SetNonceAllocatorGetter(func() (wallet.NonceAllocator, error) {
+			ec, err := getEthClientProxy(cfg)
+			if err != nil {
+				return nil, err
+			}
+			txm, err := getTransactionManager(cfg, ec)
+			if err != nil {
+				return nil, err
+			}
+			return txm, nil
+		})
+		if cfg.Smartnode.RemoteSigner.Url != "" {
+			// Validator keys live in the remote signer only, so local keystores are not written
+			if err = cfg.ValidateRemoteSigner(); err != nil {
+				return err
+			}
+			var signerClient *web3signer.Client
+			signerClient, err = newRemoteSignerClient(cfg.Smartnode.RemoteSigner)
+			if err != nil {
+				return err
+			}
+			nodeWallet.AddKeystore("web3signer", web3signer.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), cfg.Smartnode.RemoteSigner.ValidatorUrl, signerClient))
+			nodeWallet.SetRemoteSigner(signerClient)
+			return nil
+		}
+		if cfg.Smartnode.KeymanagerApi.Url != "" {
+			// Validator keys are pushed to the running validator client, so local keystores are not written
+			var authToken string
+			authToken, err = readTokenFile(cfg.Smartnode.KeymanagerApi.TokenPath)
+			if err != nil {
+				return err
+			}
+			nodeWallet.AddKeystore("keymanager", keymanager.NewKeystore(keymanager.NewClient(cfg.Smartnode.KeymanagerApi.Url, authToken)))
+			return nil
 		}
 		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), pm)
 		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), pm)
@@ -189,73 +336,269 @@ func getWallet(cfg config.RocketPoolConfig, pm *passwords.PasswordManager) (*wal
 		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
 		nodeWallet.AddKeystore("prysm", prysmKeystore)
 		nodeWallet.AddKeystore("teku", tekuKeystore)
+		return nil
 	})
 	return nodeWallet, err
 }
 
+func newRemoteSignerClient(signerCfg config.RemoteSigner) (*web3signer.Client, error) {
+	authToken, err := readTokenFile(signerCfg.AuthTokenPath)
+	if err != nil {
+		return nil, err
+	}
+	return web3signer.NewClient(signerCfg.Url, authToken), nil
+}
+
+func newNodeSigner(signerCfg config.NodeSigner) (*external.Signer, error) {
+	if !common.IsHexAddress(signerCfg.Address) {
+		return nil, fmt.Errorf("Invalid external node signer address '%s'", signerCfg.Address)
+	}
+	authToken, err := readTokenFile(signerCfg.TokenPath)
+	if err != nil {
+		return nil, err
+	}
+	return external.NewSigner(signerCfg.Url, signerCfg.Type, common.HexToAddress(signerCfg.Address), authToken)
+}
+
+func getPasswordBackend(cfg config.RocketPoolConfig) (passwords.Backend, error) {
+	backendCfg := cfg.Smartnode.PasswordBackend
+	passwordPath := os.ExpandEnv(cfg.Smartnode.PasswordPath)
+
+	// Check the wallet can't be decrypted from disk alone
+	if backendCfg.RequireExternal {
+		if backendCfg.Type == "" || backendCfg.Type == "file" {
+			return nil, errors.New("An external password backend is required but the file backend is configured")
+		}
+		if _, err := os.Stat(passwordPath); err == nil {
+			return nil, fmt.Errorf("An external password backend is required but a plaintext password file exists at %s; please remove it", passwordPath)
+		}
+	}
+
+	// Create backend
+	switch backendCfg.Type {
+	case "", "file":
+		return passwords.NewFileBackend(passwordPath), nil
+	case "unlock":
+		if backendCfg.UnlockPath == "" {
+			return nil, errors.New("The unlock password backend requires an unlock path")
+		}
+		return passwords.NewUnlockBackend(os.ExpandEnv(backendCfg.UnlockPath)), nil
+	case "env":
+		if backendCfg.Env == "" {
+			return nil, errors.New("The env password backend requires an environment variable name")
+		}
+		return passwords.NewEnvBackend(backendCfg.Env), nil
+	case "command":
+		if backendCfg.Command == "" {
+			return nil, errors.New("The command password backend requires a command")
+		}
+		return passwords.NewCommandBackend(backendCfg.Command), nil
+	case "http":
+		if backendCfg.Url == "" {
+			return nil, errors.New("The http password backend requires a URL")
+		}
+		token, err := readTokenFile(backendCfg.TokenPath)
+		if err != nil {
+			return nil, err
+		}
+		return passwords.NewHttpBackend(backendCfg.Url, token, backendCfg.Field), nil
+	default:
+		return nil, fmt.Errorf("Unknown password backend type '%s'", backendCfg.Type)
+	}
+}
+
+func readTokenFile(tokenPath string) (string, error) {
+	if tokenPath == "" {
+		return "", nil
+	}
+	tokenBytes, err := ioutil.ReadFile(os.ExpandEnv(tokenPath))
+	if err != nil {
+		return "", fmt.Errorf("Could not read auth token file: %w", err)
+	}
+	return strings.TrimSpace(string(tokenBytes)), nil
+}
+
 func getEthClientProxy(cfg config.RocketPoolConfig) (*uc.EthClientProxy, error) {
-	var err error
-	initEthClientProxy.Do(func() {
+	err := initEthClientProxy.Do(func() error {
 		reconnectDelay, err := time.ParseDuration(cfg.Chains.Platform.ReconnectDelay)
 		if err != nil {
-			return
+			return err
 		}
 		if cfg.Chains.Platform.Client.Selected == "" {
 			ethClientProxy = uc.NewEth1ClientProxy(reconnectDelay, cfg.Chains.Platform.Provider)
 		} else {
 			ethClientProxy = uc.NewEth1ClientProxy(reconnectDelay, cfg.Chains.Platform.Provider, cfg.Chains.Platform.FallbackProvider)
 		}
+		return nil
 	})
 	return ethClientProxy, err
 }
 
+func getGasOracle(cfg config.RocketPoolConfig) (*gas.Oracle, error) {
+	err := initGasOracle.Do(func() error {
+		// Fee history is read from the primary execution client, then the fallback client if one is selected
+		providers := []string{cfg.Chains.Platform.Provider}
+		if cfg.Chains.Platform.Client.Selected != "" && cfg.Chains.Platform.FallbackProvider != "" {
+			providers = append(providers, cfg.Chains.Platform.FallbackProvider)
+		}
+		callers := []gas.RpcCaller{}
+		for _, provider := range providers {
+			rpcClient, err := rpc.Dial(provider)
+			if err != nil {
+				return fmt.Errorf("Could not connect to execution client at %s: %w", provider, err)
+			}
+			callers = append(callers, rpcClient)
+		}
+		var err error
+		gasOracle, err = gas.NewOracle(cfg.Smartnode.GasOracle, callers...)
+		return err
+	})
+	return gasOracle, err
+}
+
+func getTransactionManager(cfg config.RocketPoolConfig, ec *uc.EthClientProxy) (*transactions.Manager, error) {
+	err := initTxManager.Do(func() error {
+		var err error
+		txManager, err = transactions.NewManager(cfg.GetPendingTransactionsPath(), cfg.Smartnode.Transactions, ec)
+		return err
+	})
+	return txManager, err
+}
+
+func getDryRun(cfg config.RocketPoolConfig) (*transactions.DryRun, error) {
+	err := initDryRun.Do(func() error {
+		// Transactions are simulated against the primary execution client's pending block
+		rpcClient, err := rpc.Dial(cfg.Chains.Platform.Provider)
+		if err != nil {
+			return fmt.Errorf("Could not connect to execution client at %s: %w", cfg.Chains.Platform.Provider, err)
+		}
+		dryRun = transactions.NewDryRun(rpcClient)
+		return nil
+	})
+	return dryRun, err
+}
+
+func getTransactionExport() *transactions.Export {
+	initTxExport.Do(func() {
+		txExport = transactions.NewExport()
+	})
+	return txExport
+}
+
 func getRocketPool(cfg config.RocketPoolConfig, client *uc.EthClientProxy) (*rocketpool.RocketPool, error) {
-	var err error
-	initRocketPool.Do(func() {
+	err := initRocketPool.Do(func() error {
+		var err error
 		rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Rocketpool.StorageAddress))
+		return err
 	})
 	return rocketPool, err
 }
 
 func getOneInchOracle(cfg config.RocketPoolConfig, client *uc.EthClientProxy) (*contracts.OneInchOracle, error) {
-	var err error
-	initOneInchOracle.Do(func() {
+	err := initOneInchOracle.Do(func() error {
+		var err error
 		oneInchOracle, err = contracts.NewOneInchOracle(common.HexToAddress(cfg.Rocketpool.OneInchOracleAddress), client)
+		return err
 	})
 	return oneInchOracle, err
 }
 
 func getRplFaucet(cfg config.RocketPoolConfig, client *uc.EthClientProxy) (*contracts.RPLFaucet, error) {
-	var err error
-	initRplFaucet.Do(func() {
+	err := initRplFaucet.Do(func() error {
+		var err error
 		rplFaucet, err = contracts.NewRPLFaucet(common.HexToAddress(cfg.Rocketpool.RPLFaucetAddress), client)
+		return err
 	})
 	return rplFaucet, err
 }
 
 func getBeaconClient(cfg config.RocketPoolConfig) (beacon.Client, error) {
-	var err error
-	initBeaconClient.Do(func() {
-		switch cfg.Chains.Platform.Client.Selected {
-		case "lighthouse":
-			beaconClient = lighthouse.NewClient(cfg.Chains.Platform.Provider)
-		case "nimbus":
-			beaconClient = nimbus.NewClient(cfg.Chains.Platform.Provider)
-		case "prysm":
-			beaconClient = prysm.NewClient(cfg.Chains.Platform.Provider)
-		case "teku":
-			beaconClient = teku.NewClient(cfg.Chains.Platform.Provider)
-		default:
-			err = fmt.Errorf("Unknown Eth 2.0 client '%s' selected", cfg.Chains.Platform.Client.Selected)
+	err := initBeaconClient.Do(func() error {
+
+		// Create the client, with any failover endpoints
+		client, err := newFailoverBeaconClient(cfg)
+		if err != nil {
+			return err
 		}
+
+		// Cache its responses unless disabled
+		var cacheTTL time.Duration
+		if cfg.Chains.Platform.BeaconCacheTTL != "" {
+			cacheTTL, err = time.ParseDuration(cfg.Chains.Platform.BeaconCacheTTL)
+			if err != nil {
+				return fmt.Errorf("Invalid beacon cache TTL '%s': %w", cfg.Chains.Platform.BeaconCacheTTL, err)
+			}
+			if cacheTTL == 0 {
+				beaconClient = client
+				return nil
+			}
+		}
+		beaconClient = cache.NewClient(client, cacheTTL)
+		return nil
+
 	})
 	return beaconClient, err
 }
 
+func newFailoverBeaconClient(cfg config.RocketPoolConfig) (beacon.Client, error) {
+
+	// Create the primary client
+	selected := cfg.Chains.Platform.Client.Selected
+	primary, err := newBeaconClient(selected, cfg.Chains.Platform.Provider)
+	if err != nil {
+		return nil, err
+	}
+	failoverCfg := cfg.Chains.Platform.BeaconFailover
+	if len(failoverCfg.Providers) == 0 {
+		return primary, nil
+	}
+
+	// Create the failover clients
+	providers := []string{cfg.Chains.Platform.Provider}
+	clients := []beacon.Client{primary}
+	for _, provider := range failoverCfg.Providers {
+		clientName := provider.Client
+		if clientName == "" {
+			clientName = selected
+		}
+		client, err := newBeaconClient(clientName, provider.Provider)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider.Provider)
+		clients = append(clients, client)
+	}
+	var healthCheckInterval time.Duration
+	if failoverCfg.HealthCheckInterval != "" {
+		healthCheckInterval, err = time.ParseDuration(failoverCfg.HealthCheckInterval)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid beacon health check interval '%s': %w", failoverCfg.HealthCheckInterval, err)
+		}
+	}
+	return failover.NewClient(providers, clients, healthCheckInterval, failoverCfg.Quorum)
+
+}
+
+func newBeaconClient(clientName string, provider string) (beacon.Client, error) {
+	switch clientName {
+	case "lighthouse":
+		return lighthouse.NewClient(provider), nil
+	case "nimbus":
+		return nimbus.NewClient(provider), nil
+	case "prysm":
+		return prysm.NewClient(provider), nil
+	case "teku":
+		return teku.NewClient(provider), nil
+	default:
+		return nil, fmt.Errorf("Unknown Eth 2.0 client '%s' selected", clientName)
+	}
+}
+
 func getDocker() (*client.Client, error) {
-	var err error
-	initDocker.Do(func() {
+	err := initDocker.Do(func() error {
+		var err error
 		docker, err = client.NewClientWithOpts(client.WithVersion(DockerAPIVersion))
+		return err
 	})
 	return docker, err
 }
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestServiceInitKeepsError(t *testing.T) {
+	var init serviceInit
+	runs := 0
+	initErr := errors.New("init failed")
+	for i := 0; i < 2; i++ {
+		if err := init.Do(func() error {
+			runs++
+			return initErr
+		}); err != initErr {
+			t.Errorf("Expected the initialization error, got %v", err)
+		}
+	}
+	if runs != 1 {
+		t.Errorf("Expected the initializer to run once, ran %d times", runs)
+	}
+}
@@ -0,0 +1,137 @@
+package slashing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Supported EIP-3076 interchange format version
+const InterchangeFormatVersion = "5"
+
+// EIP-3076 slashing protection interchange data
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []ValidatorHistory  `json:"data"`
+}
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+type ValidatorHistory struct {
+	Pubkey             string              `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+type SignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+type SignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// Parse and validate slashing protection interchange data
+func Parse(data []byte) (*Interchange, error) {
+	var interchange Interchange
+	if err := json.Unmarshal(data, &interchange); err != nil {
+		return nil, fmt.Errorf("Could not decode slashing protection data: %w", err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return nil, fmt.Errorf("Unsupported slashing protection interchange format version '%s'", interchange.Metadata.InterchangeFormatVersion)
+	}
+	if interchange.Metadata.GenesisValidatorsRoot == "" {
+		return nil, errors.New("Slashing protection data is missing the genesis validators root")
+	}
+	for _, history := range interchange.Data {
+		if _, err := history.GetPubkey(); err != nil {
+			return nil, err
+		}
+	}
+	return &interchange, nil
+}
+
+// Merge slashing protection data for a common chain
+// Histories for the same validator are combined; clients keep the highest slots and epochs on import
+func Merge(interchanges ...*Interchange) (*Interchange, error) {
+	merged := &Interchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data:     []ValidatorHistory{},
+	}
+	indices := map[rptypes.ValidatorPubkey]int{}
+	for _, interchange := range interchanges {
+		if interchange == nil {
+			continue
+		}
+		root := strings.ToLower(interchange.Metadata.GenesisValidatorsRoot)
+		if merged.Metadata.GenesisValidatorsRoot == "" {
+			merged.Metadata.GenesisValidatorsRoot = root
+		} else if root != merged.Metadata.GenesisValidatorsRoot {
+			return nil, fmt.Errorf("Slashing protection data is for different chains (genesis validators roots %s and %s)", merged.Metadata.GenesisValidatorsRoot, root)
+		}
+		for _, history := range interchange.Data {
+			pubkey, err := history.GetPubkey()
+			if err != nil {
+				return nil, err
+			}
+			if index, ok := indices[pubkey]; ok {
+				merged.Data[index].SignedBlocks = append(merged.Data[index].SignedBlocks, history.SignedBlocks...)
+				merged.Data[index].SignedAttestations = append(merged.Data[index].SignedAttestations, history.SignedAttestations...)
+				continue
+			}
+			indices[pubkey] = len(merged.Data)
+			merged.Data = append(merged.Data, history)
+		}
+	}
+	return merged, nil
+}
+
+// Get slashing protection data for a single validator
+func (i *Interchange) Filter(pubkey rptypes.ValidatorPubkey) *Interchange {
+	filtered := &Interchange{
+		Metadata: i.Metadata,
+		Data:     []ValidatorHistory{},
+	}
+	for _, history := range i.Data {
+		if historyPubkey, err := history.GetPubkey(); err == nil && historyPubkey == pubkey {
+			filtered.Data = append(filtered.Data, history)
+		}
+	}
+	return filtered
+}
+
+// Get the validator pubkeys with slashing protection history
+func (i *Interchange) GetPubkeys() []rptypes.ValidatorPubkey {
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, history := range i.Data {
+		if pubkey, err := history.GetPubkey(); err == nil {
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	return pubkeys
+}
+
+// Encode slashing protection interchange data
+func (i *Interchange) Serialize() ([]byte, error) {
+	data, err := json.Marshal(i)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not encode slashing protection data: %w", err)
+	}
+	return data, nil
+}
+
+// Get a validator history's pubkey
+func (h ValidatorHistory) GetPubkey() (rptypes.ValidatorPubkey, error) {
+	pubkey, err := rptypes.HexToValidatorPubkey(strings.ToLower(hexutil.RemovePrefix(h.Pubkey)))
+	if err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Invalid validator pubkey '%s' in slashing protection data: %w", h.Pubkey, err)
+	}
+	return pubkey, nil
+}
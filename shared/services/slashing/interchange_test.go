@@ -0,0 +1,60 @@
+package slashing
+
+import (
+	"testing"
+)
+
+const (
+	pubkeyA = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	pubkeyB = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	rootA   = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	rootB   = "0x0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func interchange(root, pubkey, slot string) string {
+	return `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + root + `"},
+		"data":[{"pubkey":"` + pubkey + `","signed_blocks":[{"slot":"` + slot + `"}],"signed_attestations":[]}]}`
+}
+
+func TestParseValidates(t *testing.T) {
+	if _, err := Parse([]byte(interchange(rootA, pubkeyA, "1"))); err != nil {
+		t.Fatal(err)
+	}
+	invalid := []string{
+		`{"metadata":{"interchange_format_version":"4","genesis_validators_root":"` + rootA + `"},"data":[]}`,
+		`{"metadata":{"interchange_format_version":"5"},"data":[]}`,
+		interchange(rootA, "0x1234", "1"),
+		`not json`,
+	}
+	for _, data := range invalid {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("expected an error parsing %s", data)
+		}
+	}
+}
+
+func TestMergeCombinesHistories(t *testing.T) {
+	a, _ := Parse([]byte(interchange(rootA, pubkeyA, "1")))
+	b, _ := Parse([]byte(interchange(rootA, pubkeyA, "2")))
+	c, _ := Parse([]byte(interchange(rootA, pubkeyB, "3")))
+	merged, err := Merge(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Data) != 2 || len(merged.Data[0].SignedBlocks) != 2 {
+		t.Fatalf("unexpected merged data %+v", merged.Data)
+	}
+	if pubkeys := merged.GetPubkeys(); len(pubkeys) != 2 {
+		t.Errorf("expected 2 pubkeys, got %d", len(pubkeys))
+	}
+	pubkey, _ := merged.Data[1].GetPubkey()
+	if filtered := merged.Filter(pubkey); len(filtered.Data) != 1 || filtered.Data[0].SignedBlocks[0].Slot != "3" {
+		t.Errorf("unexpected filtered data %+v", filtered.Data)
+	}
+
+	// Data for different chains can't be merged
+	d, _ := Parse([]byte(interchange(rootB, pubkeyA, "1")))
+	if _, err := Merge(a, d); err == nil {
+		t.Error("expected an error merging data for different chains")
+	}
+}
@@ -0,0 +1,154 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Execution client RPC caller used to simulate transactions
+type RpcCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Records signed transactions in dry-run mode, simulating them against the pending block instead of submitting them
+type DryRun struct {
+	caller RpcCaller
+	txs    []api.DryRunTransaction
+	lock   sync.Mutex
+}
+
+// Create new dry-run recorder
+func NewDryRun(caller RpcCaller) *DryRun {
+	return &DryRun{
+		caller: caller,
+	}
+}
+
+// Simulate a signed transaction and record it
+func (d *DryRun) Record(ctx context.Context, from common.Address, tx *types.Transaction) error {
+
+	// Encode transaction
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("Could not encode transaction: %w", err)
+	}
+	dryRunTx := api.DryRunTransaction{
+		Hash:              tx.Hash(),
+		RawTx:             rawTx,
+		From:              from,
+		To:                tx.To(),
+		Nonce:             tx.Nonce(),
+		GasLimit:          tx.Gas(),
+		MaxFeeWei:         tx.GasFeeCap(),
+		MaxPriorityFeeWei: tx.GasTipCap(),
+		Value:             tx.Value(),
+	}
+
+	// Simulate transaction
+	var result hexutil.Bytes
+	if err := d.caller.CallContext(ctx, &result, "eth_call", toCallArg(from, tx), "pending"); err != nil {
+		if !isRevert(err) {
+			return fmt.Errorf("Could not simulate transaction: %w", err)
+		}
+		dryRunTx.Reverted = true
+		dryRunTx.RevertReason = getRevertReason(err)
+	}
+
+	// Record transaction
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.txs = append(d.txs, dryRunTx)
+	return nil
+
+}
+
+// Estimate the gas limit for a transaction from the pending block, padded like the contract bindings do
+// Returns 0 if the transaction would revert, so it can still be simulated & recorded with its revert reason
+func (d *DryRun) EstimateGas(ctx context.Context, from common.Address, tx *types.Transaction) (uint64, error) {
+	arg := toCallArg(from, tx)
+	delete(arg, "gas")
+	var gasLimit hexutil.Uint64
+	if err := d.caller.CallContext(ctx, &gasLimit, "eth_estimateGas", arg, "pending"); err != nil {
+		if isRevert(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("Could not estimate gas needed: %w", err)
+	}
+	safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
+	if safeGasLimit > rocketpool.MaxGasLimit {
+		safeGasLimit = rocketpool.MaxGasLimit
+	}
+	return safeGasLimit, nil
+}
+
+// Get the recorded transactions and clear them
+func (d *DryRun) Take() []api.DryRunTransaction {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	txs := d.txs
+	d.txs = nil
+	return txs
+}
+
+// Get the eth_call arguments for a transaction
+func toCallArg(from common.Address, tx *types.Transaction) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":  from,
+		"gas":   hexutil.Uint64(tx.Gas()),
+		"value": (*hexutil.Big)(tx.Value()),
+		"data":  hexutil.Bytes(tx.Data()),
+	}
+	if tx.To() != nil {
+		arg["to"] = tx.To()
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		arg["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		arg["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	}
+	return arg
+}
+
+// Check whether a call failed because it reverted, rather than because of the execution client or the call itself
+// Clients return reverts with error code 3, or with a generic error code & an "execution reverted" message
+func isRevert(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.ErrorCode() == 3 || strings.HasPrefix(rpcErr.Error(), "execution reverted")
+}
+
+// Get the reason for a reverted call from its error, decoding the revert data if it was returned
+func getRevertReason(err error) string {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err.Error()
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err.Error()
+	}
+	revertData, decodeErr := hexutil.Decode(data)
+	if decodeErr != nil {
+		return err.Error()
+	}
+	reason, unpackErr := abi.UnpackRevert(revertData)
+	if unpackErr != nil {
+		return err.Error()
+	}
+	return reason
+}
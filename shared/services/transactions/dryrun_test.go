@@ -0,0 +1,124 @@
+package transactions
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Execution client error for a reverted call
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return "execution reverted" }
+func (e revertError) ErrorCode() int         { return 3 }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+// Execution client error for a call which failed without reverting
+type callError struct{}
+
+func (e callError) Error() string  { return "insufficient funds for gas * price + value" }
+func (e callError) ErrorCode() int { return -32000 }
+
+// Execution client reverting calls with a reason
+type revertingCaller struct {
+	reason string
+	err    error
+	args   map[string]interface{}
+}
+
+func (r *revertingCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	r.args = args[0].(map[string]interface{})
+	if r.err != nil {
+		return r.err
+	}
+	if r.reason == "" {
+		if method == "eth_estimateGas" {
+			*result.(*hexutil.Uint64) = 30000
+		}
+		return nil
+	}
+	stringType, _ := abi.NewType("string", "", nil)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(r.reason)
+	if err != nil {
+		return err
+	}
+	return revertError{data: hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], data...))}
+}
+
+func TestDryRun(t *testing.T) {
+
+	// Sign a transaction
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 4, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 50000, To: &to, Value: big.NewInt(1), Data: []byte{1, 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Successful and reverting simulations are recorded with the raw transaction
+	caller := &revertingCaller{}
+	d := NewDryRun(caller)
+	if err := d.Record(context.Background(), opts.From, tx); err != nil {
+		t.Fatal(err)
+	}
+	if caller.args["from"] != opts.From || caller.args["maxFeePerGas"].(*hexutil.Big).ToInt().Int64() != 100 {
+		t.Errorf("unexpected call arguments %v", caller.args)
+	}
+	caller.reason = "Insufficient balance"
+	if err := d.Record(context.Background(), opts.From, tx); err != nil {
+		t.Fatal(err)
+	}
+	txs := d.Take()
+	if len(txs) != 2 || txs[0].Reverted || !txs[1].Reverted || txs[1].RevertReason != "Insufficient balance" {
+		t.Fatalf("unexpected dry-run transactions %+v", txs)
+	}
+	decodedTx := new(types.Transaction)
+	if err := decodedTx.UnmarshalBinary(txs[0].RawTx); err != nil || decodedTx.Hash() != tx.Hash() {
+		t.Errorf("unexpected raw transaction %s, %v", txs[0].RawTx.String(), err)
+	}
+
+	// Gas estimates are padded, and transactions which would revert are left to the simulation
+	caller.reason = ""
+	if gasLimit, err := d.EstimateGas(context.Background(), opts.From, tx); err != nil || gasLimit != 45000 {
+		t.Errorf("expected a gas limit of 45000, got %d, %v", gasLimit, err)
+	}
+	if _, ok := caller.args["gas"]; ok {
+		t.Error("expected the gas estimate not to be limited by the transaction's gas")
+	}
+	caller.reason = "Insufficient balance"
+	if gasLimit, err := d.EstimateGas(context.Background(), opts.From, tx); err != nil || gasLimit != 0 {
+		t.Errorf("expected no gas limit for a reverting transaction, got %d, %v", gasLimit, err)
+	}
+
+	// Calls which fail without reverting aren't recorded as reverts
+	caller.err = callError{}
+	if err := d.Record(context.Background(), opts.From, tx); err == nil {
+		t.Error("expected a call error to be returned rather than recorded as a revert")
+	}
+	if _, err := d.EstimateGas(context.Background(), opts.From, tx); err == nil {
+		t.Error("expected a call error to be returned from the gas estimate")
+	}
+	caller.err = nil
+
+	// Recorded transactions are cleared once taken
+	if txs := d.Take(); len(txs) != 0 {
+		t.Errorf("expected no dry-run transactions, got %d", len(txs))
+	}
+
+}
@@ -0,0 +1,49 @@
+package transactions
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Records unsigned transactions to be exported for signing on another machine
+type Export struct {
+	txs  []api.UnsignedTransaction
+	lock sync.Mutex
+}
+
+// Create new unsigned transaction export
+func NewExport() *Export {
+	return &Export{}
+}
+
+// Record an unsigned transaction
+func (e *Export) Record(ctx context.Context, from common.Address, tx *types.Transaction) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.txs = append(e.txs, api.UnsignedTransaction{
+		From:              from,
+		ChainID:           tx.ChainId(),
+		Nonce:             tx.Nonce(),
+		To:                tx.To(),
+		Value:             tx.Value(),
+		Data:              tx.Data(),
+		GasLimit:          tx.Gas(),
+		MaxFeeWei:         tx.GasFeeCap(),
+		MaxPriorityFeeWei: tx.GasTipCap(),
+	})
+	return nil
+}
+
+// Get the recorded transactions and clear them
+func (e *Export) Take() []api.UnsignedTransaction {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	txs := e.txs
+	e.txs = nil
+	return txs
+}
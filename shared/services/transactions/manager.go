@@ -0,0 +1,553 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	DefaultBumpBlocks         = 10
+	DefaultBumpPercent        = 20
+	DefaultMaxFeeMultiplier   = 3
+	MinBumpPercent            = 10
+	CancelGasLimit            = 21000
+	NonceReservationTimeout   = 2 * time.Minute
+	PollInterval              = 5 * time.Second
+	TransactionLookupAttempts = 30
+)
+
+// Returned by Wait when a transaction's fees can't be raised further, so it's left pending for Process to resume
+var ErrLeftPending = errors.New("transaction was left pending")
+
+// Execution client used to track & replace transactions
+type Client interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Transaction manager which persists pending transactions and replaces them with higher fees when they get stuck
+// Fees are raised by the bump percentage each time a transaction goes unmined for the bump interval, up to the max fee
+type Manager struct {
+	store        *Store
+	ec           Client
+	bumpBlocks   uint64
+	bumpPercent  float64
+	maxFeeWei    *big.Int
+	pollInterval time.Duration
+}
+
+// Create new transaction manager
+// If no max fee is configured, fees are raised to at most a multiple of the transaction's initial max fee
+func NewManager(path string, txCfg config.Transactions, ec Client) (*Manager, error) {
+	manager := &Manager{
+		store:        NewStore(path),
+		ec:           ec,
+		bumpBlocks:   txCfg.BumpBlocks,
+		bumpPercent:  txCfg.BumpPercent,
+		pollInterval: PollInterval,
+	}
+	if manager.bumpBlocks == 0 {
+		manager.bumpBlocks = DefaultBumpBlocks
+	}
+	if manager.bumpPercent == 0 {
+		manager.bumpPercent = DefaultBumpPercent
+	}
+	if manager.bumpPercent < MinBumpPercent {
+		return nil, fmt.Errorf("Invalid transaction fee bump of %f%% - execution clients require replacements to raise fees by at least %d%%", manager.bumpPercent, MinBumpPercent)
+	}
+	if txCfg.MaxFee > 0 {
+		manager.maxFeeWei = eth.GweiToWei(txCfg.MaxFee)
+	}
+	return manager, nil
+}
+
+// Get a sender's pending transactions in nonce order
+func (m *Manager) GetPendingTransactions(from common.Address) ([]PendingTransaction, error) {
+	return m.store.GetAll(from)
+}
+
+// Allocate the next nonce for a sender
+// Allocations are shared between all processes using the transaction database, and nonces which were never submitted are reused
+func (m *Manager) AllocateNonce(ctx context.Context, from common.Address) (uint64, error) {
+	nonce, _, err := m.reserveNonce(ctx, from, math.MaxUint64)
+	return nonce, err
+}
+
+// Start tracking a submitted transaction
+// Transactions which are already tracked keep their record, including any replacements
+func (m *Manager) Track(ctx context.Context, hash common.Hash, task string) (*PendingTransaction, error) {
+	return m.track(ctx, hash, nil, task)
+}
+
+// Start tracking a submitted transaction if it was sent by an address
+// Returns nil if it was sent by another address, whose transactions can't be replaced
+func (m *Manager) TrackFrom(ctx context.Context, hash common.Hash, sender common.Address, task string) (*PendingTransaction, error) {
+	return m.track(ctx, hash, &sender, task)
+}
+
+// Start tracking a submitted transaction, optionally only if it was sent by an address
+func (m *Manager) track(ctx context.Context, hash common.Hash, sender *common.Address, task string) (*PendingTransaction, error) {
+
+	// Get the TX & its sender
+	tx, err := m.getTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get sender of transaction %s: %w", hash.Hex(), err)
+	}
+	if sender != nil && from != *sender {
+		return nil, nil
+	}
+	return m.put(ctx, tx, from, task)
+
+}
+
+// Submit a transaction signed at an allocated nonce and start tracking it
+// If it can't be submitted, the nonce's reservation is released so it's reused rather than left as a gap
+func (m *Manager) SubmitTransaction(ctx context.Context, tx *types.Transaction) error {
+
+	// Submit the TX
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("Could not get sender of transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	if err := m.ec.SendTransaction(ctx, tx); err != nil {
+		if releaseErr := m.ReleaseNonce(from, tx.Nonce()); releaseErr != nil {
+			return fmt.Errorf("%w; additionally, could not release its nonce: %s", err, releaseErr.Error())
+		}
+		return err
+	}
+
+	// Track it; it was submitted, so it isn't failed if this doesn't succeed, and is tracked again when it's waited on
+	_, _ = m.put(ctx, tx, from, "")
+	return nil
+
+}
+
+// Release the reservation of a nonce whose transaction wasn't submitted
+func (m *Manager) ReleaseNonce(from common.Address, nonce uint64) error {
+	return m.store.ReleaseNonce(from, nonce)
+}
+
+// Save a submitted transaction as pending
+func (m *Manager) put(ctx context.Context, tx *types.Transaction, from common.Address, task string) (*PendingTransaction, error) {
+
+	// Get the current block
+	hash := tx.Hash()
+	block, err := m.ec.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get latest block number: %w", err)
+	}
+
+	// Create pending transaction
+	pending := &PendingTransaction{
+		From:              from,
+		Nonce:             tx.Nonce(),
+		ChainID:           tx.ChainId(),
+		To:                tx.To(),
+		Value:             tx.Value(),
+		Data:              tx.Data(),
+		GasLimit:          tx.Gas(),
+		MaxFeeWei:         tx.GasFeeCap(),
+		MaxPriorityFeeWei: tx.GasTipCap(),
+		InitialMaxFeeWei:  tx.GasFeeCap(),
+		Task:              task,
+		Hashes:            []common.Hash{hash},
+		SubmittedBlock:    block,
+		SubmittedTime:     time.Now(),
+	}
+
+	// A transaction which reuses a pending nonce replaces it, e.g. with a nonce override
+	existing, err := m.store.Get(from, tx.Nonce())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.HasHash(hash) {
+		if existing.Task != "" || task == "" {
+			return existing, nil
+		}
+		existing.Task = task
+		return existing, m.store.Put(*existing)
+	}
+	if existing != nil {
+		pending.Hashes = append(existing.Hashes, hash)
+		pending.InitialMaxFeeWei = existing.InitialMaxFeeWei
+	}
+
+	// Save & return
+	if err := m.store.Put(*pending); err != nil {
+		return nil, err
+	}
+	return pending, nil
+
+}
+
+// Wait for a pending transaction to be mined, replacing it with higher fees while it's stuck
+// Errors checking or replacing the transaction are logged and retried until it's mined or can no longer be
+// If ctx is cancelled or the max fee is reached first, the transaction remains pending and can be resumed with Process
+func (m *Manager) Wait(ctx context.Context, tx *PendingTransaction, opts *bind.TransactOpts, logger log.ColorLogger) (*types.Receipt, error) {
+	for {
+		receipt, err := m.update(ctx, tx, opts, logger)
+		if receipt != nil {
+			return receipt, err
+		}
+		if err == nil && tx.MaxFeeReached {
+			return nil, fmt.Errorf("Transaction %s has reached the max fee: %w", tx.GetHash().Hex(), ErrLeftPending)
+		}
+		if err != nil {
+			var dropped droppedError
+			if errors.As(err, &dropped) || ctx.Err() != nil {
+				return nil, err
+			}
+			logger.Printlnf("Error updating pending transaction %s, retrying: %s", tx.GetHash().Hex(), err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+// Check all of a sender's pending transactions once, replacing stuck transactions with higher fees
+func (m *Manager) Process(ctx context.Context, opts *bind.TransactOpts, logger log.ColorLogger) error {
+
+	// Get pending transactions
+	txs, err := m.store.GetAll(opts.From)
+	if err != nil {
+		return err
+	}
+
+	// Update each transaction
+	for _, tx := range txs {
+		receipt, err := m.update(ctx, &tx, opts, logger)
+		if err != nil {
+			logger.Printlnf("Error updating pending transaction with nonce %d: %s", tx.Nonce, err.Error())
+		} else if receipt != nil {
+			logger.Printlnf("Transaction %s with nonce %d was mined in block %s.", receipt.TxHash.Hex(), tx.Nonce, receipt.BlockNumber.String())
+		}
+	}
+
+	// Fill any nonce gap below the remaining transactions
+	txs, err = m.store.GetAll(opts.From)
+	if err != nil || len(txs) == 0 {
+		return err
+	}
+	return m.fillNonceGap(ctx, txs, opts, logger)
+
+}
+
+// Replace a pending transaction with a zero-value transfer to its sender, at higher fees
+// The max fee limit is not applied, so the cancellation can always replace the transaction
+func (m *Manager) Cancel(ctx context.Context, hash common.Hash, opts *bind.TransactOpts) (common.Hash, error) {
+
+	// Find the pending transaction
+	txs, err := m.store.GetAll(opts.From)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var tx *PendingTransaction
+	for ti := range txs {
+		if txs[ti].HasHash(hash) {
+			tx = &txs[ti]
+			break
+		}
+	}
+	if tx == nil {
+		return common.Hash{}, fmt.Errorf("Transaction %s is not pending", hash.Hex())
+	}
+
+	// Check the transaction hasn't been mined
+	nonce, err := m.ec.NonceAt(ctx, tx.From, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not get latest nonce: %w", err)
+	}
+	if nonce > tx.Nonce {
+		return common.Hash{}, fmt.Errorf("Transaction %s has already been mined", hash.Hex())
+	}
+
+	// Replace the transaction
+	block, err := m.ec.BlockNumber(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not get latest block number: %w", err)
+	}
+	tx.To = &tx.From
+	tx.Value = big.NewInt(0)
+	tx.Data = nil
+	tx.GasLimit = CancelGasLimit
+	if !tx.IsCancelled() {
+		tx.CancelIndex = len(tx.Hashes)
+	}
+	maxFee := bumpFee(tx.MaxFeeWei, m.bumpPercent)
+	maxPriorityFee := bumpFee(tx.MaxPriorityFeeWei, m.bumpPercent)
+	return m.replace(ctx, tx, opts, maxFee, maxPriorityFee, block)
+
+}
+
+// Submit a zero-value transfer to the sender at the lowest unused nonce below its pending transactions
+// Transactions queued behind a missing nonce, e.g. from a failed submission, would otherwise never be mined
+// Node account transactions are tracked as they're submitted, so gaps below transactions submitted by any process are filled
+func (m *Manager) fillNonceGap(ctx context.Context, txs []PendingTransaction, opts *bind.TransactOpts, logger log.ColorLogger) error {
+
+	// Reserve the missing nonce
+	nonce, ok, err := m.reserveNonce(ctx, opts.From, txs[len(txs)-1].Nonce)
+	if err != nil || !ok {
+		return err
+	}
+	block, err := m.ec.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("Could not get latest block number: %w", err)
+	}
+
+	// Submit at the fees of the first transaction waiting on the gap
+	var next PendingTransaction
+	for _, tx := range txs {
+		if tx.Nonce > nonce {
+			next = tx
+			break
+		}
+	}
+	gap := &PendingTransaction{
+		From:             opts.From,
+		Nonce:            nonce,
+		ChainID:          next.ChainID,
+		To:               &opts.From,
+		Value:            big.NewInt(0),
+		GasLimit:         CancelGasLimit,
+		InitialMaxFeeWei: next.MaxFeeWei,
+		Task:             "fillNonceGap",
+	}
+	hash, err := m.replace(ctx, gap, opts, next.MaxFeeWei, next.MaxPriorityFeeWei, block)
+	if err != nil {
+		return err
+	}
+	logger.Printlnf("Nonce %d is missing below pending transaction %s, filled it with transaction %s.", nonce, next.GetHash().Hex(), hash.Hex())
+	return nil
+
+}
+
+// Reserve the lowest unused nonce below a limit for a sender
+func (m *Manager) reserveNonce(ctx context.Context, from common.Address, limit uint64) (uint64, bool, error) {
+	minedNonce, err := m.ec.NonceAt(ctx, from, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("Could not get latest nonce: %w", err)
+	}
+	pendingNonce, err := m.ec.PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, false, fmt.Errorf("Could not get pending nonce: %w", err)
+	}
+	return m.store.ReserveNonce(from, minedNonce, pendingNonce, limit, NonceReservationTimeout)
+}
+
+// Check a pending transaction, replacing it with higher fees if it's stuck
+// Returns the receipt once one of its submissions has been mined
+func (m *Manager) update(ctx context.Context, tx *PendingTransaction, opts *bind.TransactOpts, logger log.ColorLogger) (*types.Receipt, error) {
+
+	// Reload the transaction in case another process replaced it
+	stored, err := m.store.Get(tx.From, tx.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		*tx = *stored
+	}
+
+	// Get the latest nonce before looking for receipts, so a submission mined in between isn't missed
+	nonce, err := m.ec.NonceAt(ctx, tx.From, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get latest nonce: %w", err)
+	}
+
+	// Check for a mined submission
+	receipt, cancelled, err := m.getReceipt(ctx, *tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil {
+		if err := m.store.Delete(tx.From, tx.Nonce); err != nil {
+			return nil, err
+		}
+		if cancelled {
+			return receipt, fmt.Errorf("Transaction was cancelled by %s", receipt.TxHash.Hex())
+		}
+		if receipt.Status == types.ReceiptStatusFailed {
+			return receipt, errors.New("Transaction failed with status 0")
+		}
+		return receipt, nil
+	}
+	if nonce > tx.Nonce {
+		if err := m.store.Delete(tx.From, tx.Nonce); err != nil {
+			return nil, err
+		}
+		return nil, droppedError(fmt.Sprintf("Nonce %d of transaction %s was used by another transaction", tx.Nonce, tx.GetHash().Hex()))
+	}
+	if stored == nil {
+		return nil, droppedError(fmt.Sprintf("Transaction %s is no longer being tracked", tx.GetHash().Hex()))
+	}
+
+	// Replace the transaction if it's stuck
+	if tx.MaxFeeReached {
+		return nil, nil
+	}
+	block, err := m.ec.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get latest block number: %w", err)
+	}
+	if block < tx.SubmittedBlock+m.bumpBlocks {
+		return nil, nil
+	}
+	maxFee, maxPriorityFee, ok := m.getReplacementFees(*tx)
+	if !ok {
+		tx.MaxFeeReached = true
+		logger.Printlnf("Transaction %s has not been mined after %d blocks, but its fees cannot be raised further (max fee %.6f Gwei).", tx.GetHash().Hex(), block-tx.SubmittedBlock, eth.WeiToGwei(tx.MaxFeeWei))
+		return nil, m.store.Put(*tx)
+	}
+	stuckHash := tx.GetHash()
+	hash, err := m.replace(ctx, tx, opts, maxFee, maxPriorityFee, block)
+	if err != nil {
+		return nil, err
+	}
+	logger.Printlnf("Transaction %s has not been mined after %d blocks, replaced it with %s at a max fee of %.6f Gwei.", stuckHash.Hex(), m.bumpBlocks, hash.Hex(), eth.WeiToGwei(maxFee))
+	return nil, nil
+
+}
+
+// Sign & submit a replacement for a pending transaction at the same nonce, and save it
+func (m *Manager) replace(ctx context.Context, tx *PendingTransaction, opts *bind.TransactOpts, maxFee *big.Int, maxPriorityFee *big.Int, block uint64) (common.Hash, error) {
+
+	// Sign replacement; the nonce is set on the transactor so it isn't reallocated
+	nonce := opts.Nonce
+	opts.Nonce = new(big.Int).SetUint64(tx.Nonce)
+	signedTx, err := opts.Signer(tx.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainID,
+		Nonce:     tx.Nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       tx.GasLimit,
+		To:        tx.To,
+		Value:     tx.Value,
+		Data:      tx.Data,
+	}))
+	opts.Nonce = nonce
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not sign replacement transaction: %w", err)
+	}
+	if opts.NoSend {
+		return signedTx.Hash(), nil
+	}
+
+	// Submit replacement
+	if err := m.ec.SendTransaction(ctx, signedTx); err != nil {
+		return common.Hash{}, fmt.Errorf("Could not submit replacement transaction: %w", err)
+	}
+
+	// Save & return
+	loadedHashes := len(tx.Hashes)
+	tx.MaxFeeWei = maxFee
+	tx.MaxPriorityFeeWei = maxPriorityFee
+	tx.Hashes = append(tx.Hashes, signedTx.Hash())
+	tx.SubmittedBlock = block
+	tx.SubmittedTime = time.Now()
+	if err := m.store.PutReplacement(*tx, loadedHashes); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+
+}
+
+// Get the fees for a pending transaction's replacement, limited to the max fee
+// Returns false if the fees can't be raised enough for execution clients to accept the replacement
+func (m *Manager) getReplacementFees(tx PendingTransaction) (*big.Int, *big.Int, bool) {
+	maxFeeLimit := m.maxFeeWei
+	if maxFeeLimit == nil {
+		maxFeeLimit = new(big.Int).Mul(tx.InitialMaxFeeWei, big.NewInt(DefaultMaxFeeMultiplier))
+	}
+	maxFee := bumpFee(tx.MaxFeeWei, m.bumpPercent)
+	if maxFee.Cmp(maxFeeLimit) > 0 {
+		maxFee = maxFeeLimit
+	}
+	maxPriorityFee := bumpFee(tx.MaxPriorityFeeWei, m.bumpPercent)
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxPriorityFee = maxFee
+	}
+	if maxFee.Cmp(bumpFee(tx.MaxFeeWei, MinBumpPercent)) < 0 || maxPriorityFee.Cmp(bumpFee(tx.MaxPriorityFeeWei, MinBumpPercent)) < 0 {
+		return nil, nil, false
+	}
+	return maxFee, maxPriorityFee, true
+}
+
+// Get the receipt of a pending transaction's mined submission, and whether it was a cancellation
+// Returns nil if no submission has been mined
+func (m *Manager) getReceipt(ctx context.Context, tx PendingTransaction) (*types.Receipt, bool, error) {
+	for hi, hash := range tx.Hashes {
+		receipt, err := m.ec.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, tx.IsCancelled() && hi >= tx.CancelIndex, nil
+		}
+		if !isNotFound(err) {
+			return nil, false, fmt.Errorf("Could not get receipt for transaction %s: %w", hash.Hex(), err)
+		}
+	}
+	return nil, false, nil
+}
+
+// Get a submitted transaction, retrying while it hasn't propagated to the execution client
+func (m *Manager) getTransaction(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	for i := 0; ; i++ {
+		tx, _, err := m.ec.TransactionByHash(ctx, hash)
+		if err == nil {
+			return tx, nil
+		}
+		if !isNotFound(err) {
+			return nil, fmt.Errorf("Could not get transaction %s: %w", hash.Hex(), err)
+		}
+		if i == TransactionLookupAttempts-1 {
+			return nil, fmt.Errorf("Transaction not found after %d seconds.", TransactionLookupAttempts)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Error for a pending transaction which can no longer be mined
+type droppedError string
+
+func (e droppedError) Error() string {
+	return string(e)
+}
+
+// Raise a fee by a percentage
+func bumpFee(fee *big.Int, percent float64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(10000+int64(math.Round(percent*100))))
+	return bumped.Div(bumped, big.NewInt(10000))
+}
+
+// Check whether an execution client error is a not found error
+// The eth client proxy flattens errors into strings
+func isNotFound(err error) bool {
+	return strings.HasSuffix(err.Error(), ethereum.NotFound.Error())
+}
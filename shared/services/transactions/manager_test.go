@@ -0,0 +1,440 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Execution client holding submitted transactions in memory
+type fakeClient struct {
+	txs        map[common.Hash]*types.Transaction
+	receipts   map[common.Hash]*types.Receipt
+	nonce      uint64
+	pending    uint64
+	block      uint64
+	onBlockNum func() error
+	sendErr    error
+}
+
+func (f *fakeClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if tx, ok := f.txs[hash]; ok {
+		return tx, true, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+func (f *fakeClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if receipt, ok := f.receipts[hash]; ok {
+		return receipt, nil
+	}
+	return nil, ethereum.NotFound
+}
+func (f *fakeClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.txs[tx.Hash()] = tx
+	return nil
+}
+func (f *fakeClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return f.nonce, nil
+}
+func (f *fakeClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if f.pending > f.nonce {
+		return f.pending, nil
+	}
+	return f.nonce, nil
+}
+func (f *fakeClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if f.onBlockNum != nil {
+		if err := f.onBlockNum(); err != nil {
+			return 0, err
+		}
+	}
+	return f.block, nil
+}
+
+// Mine a submitted transaction
+func (f *fakeClient) mine(hash common.Hash) {
+	f.receipts[hash] = &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, BlockNumber: new(big.Int).SetUint64(f.block)}
+	f.nonce++
+}
+
+func TestManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a transactor & submit a transaction
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, block: 100}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 50000, To: &to, Data: []byte{1, 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec.SendTransaction(context.Background(), tx)
+
+	// Track it
+	m, err := NewManager(filepath.Join(dir, "pending-transactions"), config.Transactions{BumpBlocks: 5, BumpPercent: 50}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := log.NewColorLogger(0)
+	pending, err := m.Track(context.Background(), tx.Hash(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.From != opts.From || pending.Task != "test" {
+		t.Errorf("unexpected pending transaction %+v", pending)
+	}
+
+	// It isn't replaced until the bump interval has passed
+	if receipt, err := m.update(context.Background(), pending, opts, logger); receipt != nil || err != nil {
+		t.Fatalf("expected no receipt, got %v, %v", receipt, err)
+	}
+	if len(pending.Hashes) != 1 {
+		t.Fatalf("expected no replacement, got %d submissions", len(pending.Hashes))
+	}
+
+	// Stuck transactions are replaced with higher fees, up to the default limit of 3x the initial max fee
+	expectedFees := [][2]int64{{150, 15}, {225, 22}, {300, 33}}
+	for _, fees := range expectedFees {
+		ec.block += 5
+		if _, err := m.update(context.Background(), pending, opts, logger); err != nil {
+			t.Fatal(err)
+		}
+		if pending.MaxFeeWei.Int64() != fees[0] || pending.MaxPriorityFeeWei.Int64() != fees[1] {
+			t.Errorf("expected fees %v, got %s and %s", fees, pending.MaxFeeWei.String(), pending.MaxPriorityFeeWei.String())
+		}
+		replacement := ec.txs[pending.GetHash()]
+		if replacement == nil || replacement.Nonce() != tx.Nonce() || *replacement.To() != to || replacement.Gas() != tx.Gas() {
+			t.Errorf("unexpected replacement %v", replacement)
+		}
+	}
+	ec.block += 5
+	if _, err := m.update(context.Background(), pending, opts, logger); err != nil {
+		t.Fatal(err)
+	}
+	if !pending.MaxFeeReached || len(pending.Hashes) != 4 {
+		t.Errorf("expected the max fee to be reached after 3 replacements, got %d submissions", len(pending.Hashes))
+	}
+
+	// Tracking the original submission again keeps its replacements, and other senders' transactions aren't tracked
+	if tracked, err := m.Track(context.Background(), tx.Hash(), "test"); err != nil || len(tracked.Hashes) != 4 {
+		t.Errorf("expected the tracked transaction to keep its replacements, got %v, %v", tracked, err)
+	}
+	if tracked, err := m.TrackFrom(context.Background(), tx.Hash(), to, "test"); err != nil || tracked != nil {
+		t.Errorf("expected a transaction from another sender not to be tracked, got %v, %v", tracked, err)
+	}
+
+	// Waiting stops once the max fee is reached, leaving the transaction pending
+	if _, err := m.Wait(context.Background(), pending, opts, logger); !errors.Is(err, ErrLeftPending) {
+		t.Errorf("expected the transaction to be left pending, got %v", err)
+	}
+
+	// An earlier submission being mined completes the transaction
+	ec.mine(pending.Hashes[1])
+	receipt, err := m.Wait(context.Background(), pending, opts, logger)
+	if err != nil || receipt.TxHash != pending.Hashes[1] {
+		t.Fatalf("expected receipt for %s, got %v, %v", pending.Hashes[1].Hex(), receipt, err)
+	}
+	if txs, err := m.GetPendingTransactions(opts.From); err != nil || len(txs) != 0 {
+		t.Errorf("expected no pending transactions, got %v, %v", txs, err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a pending transaction
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, nonce: 7}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 7, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 50000, To: &to, Value: big.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec.SendTransaction(context.Background(), tx)
+	m, err := NewManager(filepath.Join(dir, "pending-transactions"), config.Transactions{}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := m.Track(context.Background(), tx.Hash(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cancel it with a zero-value transfer to the sender
+	cancelHash, err := m.Cancel(context.Background(), tx.Hash(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelTx := ec.txs[cancelHash]
+	if cancelTx == nil || cancelTx.Nonce() != 7 || *cancelTx.To() != opts.From || cancelTx.Value().Sign() != 0 || cancelTx.Gas() != CancelGasLimit || cancelTx.GasFeeCap().Int64() != 120 {
+		t.Errorf("unexpected cancellation %v", cancelTx)
+	}
+
+	// Waiting for the transaction reports the cancellation
+	ec.mine(cancelHash)
+	if _, err := m.Wait(context.Background(), pending, opts, log.NewColorLogger(0)); err == nil {
+		t.Error("expected an error for a cancelled transaction")
+	}
+
+	// Cancelled transactions are no longer pending
+	if _, err := m.Cancel(context.Background(), tx.Hash(), opts); err == nil {
+		t.Error("expected an error cancelling a mined transaction")
+	}
+}
+
+func TestAllocateNonce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "pending-transactions")
+	from := common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	// Managers sharing a database allocate distinct nonces above the mempool's
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, nonce: 3, pending: 5}
+	m1, err := NewManager(path, config.Transactions{}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2, err := NewManager(path, config.Transactions{}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range []*Manager{m1, m2, m1} {
+		nonce, err := m.AllocateNonce(context.Background(), from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nonce != uint64(5+i) {
+			t.Errorf("expected nonce %d, got %d", 5+i, nonce)
+		}
+	}
+
+	// Mined reservations are released, and unsubmitted nonces are reused once their reservations expire
+	ec.nonce, ec.pending = 6, 6
+	if nonce, err := m2.AllocateNonce(context.Background(), from); err != nil || nonce != 8 {
+		t.Errorf("expected nonce 8, got %d, %v", nonce, err)
+	}
+	if nonce, ok, err := m2.store.ReserveNonce(from, 6, 6, 100, 0); err != nil || !ok || nonce != 6 {
+		t.Errorf("expected expired nonce 6 to be reused, got %d, %v, %v", nonce, ok, err)
+	}
+}
+
+func TestSubmitTransaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a transactor & manager
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, block: 100}
+	m, err := NewManager(filepath.Join(dir, "pending-transactions"), config.Transactions{}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	submit := func() (*types.Transaction, error) {
+		nonce, err := m.AllocateNonce(context.Background(), opts.From)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: nonce, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to}))
+		if err != nil {
+			return nil, err
+		}
+		return tx, m.SubmitTransaction(context.Background(), tx)
+	}
+
+	// Nonces of transactions which fail to submit are released and reused
+	ec.sendErr = errors.New("submission failed")
+	if _, err := submit(); !errors.Is(err, ec.sendErr) {
+		t.Fatalf("expected the submission error, got %v", err)
+	}
+	ec.sendErr = nil
+	tx, err := submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 0 {
+		t.Errorf("expected the released nonce 0 to be reused, got %d", tx.Nonce())
+	}
+
+	// Submitted transactions are tracked, and given a task once one tracks them
+	txs, err := m.store.GetAll(opts.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || !txs[0].HasHash(tx.Hash()) {
+		t.Fatalf("expected the submitted transaction to be tracked, got %+v", txs)
+	}
+	if pending, err := m.Track(context.Background(), tx.Hash(), "test"); err != nil || pending.Task != "test" {
+		t.Errorf("expected the tracked transaction to be given a task, got %+v, %v", pending, err)
+	}
+}
+
+func TestFillNonceGap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Track a transaction queued behind a missing nonce
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, nonce: 2}
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 50000, To: &opts.From}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec.SendTransaction(context.Background(), tx)
+	m, err := NewManager(filepath.Join(dir, "pending-transactions"), config.Transactions{}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Track(context.Background(), tx.Hash(), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Processing fills the gap at the transaction's fees
+	if err := m.Process(context.Background(), opts, log.NewColorLogger(0)); err != nil {
+		t.Fatal(err)
+	}
+	txs, err := m.GetPendingTransactions(opts.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || txs[0].Nonce != 2 || txs[0].MaxFeeWei.Int64() != 100 {
+		t.Fatalf("expected the gap at nonce 2 to be filled, got %+v", txs)
+	}
+	if gapTx := ec.txs[txs[0].GetHash()]; gapTx == nil || gapTx.Nonce() != 2 || gapTx.Value().Sign() != 0 {
+		t.Errorf("unexpected gap transaction %v", gapTx)
+	}
+
+	// Tracked nonces aren't allocated again
+	ec.pending = 2
+	if nonce, err := m.AllocateNonce(context.Background(), opts.From); err != nil || nonce != 4 {
+		t.Errorf("expected nonce 4, got %d, %v", nonce, err)
+	}
+}
+
+func TestConcurrentReplacements(t *testing.T) {
+	dir, err := ioutil.TempDir("", "transactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Track a transaction
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec := &fakeClient{txs: map[common.Hash]*types.Transaction{}, receipts: map[common.Hash]*types.Receipt{}, block: 100}
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 50000, To: &opts.From}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec.SendTransaction(context.Background(), tx)
+	m, err := NewManager(filepath.Join(dir, "pending-transactions"), config.Transactions{BumpBlocks: 5}, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.pollInterval = time.Millisecond
+	pending, err := m.Track(context.Background(), tx.Hash(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replacements made from a stale copy of the transaction keep the other submissions
+	stale := *pending
+	stale.Hashes = append([]common.Hash{}, pending.Hashes...)
+	ec.block += 5
+	if _, err := m.update(context.Background(), pending, opts, log.NewColorLogger(0)); err != nil {
+		t.Fatal(err)
+	}
+	staleHash, err := m.replace(context.Background(), &stale, opts, big.NewInt(1000), big.NewInt(20), ec.block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := m.store.Get(opts.From, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Hashes) != 3 || !stored.HasHash(pending.GetHash()) || !stored.HasHash(staleHash) || stored.MaxFeeWei.Int64() != 1000 {
+		t.Fatalf("expected both replacements to be stored, got %+v", stored)
+	}
+
+	// Waiting keeps polling through execution client errors
+	calls := 0
+	ec.onBlockNum = func() error {
+		calls++
+		if calls <= 3 {
+			return errors.New("connection refused")
+		}
+		ec.mine(staleHash)
+		return nil
+	}
+	if receipt, err := m.Wait(context.Background(), pending, opts, log.NewColorLogger(0)); err != nil || receipt == nil || receipt.TxHash != staleHash {
+		t.Errorf("expected receipt for %s, got %v, %v", staleHash.Hex(), receipt, err)
+	}
+}
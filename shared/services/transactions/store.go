@@ -0,0 +1,334 @@
+package transactions
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	dbutils "github.com/rocket-pool/smartnode/shared/utils/db"
+)
+
+// Database keys
+var (
+	pendingPrefix = []byte("pending-")
+	noncePrefix   = []byte("nonce-")
+)
+
+// A submitted transaction which hasn't been mined yet
+// Replacements share the original transaction's nonce, so each sender & nonce has a single record
+type PendingTransaction struct {
+	From              common.Address  `json:"from"`
+	Nonce             uint64          `json:"nonce"`
+	ChainID           *big.Int        `json:"chainId"`
+	To                *common.Address `json:"to"`
+	Value             *big.Int        `json:"value"`
+	Data              hexutil.Bytes   `json:"data"`
+	GasLimit          uint64          `json:"gasLimit"`
+	MaxFeeWei         *big.Int        `json:"maxFee"`
+	MaxPriorityFeeWei *big.Int        `json:"maxPriorityFee"`
+	InitialMaxFeeWei  *big.Int        `json:"initialMaxFee"`
+	Task              string          `json:"task"`
+	Hashes            []common.Hash   `json:"hashes"`
+	SubmittedBlock    uint64          `json:"submittedBlock"`
+	SubmittedTime     time.Time       `json:"submittedTime"`
+	CancelIndex       int             `json:"cancelIndex,omitempty"`
+	MaxFeeReached     bool            `json:"maxFeeReached"`
+}
+
+// Get the hash of the latest submission
+func (tx PendingTransaction) GetHash() common.Hash {
+	return tx.Hashes[len(tx.Hashes)-1]
+}
+
+// Check whether the transaction has been cancelled
+// Submissions from CancelIndex onwards are cancellations; the first submission never is
+func (tx PendingTransaction) IsCancelled() bool {
+	return tx.CancelIndex > 0
+}
+
+// Check whether a hash belongs to the transaction or one of its replacements
+func (tx PendingTransaction) HasHash(hash common.Hash) bool {
+	for _, txHash := range tx.Hashes {
+		if txHash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Pending transactions, stored in a LevelDB database
+// The database is only held open while it's used, so the daemons and API can share it
+type Store struct {
+	path string
+}
+
+// Create new pending transaction store
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Add or update a pending transaction
+func (s *Store) Put(tx PendingTransaction) error {
+
+	// Open database
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Write transaction
+	value, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("Could not encode pending transaction: %w", err)
+	}
+	if err := db.Put(getPendingKey(tx.From, tx.Nonce), value, nil); err != nil {
+		return fmt.Errorf("Could not write pending transaction: %w", err)
+	}
+	return nil
+
+}
+
+// Save a replacement of a pending transaction which had a number of submissions when it was loaded
+// The database lock is held while the stored transaction is checked, so replacements from different processes are serialized
+// If another process replaced the transaction since, the new submissions are added to its record so either can be found once mined
+// Nothing is saved if the transaction was mined and removed in the meantime
+func (s *Store) PutReplacement(tx PendingTransaction, loadedHashes int) error {
+
+	// Open database
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Check the stored transaction
+	key := getPendingKey(tx.From, tx.Nonce)
+	storedValue, err := db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		if loadedHashes > 0 {
+			return nil
+		}
+	} else if err != nil {
+		return fmt.Errorf("Could not read pending transaction: %w", err)
+	} else {
+		var stored PendingTransaction
+		if err := json.Unmarshal(storedValue, &stored); err != nil {
+			return fmt.Errorf("Could not decode pending transaction: %w", err)
+		}
+		if len(stored.Hashes) != loadedHashes {
+			for _, hash := range tx.Hashes[loadedHashes:] {
+				if !stored.HasHash(hash) {
+					stored.Hashes = append(stored.Hashes, hash)
+				}
+			}
+			if stored.MaxFeeWei.Cmp(tx.MaxFeeWei) < 0 {
+				stored.MaxFeeWei = tx.MaxFeeWei
+				stored.MaxPriorityFeeWei = tx.MaxPriorityFeeWei
+			}
+			tx = stored
+		}
+	}
+
+	// Write transaction
+	value, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("Could not encode pending transaction: %w", err)
+	}
+	if err := db.Put(key, value, nil); err != nil {
+		return fmt.Errorf("Could not write pending transaction: %w", err)
+	}
+	return nil
+
+}
+
+// Get the pending transaction with a sender & nonce
+// Returns nil if there is no pending transaction
+func (s *Store) Get(from common.Address, nonce uint64) (*PendingTransaction, error) {
+
+	// Open database
+	db, err := s.open(true)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// Get transaction
+	value, err := db.Get(getPendingKey(from, nonce), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read pending transaction: %w", err)
+	}
+	var tx PendingTransaction
+	if err := json.Unmarshal(value, &tx); err != nil {
+		return nil, fmt.Errorf("Could not decode pending transaction: %w", err)
+	}
+	return &tx, nil
+
+}
+
+// Get a sender's pending transactions in nonce order
+func (s *Store) GetAll(from common.Address) ([]PendingTransaction, error) {
+
+	// Open database
+	db, err := s.open(true)
+	if err != nil || db == nil {
+		return []PendingTransaction{}, err
+	}
+	defer db.Close()
+
+	// Iterate over the sender's transactions
+	txs := []PendingTransaction{}
+	iter := db.NewIterator(util.BytesPrefix(getPendingKey(from, 0)[:len(pendingPrefix)+common.AddressLength]), nil)
+	defer iter.Release()
+	for iter.Next() {
+		var tx PendingTransaction
+		if err := json.Unmarshal(iter.Value(), &tx); err != nil {
+			return nil, fmt.Errorf("Could not decode pending transaction: %w", err)
+		}
+		txs = append(txs, tx)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("Could not read pending transactions: %w", err)
+	}
+	return txs, nil
+
+}
+
+// Remove the pending transaction with a sender & nonce
+func (s *Store) Delete(from common.Address, nonce uint64) error {
+
+	// Open database
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Delete transaction
+	if err := db.Delete(getPendingKey(from, nonce), nil); err != nil {
+		return fmt.Errorf("Could not delete pending transaction: %w", err)
+	}
+	return nil
+
+}
+
+// Release a sender's nonce reservation
+func (s *Store) ReleaseNonce(from common.Address, nonce uint64) error {
+
+	// Open database
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Delete reservation
+	if err := db.Delete(getNonceKey(from, nonce), nil); err != nil {
+		return fmt.Errorf("Could not release nonce reservation: %w", err)
+	}
+	return nil
+
+}
+
+// Reserve the lowest nonce below a limit which isn't mined, in the mempool, tracked or reserved by another process
+// Reservations are released once their nonce is mined or they expire, so nonces which were never submitted are reused
+// Returns false if every nonce below the limit is in use
+func (s *Store) ReserveNonce(from common.Address, minedNonce uint64, pendingNonce uint64, limit uint64, timeout time.Duration) (uint64, bool, error) {
+
+	// Open database; the database lock is held until the reservation is written
+	db, err := s.open(false)
+	if err != nil {
+		return 0, false, err
+	}
+	defer db.Close()
+
+	// Get current reservations, releasing mined & expired nonces
+	reserved := map[uint64]bool{}
+	batch := new(leveldb.Batch)
+	iter := db.NewIterator(util.BytesPrefix(getNonceKey(from, 0)[:len(noncePrefix)+common.AddressLength]), nil)
+	for iter.Next() {
+		key := iter.Key()
+		nonce := binary.BigEndian.Uint64(key[len(key)-8:])
+		reservedTime := time.Unix(int64(binary.BigEndian.Uint64(iter.Value())), 0)
+		if nonce < minedNonce || time.Since(reservedTime) > timeout {
+			batch.Delete(append([]byte{}, key...))
+			continue
+		}
+		reserved[nonce] = true
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, false, fmt.Errorf("Could not read nonce reservations: %w", err)
+	}
+
+	// Tracked transactions hold their nonces until they're mined, even if they've dropped out of the mempool
+	iter = db.NewIterator(util.BytesPrefix(getPendingKey(from, 0)[:len(pendingPrefix)+common.AddressLength]), nil)
+	for iter.Next() {
+		key := iter.Key()
+		if nonce := binary.BigEndian.Uint64(key[len(key)-8:]); nonce >= minedNonce {
+			reserved[nonce] = true
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, false, fmt.Errorf("Could not read pending transactions: %w", err)
+	}
+
+	// Get the lowest free nonce
+	nonce := minedNonce
+	for nonce < pendingNonce || reserved[nonce] {
+		nonce++
+	}
+	if nonce >= limit {
+		return 0, false, db.Write(batch, nil)
+	}
+
+	// Reserve it
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(time.Now().Unix()))
+	batch.Put(getNonceKey(from, nonce), value)
+	if err := db.Write(batch, nil); err != nil {
+		return 0, false, fmt.Errorf("Could not write nonce reservation: %w", err)
+	}
+	return nonce, true, nil
+
+}
+
+// Open the database, retrying while it's locked by another process
+// Returns nil when opening read-only if the database doesn't exist yet
+func (s *Store) open(readOnly bool) (*leveldb.DB, error) {
+	db, err := dbutils.OpenLevelDB(s.path, readOnly)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open pending transactions at %s: %w", s.path, err)
+	}
+	return db, nil
+}
+
+// Get the key for a sender's transaction at a nonce
+// Keys sort by sender, then nonce
+func getPendingKey(from common.Address, nonce uint64) []byte {
+	key := make([]byte, len(pendingPrefix)+common.AddressLength+8)
+	copy(key, pendingPrefix)
+	copy(key[len(pendingPrefix):], from.Bytes())
+	binary.BigEndian.PutUint64(key[len(pendingPrefix)+common.AddressLength:], nonce)
+	return key
+}
+
+// Get the key for a sender's nonce reservation
+func getNonceKey(from common.Address, nonce uint64) []byte {
+	key := make([]byte, len(noncePrefix)+common.AddressLength+8)
+	copy(key, noncePrefix)
+	copy(key[len(noncePrefix):], from.Bytes())
+	binary.BigEndian.PutUint64(key[len(noncePrefix)+common.AddressLength:], nonce)
+	return key
+}
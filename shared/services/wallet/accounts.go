@@ -0,0 +1,136 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Config
+const DefaultNodeAccount = "default"
+
+// Named node account derived at a non-default index
+type nodeAccount struct {
+	Name  string `json:"name"`
+	Index uint   `json:"index"`
+}
+
+// Get the name of the selected node account
+func (w *Wallet) GetNodeAccountName() string {
+	return w.nodeAccountName
+}
+
+// Get the path of a data file kept separately for each node account, e.g. performance records
+// The default account uses the path unchanged; other accounts have their name appended to the file name
+func (w *Wallet) GetNodeAccountDataPath(path string) string {
+	if w.nodeAccountName == DefaultNodeAccount {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), w.nodeAccountName, ext)
+}
+
+// Get the names of all node accounts in the wallet, starting with the default account
+func (w *Wallet) GetNodeAccountNames() ([]string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Get account names
+	names := []string{DefaultNodeAccount}
+	for _, account := range w.ws.NodeAccounts {
+		names = append(names, account.Name)
+	}
+
+	// Return
+	return names, nil
+
+}
+
+// Add a named node account derived at the index
+func (w *Wallet) AddNodeAccount(name string, index uint) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+
+	// Check named accounts can be used
+	if err := w.checkNodeAccountsSupported(); err != nil {
+		return err
+	}
+
+	// Check the account name & index are not in use
+	if name == "" {
+		return errors.New("Node account name cannot be empty")
+	}
+	if name == DefaultNodeAccount || index == 0 {
+		return fmt.Errorf("Index 0 is reserved for the '%s' node account", DefaultNodeAccount)
+	}
+	for _, account := range w.ws.NodeAccounts {
+		if account.Name == name {
+			return fmt.Errorf("Node account '%s' already exists", name)
+		}
+		if account.Index == index {
+			return fmt.Errorf("Node account '%s' already uses index %d", account.Name, index)
+		}
+	}
+
+	// Add account
+	w.ws.NodeAccounts = append(w.ws.NodeAccounts, nodeAccount{Name: name, Index: index})
+
+	// Return
+	return nil
+
+}
+
+// Get a view of the wallet which uses the named node account
+// Validator keys and keystores are shared with the wallet; named accounts can't be used with an external node signer
+func (w *Wallet) ForNodeAccount(name string) (*Wallet, error) {
+
+	// Default account
+	if name == "" || name == DefaultNodeAccount {
+		return w, nil
+	}
+
+	// Check wallet is initialized
+	if initialized, err := w.GetInitialized(); err != nil {
+		return nil, err
+	} else if !initialized {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Check named accounts can be used
+	if err := w.checkNodeAccountsSupported(); err != nil {
+		return nil, err
+	}
+
+	// Get account
+	for _, account := range w.ws.NodeAccounts {
+		if account.Name != name {
+			continue
+		}
+		aw := *w
+		aw.nodeAccountName = account.Name
+		aw.nodeAccountIndex = account.Index
+		aw.nodeKey = nil
+		aw.nodeKeyPath = ""
+		return &aw, nil
+	}
+
+	// Not found
+	return nil, fmt.Errorf("Node account '%s' does not exist", name)
+
+}
+
+// Check named node accounts can be used
+// They are derived from the wallet seed, so they would silently sign locally instead of with the external node signer
+func (w *Wallet) checkNodeAccountsSupported() error {
+	if w.nodeSigner != nil {
+		return errors.New("Named node accounts can't be used with an external node signer; remove the node signer from the configuration to use them")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+func TestNodeAccounts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add accounts
+	if err := w.AddNodeAccount("second", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddNodeAccount("second", 2); err == nil {
+		t.Error("Expected adding a duplicate account name to fail")
+	}
+	if err := w.AddNodeAccount("third", 1); err == nil {
+		t.Error("Expected adding a duplicate account index to fail")
+	}
+	if err := w.AddNodeAccount(DefaultNodeAccount, 0); err == nil {
+		t.Error("Expected adding the default account to fail")
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check accounts are derived at their own index and survive a reload
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := w.GetNodeAccountNames(); err != nil || len(names) != 2 || names[1] != "second" {
+		t.Fatalf("Unexpected node accounts %v: %v", names, err)
+	}
+	second, err := w.ForNodeAccount("second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondAccount, err := second.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if defaultAccount.Address == secondAccount.Address {
+		t.Error("Expected node accounts to have different addresses")
+	}
+	if secondAccount.URL.Path != "m/44'/60'/0'/0/1" {
+		t.Errorf("Unexpected derivation path %s", secondAccount.URL.Path)
+	}
+	if path := second.GetNodeAccountDataPath("/data/performance.json"); path != "/data/performance-second.json" {
+		t.Errorf("Unexpected data path %s", path)
+	}
+	if _, err := w.ForNodeAccount("missing"); err == nil {
+		t.Error("Expected selecting a missing account to fail")
+	}
+
+	// Named accounts can't be used with an external node signer
+	w.SetNodeSigner(&mockNodeSigner{})
+	if _, err := w.ForNodeAccount("second"); err == nil {
+		t.Error("Expected selecting a named account with an external node signer to fail")
+	}
+	if err := w.AddNodeAccount("third", 3); err == nil {
+		t.Error("Expected adding a named account with an external node signer to fail")
+	}
+	if aw, err := w.ForNodeAccount(DefaultNodeAccount); err != nil || !aw.HasExternalNodeSigner() {
+		t.Errorf("Expected the default account to use the external node signer (%v)", err)
+	}
+}
+
+// Mock external node signer
+type mockNodeSigner struct{}
+
+func (s *mockNodeSigner) GetAddress() common.Address { return common.Address{} }
+func (s *mockNodeSigner) GetUrl() string             { return "" }
+func (s *mockNodeSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return tx, nil
+}
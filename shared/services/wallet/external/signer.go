@@ -0,0 +1,172 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Config
+const (
+	ClefSignMethod    = "account_signTransaction"
+	GenericSignMethod = "eth_signTransaction"
+	SignTimeout       = 5 * time.Minute
+)
+
+// Node account signer backed by an external JSON-RPC signer such as Clef
+// Transactions must be approved by the signer, which may require manual confirmation
+type Signer struct {
+	client  *rpc.Client
+	method  string
+	address common.Address
+	url     string
+}
+
+// Signed transaction response
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Create new external signer
+func NewSigner(url, method string, address common.Address, authToken string) (*Signer, error) {
+
+	// Check sign method
+	method = parseMethod(method)
+	if method != ClefSignMethod && method != GenericSignMethod {
+		return nil, fmt.Errorf("Unsupported external signer method '%s'", method)
+	}
+
+	// Create RPC client
+	client, err := rpc.DialHTTPWithClient(url, &http.Client{Timeout: SignTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("Could not connect to external signer: %w", err)
+	}
+	if authToken != "" {
+		client.SetHeader("Authorization", "Bearer "+authToken)
+	}
+
+	// Return
+	return &Signer{
+		client:  client,
+		method:  method,
+		address: address,
+		url:     url,
+	}, nil
+
+}
+
+// Get the node account address
+func (s *Signer) GetAddress() common.Address {
+	return s.address
+}
+
+// Get the signer URL
+func (s *Signer) GetUrl() string {
+	return s.url
+}
+
+// Sign a transaction with the external signer
+func (s *Signer) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Get request args
+	data := hexutil.Bytes(tx.Data())
+	var to *common.MixedcaseAddress
+	if tx.To() != nil {
+		mixedcaseTo := common.NewMixedcaseAddress(*tx.To())
+		to = &mixedcaseTo
+	}
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		To:      to,
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("Unsupported transaction type %d", tx.Type())
+	}
+
+	// Request signature; args are passed by reference so mixed-case addresses encode as strings
+	// Signers return either the signed transaction object or the raw transaction hex
+	var result json.RawMessage
+	if err := s.client.CallContext(context.Background(), &result, s.method, &args); err != nil {
+		return nil, fmt.Errorf("Could not sign transaction with external signer: %w", err)
+	}
+	var raw hexutil.Bytes
+	var signed signTransactionResult
+	if err := json.Unmarshal(result, &signed); err == nil && len(signed.Raw) > 0 {
+		raw = signed.Raw
+	} else if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("Could not decode external signer response: %w", err)
+	}
+
+	// Decode signed transaction
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("Could not decode signed transaction: %w", err)
+	}
+
+	// Check the signer didn't change the transaction
+	if err := checkSignedTransaction(tx, signedTx, s.address, chainID); err != nil {
+		return nil, err
+	}
+
+	// Return
+	return signedTx, nil
+
+}
+
+// Check a signed transaction matches the requested transaction and sender
+func checkSignedTransaction(tx, signedTx *types.Transaction, address common.Address, chainID *big.Int) error {
+	if tx.Type() != signedTx.Type() ||
+		tx.Nonce() != signedTx.Nonce() ||
+		tx.Gas() != signedTx.Gas() ||
+		tx.Value().Cmp(signedTx.Value()) != 0 ||
+		!bytes.Equal(tx.Data(), signedTx.Data()) ||
+		tx.GasFeeCap().Cmp(signedTx.GasFeeCap()) != 0 ||
+		tx.GasTipCap().Cmp(signedTx.GasTipCap()) != 0 {
+		return errors.New("The external signer returned a different transaction than requested")
+	}
+	if (tx.To() == nil) != (signedTx.To() == nil) || (tx.To() != nil && *tx.To() != *signedTx.To()) {
+		return errors.New("The external signer returned a transaction with a different recipient")
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return fmt.Errorf("Could not recover signed transaction sender: %w", err)
+	}
+	if sender != address {
+		return fmt.Errorf("The external signer signed the transaction with %s instead of %s", sender.Hex(), address.Hex())
+	}
+	return nil
+}
+
+// Parse a sign method name, accepting signer types as aliases
+func parseMethod(method string) string {
+	switch strings.ToLower(method) {
+	case "", "clef":
+		return ClefSignMethod
+	case "generic", "eth":
+		return GenericSignMethod
+	}
+	return method
+}
@@ -0,0 +1,138 @@
+package external
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Local test signer which signs requested transactions with a key
+func newTestSigner(key *ecdsa.PrivateKey, method string, rawOnly bool, valueOffset int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage       `json:"id"`
+			Method string                `json:"method"`
+			Params []apitypes.SendTxArgs `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != method || len(request.Params) != 1 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		args := request.Params[0]
+		chainID := (*big.Int)(args.ChainID)
+		to := args.To.Address()
+		value := new(big.Int).Add((*big.Int)(&args.Value), big.NewInt(valueOffset))
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+			GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+			Gas:       uint64(args.Gas),
+			To:        &to,
+			Value:     value,
+			Data:      *args.Data,
+		})
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		raw, err := signedTx.MarshalBinary()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var result interface{} = map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTx}
+		if rawOnly {
+			result = hexutil.Bytes(raw)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	}))
+}
+
+func newTestTransaction(chainID *big.Int) *types.Transaction {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(50e9),
+		Gas:       100000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+func TestSignTransaction(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(5)
+
+	tests := []struct {
+		name    string
+		method  string
+		rawOnly bool
+	}{
+		{"clef", ClefSignMethod, false},
+		{"generic", GenericSignMethod, true},
+	}
+	for _, test := range tests {
+		server := newTestSigner(key, test.method, test.rawOnly, 0)
+		signer, err := NewSigner(server.URL, test.name, address, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := newTestTransaction(chainID)
+		signedTx, err := signer.SignTransaction(tx, chainID)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if signedTx.Nonce() != tx.Nonce() {
+			t.Errorf("%s: incorrect signed transaction nonce %d", test.name, signedTx.Nonce())
+		}
+		server.Close()
+	}
+}
+
+func TestSignTransactionChecksResponse(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(5)
+
+	// Signer which changes the transaction value
+	server := newTestSigner(key, ClefSignMethod, false, 1)
+	defer server.Close()
+	signer, err := NewSigner(server.URL, "clef", crypto.PubkeyToAddress(key.PublicKey), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.SignTransaction(newTestTransaction(chainID), chainID); err == nil {
+		t.Error("Expected a modified transaction to be rejected")
+	}
+
+	// Signer which signs with a different account
+	server2 := newTestSigner(key, ClefSignMethod, false, 0)
+	defer server2.Close()
+	signer, err = NewSigner(server2.URL, "clef", common.HexToAddress("0x2222222222222222222222222222222222222222"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.SignTransaction(newTestTransaction(chainID), chainID); err == nil {
+		t.Error("Expected a transaction signed by another account to be rejected")
+	}
+}
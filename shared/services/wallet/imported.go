@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const EIP2335Version = 4
+
+// Validator key imported from an external keystore, encrypted with the wallet password
+type importedKey struct {
+	Crypto map[string]interface{}  `json:"crypto"`
+	Path   string                  `json:"path"`
+	Pubkey rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// EIP-2335 validator keystore
+type eip2335Keystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Path    string                 `json:"path"`
+	Pubkey  string                 `json:"pubkey"`
+	Version uint                   `json:"version"`
+}
+
+// Import a validator key from an EIP-2335 keystore and store it in all keystores
+func (w *Wallet) ImportValidatorKey(keystoreBytes []byte, keystorePassword string) (rptypes.ValidatorPubkey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return rptypes.ValidatorPubkey{}, errors.New("Wallet is not initialized")
+	}
+
+	// Decode keystore
+	var ks eip2335Keystore
+	if err := json.Unmarshal(keystoreBytes, &ks); err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode validator keystore: %w", err)
+	}
+	if ks.Version != EIP2335Version {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Unsupported validator keystore version %d", ks.Version)
+	}
+
+	// Decrypt key
+	keyBytes, err := w.encryptor.Decrypt(ks.Crypto, keystorePassword)
+	if err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decrypt validator keystore: %w", err)
+	}
+	if err := initializeBLS(); err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+	key, err := eth2types.BLSPrivateKeyFromBytes(keyBytes)
+	if err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode validator key: %w", err)
+	}
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Check the key matches the keystore pubkey
+	if ks.Pubkey != "" && hexutil.RemovePrefix(ks.Pubkey) != pubkey.Hex() {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Validator keystore pubkey %s does not match its key", ks.Pubkey)
+	}
+
+	// Check the key is not already in the wallet
+	if existing, err := w.GetValidatorKeyByPubkey(pubkey); err == nil && existing != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Validator %s key is already in the wallet", pubkey.Hex())
+	}
+
+	// Encrypt key with the wallet password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	encryptedKey, err := w.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Update keystores
+	for name := range w.keystores {
+		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
+		if err := w.keystores[name].StoreValidatorKey(key, ks.Path); err != nil {
+			return rptypes.ValidatorPubkey{}, fmt.Errorf("Could not store %s validator key: %w", name, err)
+		}
+	}
+
+	// Add imported key
+	w.ws.ImportedKeys = append(w.ws.ImportedKeys, importedKey{
+		Crypto: encryptedKey,
+		Path:   ks.Path,
+		Pubkey: pubkey,
+	})
+	w.importedKeys[pubkey.Hex()] = key
+
+	// Return
+	return pubkey, nil
+
+}
+
+// Get the pubkeys of all imported validator keys
+func (w *Wallet) GetImportedValidatorPubkeys() ([]rptypes.ValidatorPubkey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Return pubkeys
+	pubkeys := make([]rptypes.ValidatorPubkey, len(w.ws.ImportedKeys))
+	for ki, ik := range w.ws.ImportedKeys {
+		pubkeys[ki] = ik.Pubkey
+	}
+	return pubkeys, nil
+
+}
+
+// Get an imported validator key by public key; returns nil if the key was not imported
+func (w *Wallet) getImportedValidatorKey(pubkey rptypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, string, error) {
+
+	// Find imported key
+	var ik *importedKey
+	for ki := range w.ws.ImportedKeys {
+		if bytes.Equal(w.ws.ImportedKeys[ki].Pubkey.Bytes(), pubkey.Bytes()) {
+			ik = &w.ws.ImportedKeys[ki]
+			break
+		}
+	}
+	if ik == nil {
+		return nil, "", nil
+	}
+
+	// Check for cached validator key
+	if key, ok := w.importedKeys[pubkey.Hex()]; ok {
+		return key, ik.Path, nil
+	}
+
+	// Decrypt key
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	keyBytes, err := w.encryptor.Decrypt(ik.Crypto, password)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not decrypt imported validator %s key: %w", pubkey.Hex(), err)
+	}
+	if err := initializeBLS(); err != nil {
+		return nil, "", fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+	key, err := eth2types.BLSPrivateKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not decode imported validator %s key: %w", pubkey.Hex(), err)
+	}
+
+	// Cache validator key & return
+	w.importedKeys[pubkey.Hex()] = key
+	return key, ik.Path, nil
+
+}
+
+// Get all derived and imported validator keys
+func (w *Wallet) getValidatorKeys() ([]keystore.ValidatorKey, error) {
+	keys := make([]keystore.ValidatorKey, 0, int(w.ws.NextAccount)+len(w.ws.ImportedKeys))
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		key, derivationPath, err := w.getValidatorPrivateKey(index)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, keystore.ValidatorKey{Key: key, DerivationPath: derivationPath})
+	}
+	for _, ik := range w.ws.ImportedKeys {
+		key, derivationPath, err := w.getImportedValidatorKey(ik.Pubkey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, keystore.ValidatorKey{Key: key, DerivationPath: derivationPath})
+	}
+	return keys, nil
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+func TestImportValidatorKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create an external keystore
+	if err := initializeBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	crypto, err := eth2ks.New().Encrypt(key.Marshal(), "keystore password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keystoreBytes, err := json.Marshal(map[string]interface{}{
+		"crypto":  crypto,
+		"path":    "m/12381/3600/7/0/0",
+		"version": 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Import key
+	if _, err := w.ImportValidatorKey(keystoreBytes, "wrong password"); err == nil {
+		t.Error("Expected import with the wrong password to fail")
+	}
+	pubkey, err := w.ImportValidatorKey(keystoreBytes, "keystore password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ImportValidatorKey(keystoreBytes, "keystore password"); err == nil {
+		t.Error("Expected duplicate import to fail")
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reload wallet and check the key is loaded
+	w, err = NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loadedKey, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(loadedKey.Marshal()) != string(key.Marshal()) {
+		t.Error("Loaded validator key does not match the imported key")
+	}
+}
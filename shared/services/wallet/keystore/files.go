@@ -0,0 +1,36 @@
+package keystore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Config
+const (
+	BackupsDir = "backups"
+	MaxBackups = 5
+)
+
+// Write a keystore file atomically, backing up the existing file to the keystore backups folder
+func WriteFile(keystorePath, path string, data []byte, mode os.FileMode) error {
+	relPath, err := filepath.Rel(keystorePath, path)
+	if err != nil {
+		return fmt.Errorf("Could not get keystore file path: %w", err)
+	}
+	if err := files.Backup(path, filepath.Join(keystorePath, BackupsDir, relPath), MaxBackups); err != nil {
+		return fmt.Errorf("Could not back up keystore file: %w", err)
+	}
+	return files.WriteFile(path, data, mode)
+}
+
+// Remove all keystore file backups
+// Backups hold keys encrypted with their previous secrets, so they are removed once the keys are re-encrypted
+func RemoveBackups(keystorePath string) error {
+	if err := os.RemoveAll(filepath.Join(keystorePath, BackupsDir)); err != nil {
+		return fmt.Errorf("Could not remove keystore backups: %w", err)
+	}
+	return nil
+}
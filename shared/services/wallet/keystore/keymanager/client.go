@@ -0,0 +1,207 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestKeystoresPath = "/eth/v1/keystores"
+	RequestTimeout       = 30 * time.Second
+)
+
+// Keystore import & delete statuses
+const (
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+	StatusDeleted   = "deleted"
+	StatusNotActive = "not_active"
+	StatusNotFound  = "not_found"
+)
+
+// Standard Keymanager API client
+type Client struct {
+	url       string
+	authToken string
+	client    http.Client
+}
+
+// Request & response types
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type keystoreStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+type keystoreStatusesResponse struct {
+	Data               []keystoreStatus `json:"data"`
+	SlashingProtection string           `json:"slashing_protection"`
+}
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+		DerivationPath   string `json:"derivation_path"`
+		Readonly         bool   `json:"readonly"`
+	} `json:"data"`
+}
+
+// Create new Keymanager API client
+func NewClient(url, authToken string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		authToken: authToken,
+		client:    http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// Import an encrypted EIP-2335 keystore, with optional EIP-3076 slashing protection data
+func (c *Client) ImportKeystore(ctx context.Context, keystore []byte, password string, slashingProtection []byte) error {
+	responseBody, status, err := c.request(ctx, http.MethodPost, RequestKeystoresPath, importKeystoresRequest{
+		Keystores:          []string{string(keystore)},
+		Passwords:          []string{password},
+		SlashingProtection: string(slashingProtection),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not import keystore: %w", err)
+	} else if status != http.StatusOK {
+		return fmt.Errorf("Could not import keystore: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var response keystoreStatusesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return fmt.Errorf("Could not decode keystore import response: %w", err)
+	}
+	result, err := getSingleStatus(response)
+	if err != nil {
+		return fmt.Errorf("Could not import keystore: %w", err)
+	}
+	if result.Status != StatusImported && result.Status != StatusDuplicate {
+		return fmt.Errorf("Could not import keystore: status '%s'; message: '%s'", result.Status, result.Message)
+	}
+	return nil
+}
+
+// Delete a keystore and return its EIP-3076 slashing protection data
+func (c *Client) DeleteKeystore(ctx context.Context, pubkey rptypes.ValidatorPubkey) ([]byte, error) {
+	responseBody, status, err := c.request(ctx, http.MethodDelete, RequestKeystoresPath, deleteKeystoresRequest{
+		Pubkeys: []string{hexutil.AddPrefix(pubkey.Hex())},
+	})
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not delete keystore: %w", err)
+	} else if status != http.StatusOK {
+		return []byte{}, fmt.Errorf("Could not delete keystore: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var response keystoreStatusesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return []byte{}, fmt.Errorf("Could not decode keystore delete response: %w", err)
+	}
+	result, err := getSingleStatus(response)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not delete keystore: %w", err)
+	}
+
+	// Inactive keys may still have slashing protection history
+	switch result.Status {
+	case StatusDeleted, StatusNotActive:
+		return []byte(response.SlashingProtection), nil
+	case StatusNotFound:
+		return []byte{}, fmt.Errorf("Could not delete keystore %s from the validator client: %w", pubkey.Hex(), keystore.ErrValidatorKeyNotFound)
+	default:
+		return []byte{}, fmt.Errorf("Could not delete keystore: status '%s'; message: '%s'", result.Status, result.Message)
+	}
+}
+
+// Get the pubkeys of all keystores loaded by the validator client
+func (c *Client) ListKeystores(ctx context.Context) ([]rptypes.ValidatorPubkey, error) {
+	responseBody, status, err := c.request(ctx, http.MethodGet, RequestKeystoresPath, nil)
+	if err != nil {
+		return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not list keystores: %w", err)
+	} else if status != http.StatusOK {
+		return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not list keystores: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var response listKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode keystore list response: %w", err)
+	}
+	pubkeys := make([]rptypes.ValidatorPubkey, len(response.Data))
+	for i, keystore := range response.Data {
+		pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.ValidatingPubkey))
+		if err != nil {
+			return []rptypes.ValidatorPubkey{}, fmt.Errorf("Could not decode keystore pubkey: %w", err)
+		}
+		pubkeys[i] = pubkey
+	}
+	return pubkeys, nil
+}
+
+// Get the status of a single keystore operation
+func getSingleStatus(response keystoreStatusesResponse) (keystoreStatus, error) {
+	if len(response.Data) != 1 {
+		return keystoreStatus{}, fmt.Errorf("expected 1 keystore status, got %d", len(response.Data))
+	}
+	return response.Data[0], nil
+}
+
+// Make a request to the Keymanager API
+func (c *Client) request(ctx context.Context, method, requestPath string, requestBody interface{}) ([]byte, int, error) {
+
+	// Get request body
+	var requestBodyReader *bytes.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return []byte{}, 0, err
+		}
+		requestBodyReader = bytes.NewReader(requestBodyBytes)
+	} else {
+		requestBodyReader = bytes.NewReader([]byte{})
+	}
+
+	// Build request
+	request, err := http.NewRequestWithContext(ctx, method, c.url+requestPath, requestBodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// Submit request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	// Return
+	return body, response.StatusCode, nil
+
+}
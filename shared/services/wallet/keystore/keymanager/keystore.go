@@ -0,0 +1,100 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Keymanager API keystore
+// Keys are pushed to the running validator client, so it doesn't need to be restarted to load them
+type Keystore struct {
+	client    *Client
+	encryptor *eth2ks.Encryptor
+}
+
+// Encrypted validator key store
+type validatorKey struct {
+	Crypto  map[string]interface{}  `json:"crypto"`
+	Version uint                    `json:"version"`
+	UUID    uuid.UUID               `json:"uuid"`
+	Path    string                  `json:"path"`
+	Pubkey  rptypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Create new Keymanager API keystore
+func NewKeystore(client *Client) *Keystore {
+	return &Keystore{
+		client:    client,
+		encryptor: eth2ks.New(),
+	}
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.StoreValidatorKeyWithSlashingProtection(key, derivationPath, nil)
+}
+
+// Store a validator key along with its slashing protection data
+func (ks *Keystore) StoreValidatorKeyWithSlashingProtection(key *eth2types.BLSPrivateKey, derivationPath string, slashingProtection []byte) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import key store
+	return ks.client.ImportKeystore(context.Background(), keyStoreBytes, password, slashingProtection)
+
+}
+
+// Check that a validator key has been imported into the validator client
+func (ks *Keystore) VerifyValidatorKey(key *eth2types.BLSPrivateKey) error {
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	pubkeys, err := ks.client.ListKeystores(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, imported := range pubkeys {
+		if bytes.Equal(imported.Bytes(), pubkey.Bytes()) {
+			return nil
+		}
+	}
+	return errors.New("The validator key has not been imported into the validator client")
+}
+
+// Delete a validator key and return its slashing protection data
+func (ks *Keystore) DeleteValidatorKey(pubkey rptypes.ValidatorPubkey) ([]byte, error) {
+	return ks.client.DeleteKeystore(context.Background(), pubkey)
+}
@@ -0,0 +1,120 @@
+package keymanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Mock validator client keymanager API
+func newMockKeymanager() *httptest.Server {
+	pubkeys := map[string]bool{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != RequestKeystoresPath || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			response := listKeystoresResponse{}
+			for pubkey := range pubkeys {
+				response.Data = append(response.Data, struct {
+					ValidatingPubkey string `json:"validating_pubkey"`
+					DerivationPath   string `json:"derivation_path"`
+					Readonly         bool   `json:"readonly"`
+				}{ValidatingPubkey: pubkey})
+			}
+			_ = json.NewEncoder(w).Encode(response)
+		case http.MethodPost:
+			var request importKeystoresRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ks validatorKey
+			if err := json.Unmarshal([]byte(request.Keystores[0]), &ks); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			status := StatusImported
+			if pubkeys[hexutil.AddPrefix(ks.Pubkey.Hex())] {
+				status = StatusDuplicate
+			}
+			pubkeys[hexutil.AddPrefix(ks.Pubkey.Hex())] = true
+			_ = json.NewEncoder(w).Encode(keystoreStatusesResponse{Data: []keystoreStatus{{Status: status}}})
+		case http.MethodDelete:
+			var request deleteKeystoresRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			status := StatusNotFound
+			if pubkeys[request.Pubkeys[0]] {
+				status = StatusDeleted
+				delete(pubkeys, request.Pubkeys[0])
+			}
+			_ = json.NewEncoder(w).Encode(keystoreStatusesResponse{
+				Data:               []keystoreStatus{{Status: status}},
+				SlashingProtection: `{"metadata":{"interchange_format_version":"5"},"data":[]}`,
+			})
+		}
+	}))
+}
+
+func TestKeystoreImportsAndDeletesKeys(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	server := newMockKeymanager()
+	defer server.Close()
+	client := NewClient(server.URL, "token")
+	ks := NewKeystore(client)
+
+	// Importing the same key twice succeeds
+	for i := 0; i < 2; i++ {
+		if err := ks.StoreValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pubkeys, err := client.ListKeystores(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || pubkeys[0] != pubkey {
+		t.Fatalf("unexpected keystores %v", pubkeys)
+	}
+
+	// Deleting returns slashing protection data
+	slashingProtection, err := ks.DeleteValidatorKey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(slashingProtection) {
+		t.Errorf("invalid slashing protection data %s", string(slashingProtection))
+	}
+	if pubkeys, err := client.ListKeystores(context.Background()); err != nil || len(pubkeys) != 0 {
+		t.Errorf("expected no keystores after deletion, got %v (%v)", pubkeys, err)
+	}
+	if _, err := ks.DeleteValidatorKey(pubkey); !errors.Is(err, keystore.ErrValidatorKeyNotFound) {
+		t.Errorf("expected a not found error deleting a key which isn't loaded, got %v", err)
+	}
+
+	// Unauthorized requests fail
+	if err := NewKeystore(NewClient(server.URL, "")).StoreValidatorKey(key, ""); err == nil {
+		t.Error("expected an error importing without an auth token")
+	}
+}
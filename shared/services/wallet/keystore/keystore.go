@@ -1,6 +1,9 @@
 package keystore
 
 import (
+	"errors"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
@@ -21,3 +24,38 @@ func GenerateRandomPassword() (string, error) {
 type Keystore interface {
 	StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
 }
+
+// Validator key with its derivation path
+type ValidatorKey struct {
+	Key            *eth2types.BLSPrivateKey
+	DerivationPath string
+}
+
+// Keystore which can re-encrypt its stored validator keys with new secrets
+// The returned snapshot restores the previous keystore files; backups of them are removed once the change is complete
+type ReencryptableKeystore interface {
+	Keystore
+	ReencryptValidatorKeys(keys []ValidatorKey) (*FileSnapshot, error)
+	RemoveBackups() error
+}
+
+// Keystore which can check that a validator key is stored and decrypts correctly
+type VerifiableKeystore interface {
+	Keystore
+	VerifyValidatorKey(key *eth2types.BLSPrivateKey) error
+}
+
+// Returned when deleting a validator key which isn't stored
+var ErrValidatorKeyNotFound = errors.New("validator key not found")
+
+// Keystore which can delete validator keys, returning their EIP-3076 slashing protection data
+type DeletableKeystore interface {
+	Keystore
+	DeleteValidatorKey(pubkey rptypes.ValidatorPubkey) ([]byte, error)
+}
+
+// Keystore which can store validator keys with their EIP-3076 slashing protection data
+type SlashingProtectionKeystore interface {
+	Keystore
+	StoreValidatorKeyWithSlashingProtection(key *eth2types.BLSPrivateKey, derivationPath string, slashingProtection []byte) error
+}
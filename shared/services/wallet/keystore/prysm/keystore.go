@@ -92,13 +92,91 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Get the keystore account password
-	passwordFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
-	passwordBytes, err := ioutil.ReadFile(passwordFilePath)
+	passwordBytes, err := ioutil.ReadFile(ks.getPasswordFilePath())
 	if err != nil {
 		return fmt.Errorf("Error reading account password file: %w", err)
 	}
 	password := string(passwordBytes)
 
+	// Write account store
+	return ks.writeAccountStore(asBytes, password)
+
+}
+
+// Check that a validator key is stored in the account store
+func (ks *Keystore) VerifyValidatorKey(key *eth2types.BLSPrivateKey) error {
+	if _, err := os.Stat(ks.getKeystoreFilePath()); os.IsNotExist(err) {
+		return errors.New("The validator account store does not exist")
+	}
+	if err := ks.initialize(); err != nil {
+		return err
+	}
+	for ki := 0; ki < len(ks.as.PrivateKeys); ki++ {
+		if bytes.Equal(key.Marshal(), ks.as.PrivateKeys[ki]) {
+			return nil
+		}
+	}
+	return errors.New("The validator key is not in the account store")
+}
+
+// Re-encrypt the account store with a new password
+func (ks *Keystore) ReencryptValidatorKeys(keys []rpkeystore.ValidatorKey) (*rpkeystore.FileSnapshot, error) {
+
+	// Cancel if the account store doesn't exist
+	if _, err := os.Stat(ks.getKeystoreFilePath()); os.IsNotExist(err) {
+		return rpkeystore.NewFileSnapshot(), nil
+	}
+
+	// Initialize the account store
+	if err := ks.initialize(); err != nil {
+		return nil, err
+	}
+
+	// Encode account store
+	asBytes, err := json.Marshal(ks.as)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode validator account store: %w", err)
+	}
+
+	// Create a new password
+	password, err := rpkeystore.GenerateRandomPassword()
+	if err != nil {
+		return nil, fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Snapshot keystore files
+	snapshot := rpkeystore.NewFileSnapshot()
+	if err := snapshot.Add(ks.getPasswordFilePath(), ks.getKeystoreFilePath()); err != nil {
+		return nil, err
+	}
+
+	// Write password and account store
+	err = rpkeystore.WriteFile(ks.keystorePath, ks.getPasswordFilePath(), []byte(password), FileMode)
+	if err != nil {
+		err = fmt.Errorf("Error writing account password file: %w", err)
+	} else {
+		err = ks.writeAccountStore(asBytes, password)
+	}
+	if err != nil {
+		if restoreErr := snapshot.Restore(); restoreErr != nil {
+			return nil, fmt.Errorf("%w; additionally, could not restore the keystore: %s", err, restoreErr.Error())
+		}
+		return nil, err
+	}
+
+	// Return
+	return snapshot, nil
+
+}
+
+// Remove the backups of keystore files encrypted with previous passwords
+func (ks *Keystore) RemoveBackups() error {
+	return rpkeystore.RemoveBackups(ks.keystorePath)
+}
+
+// Encrypt the account store and write it to disk
+func (ks *Keystore) writeAccountStore(asBytes []byte, password string) error {
+
 	// Encrypt account store
 	asEncrypted, err := ks.encryptor.Encrypt(asBytes, password)
 	if err != nil {
@@ -120,7 +198,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Get file paths
-	keystoreFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName)
+	keystoreFilePath := ks.getKeystoreFilePath()
 	configFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, ConfigFileName)
 
 	// Create keystore dir
@@ -129,7 +207,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write keystore to disk
-	if err := ioutil.WriteFile(keystoreFilePath, ksBytes, FileMode); err != nil {
+	if err := rpkeystore.WriteFile(ks.keystorePath, keystoreFilePath, ksBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write keystore to disk: %w", err)
 	}
 
@@ -147,7 +225,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write wallet config to disk
-	if err := ioutil.WriteFile(configFilePath, configBytes, FileMode); err != nil {
+	if err := rpkeystore.WriteFile(ks.keystorePath, configFilePath, configBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write wallet config to disk: %w", err)
 	}
 
@@ -166,7 +244,7 @@ func (ks *Keystore) initialize() error {
 
 	// Create the random keystore password if it doesn't exist
 	var password string
-	passwordFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
+	passwordFilePath := ks.getPasswordFilePath()
 	_, err := os.Stat(passwordFilePath)
 	if os.IsNotExist(err) {
 		// Create a new password
@@ -183,7 +261,7 @@ func (ks *Keystore) initialize() error {
 		if err != nil {
 			return fmt.Errorf("Error creating account password directory: %w", err)
 		}
-		err = ioutil.WriteFile(passwordFilePath, passwordBytes, FileMode)
+		err = rpkeystore.WriteFile(ks.keystorePath, passwordFilePath, passwordBytes, FileMode)
 		if err != nil {
 			return fmt.Errorf("Error writing account password file: %w", err)
 		}
@@ -197,7 +275,7 @@ func (ks *Keystore) initialize() error {
 	password = string(passwordBytes)
 
 	// Read keystore file; initialize empty account store if it doesn't exist
-	ksBytes, err := ioutil.ReadFile(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName))
+	ksBytes, err := ioutil.ReadFile(ks.getKeystoreFilePath())
 	if err != nil {
 		ks.as = &accountStore{}
 		return nil
@@ -229,3 +307,13 @@ func (ks *Keystore) initialize() error {
 	return nil
 
 }
+
+// Get the path of the account password file
+func (ks *Keystore) getPasswordFilePath() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
+}
+
+// Get the path of the account keystore file
+func (ks *Keystore) getKeystoreFilePath() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName)
+}
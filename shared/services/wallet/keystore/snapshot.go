@@ -0,0 +1,64 @@
+package keystore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Copy of a set of files taken before they are rewritten, used to roll the change back
+type FileSnapshot struct {
+	files map[string]*snapshotFile
+}
+type snapshotFile struct {
+	data []byte
+	mode os.FileMode
+}
+
+// Create new file snapshot
+func NewFileSnapshot() *FileSnapshot {
+	return &FileSnapshot{
+		files: make(map[string]*snapshotFile),
+	}
+}
+
+// Add files to the snapshot; files which don't exist yet are removed on restore
+func (s *FileSnapshot) Add(paths ...string) error {
+	for _, path := range paths {
+		if _, ok := s.files[path]; ok {
+			continue
+		}
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			s.files[path] = nil
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Could not read file %s: %w", path, err)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Could not read file %s: %w", path, err)
+		}
+		s.files[path] = &snapshotFile{data: data, mode: info.Mode()}
+	}
+	return nil
+}
+
+// Restore all files to their snapshotted state
+func (s *FileSnapshot) Restore() error {
+	for path, file := range s.files {
+		if file == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("Could not remove file %s: %w", path, err)
+			}
+			continue
+		}
+		if err := files.WriteFile(path, file.data, file.mode); err != nil {
+			return fmt.Errorf("Could not restore file %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	existingPath := filepath.Join(dir, "existing")
+	newPath := filepath.Join(dir, "new")
+	if err := ioutil.WriteFile(existingPath, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := NewFileSnapshot()
+	if err := snapshot.Add(existingPath, newPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(existingPath, []byte("changed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(newPath, []byte("created"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := snapshot.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(existingPath); err != nil || string(data) != "original" {
+		t.Errorf("Existing file was not restored: %q %v", data, err)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Error("New file was not removed")
+	}
+}
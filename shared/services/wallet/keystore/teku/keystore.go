@@ -1,7 +1,9 @@
 package teku
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -85,7 +87,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Get secret file path
-	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())+".txt")
+	secretFilePath := ks.getSecretFilePath(pubkey)
 
 	// Create secrets dir
 	if err := os.MkdirAll(filepath.Dir(secretFilePath), DirMode); err != nil {
@@ -93,12 +95,12 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := keystore.WriteFile(ks.keystorePath, secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
 	// Get key file path
-	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex())+".json")
+	keyFilePath := ks.getKeyFilePath(pubkey)
 
 	// Create key dir
 	if err := os.MkdirAll(filepath.Dir(keyFilePath), DirMode); err != nil {
@@ -106,7 +108,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := ioutil.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := keystore.WriteFile(ks.keystorePath, keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
@@ -114,3 +116,77 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Re-encrypt the stored validator keys with new secrets
+func (ks *Keystore) ReencryptValidatorKeys(keys []keystore.ValidatorKey) (*keystore.FileSnapshot, error) {
+	snapshot := keystore.NewFileSnapshot()
+	for _, vk := range keys {
+
+		// Skip keys which aren't stored in the keystore
+		pubkey := rptypes.BytesToValidatorPubkey(vk.Key.PublicKey().Marshal())
+		keyFilePath := ks.getKeyFilePath(pubkey)
+		if _, err := os.Stat(keyFilePath); os.IsNotExist(err) {
+			continue
+		}
+
+		// Re-encrypt key
+		if err := snapshot.Add(ks.getSecretFilePath(pubkey), keyFilePath); err != nil {
+			return nil, err
+		}
+		if err := ks.StoreValidatorKey(vk.Key, vk.DerivationPath); err != nil {
+			if restoreErr := snapshot.Restore(); restoreErr != nil {
+				return nil, fmt.Errorf("%w; additionally, could not restore the keystore: %s", err, restoreErr.Error())
+			}
+			return nil, err
+		}
+
+	}
+	return snapshot, nil
+}
+
+// Remove the backups of keystore files encrypted with previous secrets
+func (ks *Keystore) RemoveBackups() error {
+	return keystore.RemoveBackups(ks.keystorePath)
+}
+
+// Check that a validator key is stored and decrypts correctly
+func (ks *Keystore) VerifyValidatorKey(key *eth2types.BLSPrivateKey) error {
+
+	// Read key store and secret
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	keyStoreBytes, err := ioutil.ReadFile(ks.getKeyFilePath(pubkey))
+	if err != nil {
+		return fmt.Errorf("Could not read validator key: %w", err)
+	}
+	password, err := ioutil.ReadFile(ks.getSecretFilePath(pubkey))
+	if err != nil {
+		return fmt.Errorf("Could not read validator secret: %w", err)
+	}
+
+	// Decode & decrypt key store
+	var keyStore validatorKey
+	if err := json.Unmarshal(keyStoreBytes, &keyStore); err != nil {
+		return fmt.Errorf("Could not decode validator key: %w", err)
+	}
+	decryptedKey, err := ks.encryptor.Decrypt(keyStore.Crypto, string(password))
+	if err != nil {
+		return fmt.Errorf("Could not decrypt validator key: %w", err)
+	}
+	if !bytes.Equal(decryptedKey, key.Marshal()) {
+		return errors.New("The stored validator key does not match")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the path of a validator key's secret file
+func (ks *Keystore) getSecretFilePath(pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())+".txt")
+}
+
+// Get the path of a validator key file
+func (ks *Keystore) getKeyFilePath(pubkey rptypes.ValidatorPubkey) string {
+	return filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex())+".json")
+}
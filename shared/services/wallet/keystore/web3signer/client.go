@@ -0,0 +1,111 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Config
+const (
+	RequestSignPath = "/api/v1/eth2/sign/%s"
+	RequestTimeout  = 30 * time.Second
+)
+
+// Web3Signer-compatible remote signer client
+type Client struct {
+	url       string
+	authToken string
+	client    http.Client
+}
+
+// Response types
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Create new remote signer client
+func NewClient(url, authToken string) *Client {
+	return &Client{
+		url:       strings.TrimSuffix(url, "/"),
+		authToken: authToken,
+		client:    http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// Get a validator signer which signs through the remote signer
+func (c *Client) GetSigner(pubkey rptypes.ValidatorPubkey) validator.Signer {
+	return &Signer{client: c, pubkey: pubkey}
+}
+
+// Request a signature from the remote signer
+func (c *Client) sign(ctx context.Context, pubkey rptypes.ValidatorPubkey, request interface{}) ([]byte, error) {
+	responseBody, status, err := c.postRequest(ctx, fmt.Sprintf(RequestSignPath, hexutil.AddPrefix(pubkey.Hex())), request)
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not get remote signature: %w", err)
+	} else if status != http.StatusOK {
+		return []byte{}, fmt.Errorf("Could not get remote signature: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	// Signatures are returned as JSON or plain text depending on the requested content type
+	signatureHex := strings.TrimSpace(string(responseBody))
+	var response signResponse
+	if err := json.Unmarshal(responseBody, &response); err == nil {
+		signatureHex = response.Signature
+	}
+	signature, err := rptypes.HexToValidatorSignature(hexutil.RemovePrefix(signatureHex))
+	if err != nil {
+		return []byte{}, fmt.Errorf("Could not decode remote signature: %w", err)
+	}
+	return signature.Bytes(), nil
+}
+
+// Make a POST request to the remote signer
+func (c *Client) postRequest(ctx context.Context, requestPath string, requestBody interface{}) ([]byte, int, error) {
+
+	// Get request body
+	requestBodyBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	// Build request
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+requestPath, bytes.NewReader(requestBodyBytes))
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	if c.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// Submit request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+
+	// Return
+	return body, response.StatusCode, nil
+
+}
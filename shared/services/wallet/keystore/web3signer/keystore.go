@@ -0,0 +1,157 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	LighthouseDefinitionsPath = "lighthouse/validators/validator_definitions.yml"
+	NimbusValidatorsDir       = "nimbus/validators"
+	NimbusKeyFileName         = "remote_keystore.json"
+	SignerType                = "web3signer"
+	DirMode                   = 0700
+	FileMode                  = 0600
+)
+
+// Remote signer keystore
+// Keys are imported into the remote signer, and validator clients are pointed at it instead of at local key files
+type Keystore struct {
+	keystorePath string
+	validatorUrl string
+	keystore     *keymanager.Keystore
+}
+
+// Nimbus remote validator definition
+type nimbusRemoteKey struct {
+	Version uint   `json:"version"`
+	Type    string `json:"type"`
+	Pubkey  string `json:"pubkey"`
+	Remote  string `json:"remote"`
+}
+
+// Create new remote signer keystore
+// If validatorUrl is set, validator client definitions which sign through it are written under keystorePath
+func NewKeystore(keystorePath, validatorUrl string, client *Client) *Keystore {
+	return &Keystore{
+		keystorePath: keystorePath,
+		validatorUrl: validatorUrl,
+		keystore:     keymanager.NewKeystore(keymanager.NewClient(client.url, client.authToken)),
+	}
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.StoreValidatorKeyWithSlashingProtection(key, derivationPath, nil)
+}
+
+// Store a validator key along with its slashing protection data
+func (ks *Keystore) StoreValidatorKeyWithSlashingProtection(key *eth2types.BLSPrivateKey, derivationPath string, slashingProtection []byte) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Import key into the remote signer
+	if err := ks.keystore.StoreValidatorKeyWithSlashingProtection(key, derivationPath, slashingProtection); err != nil {
+		return err
+	}
+
+	// Point validator clients at the remote signer
+	if ks.validatorUrl == "" {
+		return nil
+	}
+	if err := ks.storeLighthouseDefinition(pubkey); err != nil {
+		return err
+	}
+	return ks.storeNimbusDefinition(pubkey)
+
+}
+
+// Check that a validator key has been imported into the validator client
+func (ks *Keystore) VerifyValidatorKey(key *eth2types.BLSPrivateKey) error {
+	return ks.keystore.VerifyValidatorKey(key)
+}
+
+// Delete a validator key from the remote signer and return its slashing protection data
+func (ks *Keystore) DeleteValidatorKey(pubkey rptypes.ValidatorPubkey) ([]byte, error) {
+	return ks.keystore.DeleteValidatorKey(pubkey)
+}
+
+// Add a remote signer definition to the lighthouse validator definitions file
+func (ks *Keystore) storeLighthouseDefinition(pubkey rptypes.ValidatorPubkey) error {
+
+	// Load existing definitions
+	definitionsPath := filepath.Join(ks.keystorePath, LighthouseDefinitionsPath)
+	definitions := []yaml.MapSlice{}
+	if bytes, err := ioutil.ReadFile(definitionsPath); err == nil {
+		if err := yaml.Unmarshal(bytes, &definitions); err != nil {
+			return fmt.Errorf("Could not decode lighthouse validator definitions: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("Could not read lighthouse validator definitions: %w", err)
+	}
+
+	// Check for an existing definition
+	pubkeyHex := hexutil.AddPrefix(pubkey.Hex())
+	for _, definition := range definitions {
+		for _, item := range definition {
+			if item.Key == "voting_public_key" && item.Value == pubkeyHex {
+				return nil
+			}
+		}
+	}
+
+	// Add definition
+	definitions = append(definitions, yaml.MapSlice{
+		{Key: "enabled", Value: true},
+		{Key: "voting_public_key", Value: pubkeyHex},
+		{Key: "type", Value: SignerType},
+		{Key: "url", Value: ks.validatorUrl},
+	})
+	definitionsBytes, err := yaml.Marshal(definitions)
+	if err != nil {
+		return fmt.Errorf("Could not encode lighthouse validator definitions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(definitionsPath), DirMode); err != nil {
+		return fmt.Errorf("Could not create lighthouse validators folder: %w", err)
+	}
+	if err := keystore.WriteFile(ks.keystorePath, definitionsPath, definitionsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write lighthouse validator definitions to disk: %w", err)
+	}
+	return nil
+
+}
+
+// Write a nimbus remote keystore for a validator
+func (ks *Keystore) storeNimbusDefinition(pubkey rptypes.ValidatorPubkey) error {
+	pubkeyHex := hexutil.AddPrefix(pubkey.Hex())
+	remoteKeyBytes, err := json.Marshal(nimbusRemoteKey{
+		Version: 1,
+		Type:    SignerType,
+		Pubkey:  pubkeyHex,
+		Remote:  ks.validatorUrl,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode nimbus remote keystore: %w", err)
+	}
+	keyFilePath := filepath.Join(ks.keystorePath, NimbusValidatorsDir, pubkeyHex, NimbusKeyFileName)
+	if err := os.MkdirAll(filepath.Dir(keyFilePath), DirMode); err != nil {
+		return fmt.Errorf("Could not create nimbus validator folder: %w", err)
+	}
+	if err := keystore.WriteFile(ks.keystorePath, keyFilePath, remoteKeyBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write nimbus remote keystore to disk: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Mock remote signer which decrypts imported keystores and signs with them
+func newMockSigner() *httptest.Server {
+	keys := map[string]*eth2types.BLSPrivateKey{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(keymanager.RequestKeystoresPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var request struct {
+			Keystores []string `json:"keystores"`
+			Passwords []string `json:"passwords"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var ks struct {
+			Crypto map[string]interface{} `json:"crypto"`
+			Pubkey string                 `json:"pubkey"`
+		}
+		if err := json.Unmarshal([]byte(request.Keystores[0]), &ks); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		keyBytes, err := eth2ks.New().Decrypt(ks.Crypto, request.Passwords[0])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key, err := eth2types.BLSPrivateKeyFromBytes(keyBytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		keys[hexutil.AddPrefix(ks.Pubkey)] = key
+		_, _ = w.Write([]byte(`{"data":[{"status":"imported","message":""}]}`))
+	})
+	mux.HandleFunc("/api/v1/eth2/sign/", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := keys[strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var request struct {
+			Type        string `json:"type"`
+			SigningRoot string `json:"signingRoot"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if request.Type != SignTypeDeposit && request.Type != SignTypeVoluntaryExit {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signingRoot := common.FromHex(request.SigningRoot)
+		_ = json.NewEncoder(w).Encode(signResponse{Signature: hexutil.AddPrefix(rptypes.BytesToValidatorSignature(key.Sign(signingRoot).Marshal()).Hex())})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestRemoteSigningMatchesLocalSigning(t *testing.T) {
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newMockSigner()
+	defer server.Close()
+	keystorePath, err := ioutil.TempDir("", "web3signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(keystorePath)
+
+	// Import key
+	client := NewClient(server.URL, "token")
+	ks := NewKeystore(keystorePath, "http://web3signer:9000", client)
+	if err := ks.StoreValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.StoreValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+		t.Fatal(err)
+	}
+	definitions, err := ioutil.ReadFile(filepath.Join(keystorePath, LighthouseDefinitionsPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(string(definitions), "voting_public_key"); count != 1 {
+		t.Errorf("expected 1 lighthouse validator definition, got %d", count)
+	}
+
+	// Compare deposit data
+	local := validator.NewLocalSigner(key)
+	remote := client.GetSigner(local.GetPubkey())
+	eth2Config := beacon.Eth2Config{GenesisForkVersion: []byte{0, 0, 0, 0}}
+	withdrawalCredentials := common.HexToHash("0x01")
+	localData, localRoot, err := validator.GetDepositData(local, withdrawalCredentials, eth2Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteData, remoteRoot, err := validator.GetDepositData(remote, withdrawalCredentials, eth2Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localRoot != remoteRoot || string(localData.Signature) != string(remoteData.Signature) {
+		t.Error("remote deposit data does not match local deposit data")
+	}
+
+	// Compare voluntary exit signatures
+	forkInfo := validator.ForkInfo{
+		Fork:                  beacon.Fork{PreviousVersion: []byte{0, 0, 0, 0}, CurrentVersion: []byte{1, 0, 0, 0}, Epoch: 10},
+		GenesisValidatorsRoot: make([]byte, 32),
+	}
+	localSignature, err := validator.GetSignedExitMessage(local, 5, 20, forkInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteSignature, err := validator.GetSignedExitMessage(remote, 5, 20, forkInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localSignature != remoteSignature {
+		t.Error("remote exit signature does not match local exit signature")
+	}
+
+	// Unknown keys are rejected
+	if _, err := validator.GetSignedExitMessage(client.GetSigner(rptypes.ValidatorPubkey{}), 5, 20, forkInfo); err == nil {
+		t.Error("expected an error signing with an unknown key")
+	}
+}
@@ -0,0 +1,97 @@
+package web3signer
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Signing request types
+const (
+	SignTypeDeposit       = "DEPOSIT"
+	SignTypeVoluntaryExit = "VOLUNTARY_EXIT"
+)
+
+// Signs validator messages through a remote signer
+type Signer struct {
+	client *Client
+	pubkey rptypes.ValidatorPubkey
+}
+
+// Signing request types
+type depositSignRequest struct {
+	Type        string         `json:"type"`
+	SigningRoot string         `json:"signingRoot"`
+	Deposit     depositMessage `json:"deposit"`
+}
+type depositMessage struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+type voluntaryExitSignRequest struct {
+	Type          string               `json:"type"`
+	SigningRoot   string               `json:"signingRoot"`
+	ForkInfo      forkInfo             `json:"fork_info"`
+	VoluntaryExit voluntaryExitMessage `json:"voluntary_exit"`
+}
+type forkInfo struct {
+	Fork struct {
+		PreviousVersion string `json:"previous_version"`
+		CurrentVersion  string `json:"current_version"`
+		Epoch           string `json:"epoch"`
+	} `json:"fork"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+}
+type voluntaryExitMessage struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+// Get the validator pubkey
+func (s *Signer) GetPubkey() rptypes.ValidatorPubkey {
+	return s.pubkey
+}
+
+// Sign deposit data
+func (s *Signer) SignDeposit(depositData eth2.DepositDataNoSignature, signingRoot []byte, genesisForkVersion []byte) ([]byte, error) {
+	return s.client.sign(context.Background(), s.pubkey, depositSignRequest{
+		Type:        SignTypeDeposit,
+		SigningRoot: encodeHex(signingRoot),
+		Deposit: depositMessage{
+			Pubkey:                encodeHex(depositData.PublicKey),
+			WithdrawalCredentials: encodeHex(depositData.WithdrawalCredentials),
+			Amount:                strconv.FormatUint(depositData.Amount, 10),
+			GenesisForkVersion:    encodeHex(genesisForkVersion),
+		},
+	})
+}
+
+// Sign a voluntary exit message
+func (s *Signer) SignVoluntaryExit(exitMessage eth2.VoluntaryExit, signingRoot []byte, fork validator.ForkInfo) ([]byte, error) {
+	request := voluntaryExitSignRequest{
+		Type:        SignTypeVoluntaryExit,
+		SigningRoot: encodeHex(signingRoot),
+		VoluntaryExit: voluntaryExitMessage{
+			Epoch:          strconv.FormatUint(exitMessage.Epoch, 10),
+			ValidatorIndex: strconv.FormatUint(exitMessage.ValidatorIndex, 10),
+		},
+	}
+	request.ForkInfo.Fork.PreviousVersion = encodeHex(fork.Fork.PreviousVersion)
+	request.ForkInfo.Fork.CurrentVersion = encodeHex(fork.Fork.CurrentVersion)
+	request.ForkInfo.Fork.Epoch = strconv.FormatUint(fork.Fork.Epoch, 10)
+	request.ForkInfo.GenesisValidatorsRoot = encodeHex(fork.GenesisValidatorsRoot)
+	return s.client.sign(context.Background(), s.pubkey, request)
+}
+
+// Encode bytes as a prefixed hex string
+func encodeHex(value []byte) string {
+	return hexutil.AddPrefix(hex.EncodeToString(value))
+}
@@ -10,20 +10,45 @@ import (
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
 // Config
-const NodeKeyPath = "m/44'/60'/0'/0/%d"
+const (
+	NodeKeyPath          = "m/44'/60'/0'/0/%d"
+	ExternalSignerScheme = "extapi"
+)
+
+// Returned when the node account key is held by an external signer
+var ErrExternalNodeSigner = errors.New("The node account is managed by an external signer")
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Get watch-only account
+	if w.isWatchOnly() {
+		return accounts.Account{Address: *w.watchOnlyAddress}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
+	// Get external signer account
+	if w.nodeSigner != nil {
+		return accounts.Account{
+			Address: w.nodeSigner.GetAddress(),
+			URL: accounts.URL{
+				Scheme: ExternalSignerScheme,
+				Path:   w.nodeSigner.GetUrl(),
+			},
+		}, nil
+	}
+
 	// Get private key
 	privateKey, path, err := w.getNodePrivateKey()
 	if err != nil {
@@ -51,11 +76,25 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+	// Get unsigned transactor; the wallet isn't needed if a watch-only node address is set
+	if w.exportRecorder != nil {
+		return w.getUnsignedTransactor()
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Get external signer transactor
+	if w.nodeSigner != nil {
+		transactor := w.getExternalSignerTransactor()
+		if err := w.prepareTransactor(transactor); err != nil {
+			return nil, err
+		}
+		return transactor, nil
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -64,12 +103,143 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 	// Create & return transactor
 	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	if err != nil {
+		return nil, err
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	if err := w.prepareTransactor(transactor); err != nil {
+		return nil, err
+	}
+	return transactor, nil
+
+}
+
+// Set up a transactor to record its transactions in dry-run mode, or to allocate their nonces otherwise
+func (w *Wallet) prepareTransactor(transactor *bind.TransactOpts) error {
+	if w.dryRunRecorder != nil {
+		w.recordTransactions(transactor)
+		return nil
+	}
+	return w.allocateNonces(transactor)
+}
+
+// Record a transactor's transactions with the dry-run recorder as they're signed, and don't submit them
+// Nonces aren't allocated as the transactions won't use them
+// Gas is estimated by the recorder after the transaction is built rather than by the contract binding, so transactions which would revert are still simulated and recorded with their revert reason
+func (w *Wallet) recordTransactions(transactor *bind.TransactOpts) {
+	transactor.NoSend = true
+	estimateGas := (transactor.GasLimit == 0)
+	if estimateGas {
+		transactor.GasLimit = rocketpool.MaxGasLimit
+	}
+	signer := transactor.Signer
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		ctx := transactor.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if estimateGas && tx.Gas() == rocketpool.MaxGasLimit {
+			gasLimit, err := w.dryRunRecorder.EstimateGas(ctx, address, tx)
+			if err != nil {
+				return nil, err
+			}
+			if gasLimit > 0 {
+				tx = copyTx(tx, tx.Nonce(), gasLimit)
+			}
+		}
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.dryRunRecorder.Record(ctx, address, signedTx); err != nil {
+			return nil, err
+		}
+		return signedTx, nil
+	}
+}
+
+// Assign nonces to a transactor's transactions from the nonce allocator as they're signed
+// Nonces are only allocated for transactions which are submitted, not for gas estimates; nonces set on the transactor are kept
+// Transactions at allocated nonces are submitted by the allocator as they're signed, and the transactor is set not to submit them again,
+// so the nonce is released if signing or submission fails
+func (w *Wallet) allocateNonces(transactor *bind.TransactOpts) error {
+	if w.getNonceAllocator == nil {
+		return nil
+	}
+	nonceAllocator, err := w.getNonceAllocator()
+	if err != nil {
+		return fmt.Errorf("Could not get nonce allocator: %w", err)
+	}
+	signer := transactor.Signer
+	noSend := transactor.NoSend
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		transactor.NoSend = noSend
+		if transactor.Nonce != nil || noSend {
+			return signer(address, tx)
+		}
+		ctx := transactor.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		nonce, err := nonceAllocator.AllocateNonce(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("Could not allocate nonce: %w", err)
+		}
+		signedTx, err := signer(address, copyTx(tx, nonce, tx.Gas()))
+		if err != nil {
+			if releaseErr := nonceAllocator.ReleaseNonce(address, nonce); releaseErr != nil {
+				return nil, fmt.Errorf("%w; additionally, could not release nonce %d: %s", err, nonce, releaseErr.Error())
+			}
+			return nil, err
+		}
+		if err := nonceAllocator.SubmitTransaction(ctx, signedTx); err != nil {
+			return nil, err
+		}
+		transactor.NoSend = true
+		return signedTx, nil
+	}
+	return nil
+}
 
+// Get a copy of a transaction with a different nonce & gas limit
+func copyTx(tx *types.Transaction, nonce uint64, gasLimit uint64) *types.Transaction {
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        gasLimit,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasPrice:   tx.GasPrice(),
+			Gas:        gasLimit,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	default:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: tx.GasPrice(),
+			Gas:      gasLimit,
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	}
 }
 
 // Get the node account private key bytes
@@ -80,6 +250,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Check the node key is held locally
+	if w.nodeSigner != nil {
+		return nil, ErrExternalNodeSigner
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -91,6 +266,51 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
 }
 
+// Get a transactor which records its transactions unsigned with the export recorder, and doesn't submit them
+// The transactions are signed later on another machine with 'rocketpool sign'
+func (w *Wallet) getUnsignedTransactor() (*bind.TransactOpts, error) {
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	transactor := &bind.TransactOpts{
+		From:      account.Address,
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, bind.ErrNotAuthorized
+		}
+		if err := w.exportRecorder.Record(transactor.Context, address, tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+	return transactor, nil
+}
+
+// Get a transactor which signs with the external node signer
+func (w *Wallet) getExternalSignerTransactor() *bind.TransactOpts {
+	address := w.nodeSigner.GetAddress()
+	return &bind.TransactOpts{
+		From: address,
+		Signer: func(signerAddress common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if signerAddress != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.nodeSigner.SignTransaction(tx, w.chainID)
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+	}
+}
+
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
@@ -100,7 +320,7 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 	}
 
 	// Get derived key
-	derivedKey, path, err := w.getNodeDerivedKey(0)
+	derivedKey, path, err := w.getNodeDerivedKey(w.nodeAccountIndex)
 	if err != nil {
 		return nil, "", err
 	}
@@ -0,0 +1,275 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+)
+
+// Nonce allocator which counts up from a starting nonce, and releases nonces whose transactions fail to submit
+type fakeNonceAllocator struct {
+	next      uint64
+	submitErr error
+	submitted []*types.Transaction
+}
+
+func (a *fakeNonceAllocator) AllocateNonce(ctx context.Context, from common.Address) (uint64, error) {
+	a.next++
+	return a.next - 1, nil
+}
+func (a *fakeNonceAllocator) ReleaseNonce(from common.Address, nonce uint64) error {
+	if nonce == a.next-1 {
+		a.next--
+	}
+	return nil
+}
+func (a *fakeNonceAllocator) SubmitTransaction(ctx context.Context, tx *types.Transaction) error {
+	if a.submitErr != nil {
+		_ = a.ReleaseNonce(common.Address{}, tx.Nonce())
+		return a.submitErr
+	}
+	a.submitted = append(a.submitted, tx)
+	return nil
+}
+
+func TestNonceAllocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	allocators := 0
+	allocator := &fakeNonceAllocator{next: 42}
+	w.SetNonceAllocatorGetter(func() (NonceAllocator, error) {
+		allocators++
+		return allocator, nil
+	})
+
+	// The allocator is only created for transactors
+	if _, err := w.GetNodeAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if allocators != 0 {
+		t.Fatal("Expected the nonce allocator not to be created before a transactor")
+	}
+
+	// Signed transactions take their nonces from the allocator
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocators != 1 {
+		t.Errorf("Expected the nonce allocator to be created once, created %d times", allocators)
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	for _, expected := range []uint64{42, 43} {
+		tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 7, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(3)}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Nonce() != expected || tx.Value().Int64() != 3 || *tx.To() != to {
+			t.Errorf("Unexpected signed transaction with nonce %d, expected %d", tx.Nonce(), expected)
+		}
+		if !opts.NoSend {
+			t.Error("Expected the transactor not to submit a transaction the allocator submitted")
+		}
+	}
+	if len(allocator.submitted) != 2 {
+		t.Errorf("Expected the allocator to submit 2 transactions, submitted %d", len(allocator.submitted))
+	}
+
+	// Nonces of transactions which fail to submit are reused
+	allocator.submitErr = errors.New("submission failed")
+	if _, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21000, To: &to})); err == nil {
+		t.Error("Expected the submission error to be returned")
+	}
+	allocator.submitErr = nil
+	if tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: 21000, To: &to})); err != nil || tx.Nonce() != 44 {
+		t.Errorf("Expected nonce 44 to be reused after a failed submission, got %v (%v)", tx, err)
+	}
+
+	// Nonces set on the transactor are kept, and their transactions are left to the caller to submit
+	opts.Nonce = big.NewInt(7)
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 7, Gas: 21000, To: &to}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 7 || opts.NoSend {
+		t.Errorf("Expected the nonce override to be kept and submitted by the caller, got nonce %d", tx.Nonce())
+	}
+}
+
+// Transaction recorder which keeps recorded transactions in memory
+type fakeRecorder struct {
+	txs      []*types.Transaction
+	gasLimit uint64
+}
+
+func (r *fakeRecorder) Record(ctx context.Context, from common.Address, tx *types.Transaction) error {
+	r.txs = append(r.txs, tx)
+	return nil
+}
+func (r *fakeRecorder) EstimateGas(ctx context.Context, from common.Address, tx *types.Transaction) (uint64, error) {
+	return r.gasLimit, nil
+}
+
+func TestUnsignedExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	w.SetNonceAllocator(&fakeNonceAllocator{next: 42})
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Transactions are recorded unsigned, with their nonces unchanged, and aren't submitted
+	recorder := &fakeRecorder{}
+	opts, err := w.ForUnsignedExport(recorder).GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.From != account.Address || !opts.NoSend {
+		t.Fatalf("Unexpected unsigned transactor for %s", opts.From.Hex())
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 7, Gas: 21000, To: &to}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, r, s := tx.RawSignatureValues(); v.Sign() != 0 || r.Sign() != 0 || s.Sign() != 0 {
+		t.Error("Expected the transaction to be unsigned")
+	}
+	if len(recorder.txs) != 1 || recorder.txs[0].Nonce() != 7 {
+		t.Errorf("Expected the transaction with nonce 7 to be recorded, got %d transactions", len(recorder.txs))
+	}
+
+	// Other senders aren't authorized
+	if _, err := opts.Signer(to, tx); err == nil {
+		t.Error("Expected an error recording a transaction from another address")
+	}
+}
+
+func TestWatchOnlyUnsignedExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a wallet without a password or seed, with a watch-only node address
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	w.SetWatchOnlyNodeAddress(address)
+
+	// Transactions can only be exported unsigned
+	if _, err := w.GetNodeAccountTransactor(); err == nil {
+		t.Error("Expected an error getting a transactor without the wallet")
+	}
+	recorder := &fakeRecorder{}
+	ew := w.ForUnsignedExport(recorder)
+	account, err := ew.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := ew.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != address || opts.From != address {
+		t.Fatalf("Expected the watch-only address %s, got %s and %s", address.Hex(), account.Address.Hex(), opts.From.Hex())
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	if _, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 3, Gas: 21000, To: &to})); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.txs) != 1 || recorder.txs[0].Nonce() != 3 {
+		t.Errorf("Expected the transaction with nonce 3 to be recorded, got %d transactions", len(recorder.txs))
+	}
+}
+
+func TestDryRunGasEstimate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The contract bindings don't estimate gas, so reverting transactions are still signed & recorded
+	recorder := &fakeRecorder{gasLimit: 45000}
+	opts, err := w.ForDryRun(recorder).GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.GasLimit == 0 || !opts.NoSend {
+		t.Fatalf("Unexpected dry-run transactor with gas limit %d", opts.GasLimit)
+	}
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	for _, gasLimits := range [][2]uint64{{45000, 45000}, {0, opts.GasLimit}} {
+		recorder.gasLimit = gasLimits[0]
+		tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Gas: opts.GasLimit, To: &to}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Gas() != gasLimits[1] {
+			t.Errorf("Expected a gas limit of %d, got %d", gasLimits[1], tx.Gas())
+		}
+	}
+	if len(recorder.txs) != 2 {
+		t.Errorf("Expected 2 recorded transactions, got %d", len(recorder.txs))
+	}
+}
@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Change the wallet password, re-encrypting the wallet seed and all validator keystores
+// The new password is only stored once everything has been re-encrypted, and all changes are rolled back if any step fails
+// Returns the names of keystores which don't support re-encryption and were left unchanged
+func (w *Wallet) ChangePassword(currentPassword, newPassword string) ([]string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Check current password
+	if _, err := w.encryptor.Decrypt(w.ws.Crypto, currentPassword); err != nil {
+		return nil, errors.New("The current wallet password is incorrect")
+	}
+
+	// Get validator keys
+	keys, err := w.getValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-encrypt seed and imported keys
+	encryptedSeed, err := w.encryptor.Encrypt(w.seed, newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encrypt wallet seed: %w", err)
+	}
+	importedKeys := make([]importedKey, len(w.ws.ImportedKeys))
+	for ki, ik := range w.ws.ImportedKeys {
+		key, _, err := w.getImportedValidatorKey(ik.Pubkey)
+		if err != nil {
+			return nil, err
+		}
+		encryptedKey, err := w.encryptor.Encrypt(key.Marshal(), newPassword)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encrypt imported validator key: %w", err)
+		}
+		importedKeys[ki] = importedKey{Crypto: encryptedKey, Path: ik.Path, Pubkey: ik.Pubkey}
+	}
+
+	// Roll back every completed step on failure
+	rollbacks := []func() error{}
+	rollback := func(err error) error {
+		rollbackErrors := []string{}
+		for ri := len(rollbacks) - 1; ri >= 0; ri-- {
+			if rollbackErr := rollbacks[ri](); rollbackErr != nil {
+				rollbackErrors = append(rollbackErrors, rollbackErr.Error())
+			}
+		}
+		if len(rollbackErrors) > 0 {
+			return fmt.Errorf("%w; additionally, could not roll back the password change: %s", err, strings.Join(rollbackErrors, "; "))
+		}
+		return err
+	}
+
+	// Re-encrypt validator keystores
+	unchangedKeystores := []string{}
+	for name, ks := range w.keystores {
+		rks, ok := ks.(keystore.ReencryptableKeystore)
+		if !ok {
+			unchangedKeystores = append(unchangedKeystores, name)
+			continue
+		}
+		snapshot, err := rks.ReencryptValidatorKeys(keys)
+		if err != nil {
+			return nil, rollback(fmt.Errorf("Could not re-encrypt %s validator keystore: %w", name, err))
+		}
+		rollbacks = append(rollbacks, snapshot.Restore)
+	}
+	sort.Strings(unchangedKeystores)
+
+	// Save wallet with the re-encrypted seed and imported keys
+	currentSeed, currentImportedKeys := w.ws.Crypto, w.ws.ImportedKeys
+	restoreWallet := func() error {
+		w.ws.Crypto, w.ws.ImportedKeys = currentSeed, currentImportedKeys
+		return w.Save()
+	}
+	w.ws.Crypto, w.ws.ImportedKeys = encryptedSeed, importedKeys
+	if err := w.Save(); err != nil {
+		rollbacks = append(rollbacks, restoreWallet)
+		return nil, rollback(err)
+	}
+	rollbacks = append(rollbacks, restoreWallet)
+
+	// Store new password
+	if err := w.pm.ChangePassword(newPassword); err != nil {
+		return nil, rollback(fmt.Errorf("Could not store new wallet password: %w", err))
+	}
+
+	// Remove backups encrypted with the previous password and secrets
+	if err := w.removeBackups(); err != nil {
+		return nil, fmt.Errorf("The wallet password was changed, but backups encrypted with the previous password could not be removed: %w", err)
+	}
+
+	// Return
+	return unchangedKeystores, nil
+
+}
+
+// Remove the wallet and validator keystore backups
+func (w *Wallet) removeBackups() error {
+	if err := files.RemoveBackups(w.getBackupPath()); err != nil {
+		return fmt.Errorf("Could not remove wallet backups: %w", err)
+	}
+	for name, ks := range w.keystores {
+		if rks, ok := ks.(keystore.ReencryptableKeystore); ok {
+			if err := rks.RemoveBackups(); err != nil {
+				return fmt.Errorf("Could not remove %s validator keystore backups: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+func TestChangePasswordRemovesBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create wallet with a backup encrypted with its password
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWallet(filepath.Join(dir, "wallet"), "1", nil, nil, 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := w.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if backups, err := files.GetBackups(w.getBackupPath()); err != nil || len(backups) == 0 {
+		t.Fatalf("Expected a wallet backup, got %v (%v)", backups, err)
+	}
+
+	// Changing the password removes the backups
+	if _, err := w.ChangePassword("wallet password", "new wallet password"); err != nil {
+		t.Fatal(err)
+	}
+	if backups, err := files.GetBackups(w.getBackupPath()); err != nil || len(backups) != 0 {
+		t.Errorf("Expected no wallet backups after changing the password, got %v (%v)", backups, err)
+	}
+}
@@ -9,6 +9,10 @@ import (
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2util "github.com/wealdtech/go-eth2-util"
+
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
 // Config
@@ -17,6 +21,9 @@ const (
 	MaxValidatorKeyRecoverAttempts = 100
 )
 
+// Returned when recovering a validator key which neither derives from the wallet seed nor was imported
+var ErrValidatorKeyNotFound = errors.New("it is not derived from the wallet seed or imported into the wallet")
+
 // Get the number of validator keys recorded in the wallet
 func (w *Wallet) GetValidatorKeyCount() (uint, error) {
 
@@ -64,6 +71,13 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 		}
 	}
 
+	// Check for imported validator key
+	if key, _, err := w.getImportedValidatorKey(pubkey); err != nil {
+		return nil, err
+	} else if key != nil {
+		return key, nil
+	}
+
 	// Find matching validator key
 	var index uint
 	var validatorKey *eth2types.BLSPrivateKey
@@ -89,6 +103,40 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 
 }
 
+// Check that a validator key is stored in each keystore
+// Returns the verification errors by keystore name
+func (w *Wallet) VerifyValidatorKey(pubkey rptypes.ValidatorPubkey) (map[string]error, error) {
+
+	// Get validator key
+	key, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify key in keystores
+	failures := make(map[string]error)
+	for name, ks := range w.keystores {
+		if vks, ok := ks.(keystore.VerifiableKeystore); ok {
+			if err := vks.VerifyValidatorKey(key); err != nil {
+				failures[name] = err
+			}
+		}
+	}
+
+	// Return
+	return failures, nil
+
+}
+
+// Get the signer for a validator key
+// Messages are signed through the remote signer if one is set, otherwise with the local key
+func (w *Wallet) GetValidatorSigner(key *eth2types.BLSPrivateKey) validator.Signer {
+	if w.remoteSigner != nil {
+		return w.remoteSigner.GetSigner(rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal()))
+	}
+	return validator.NewLocalSigner(key)
+}
+
 // Create a new validator key
 func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
@@ -150,6 +198,18 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 		return errors.New("Wallet is not initialized")
 	}
 
+	// Restore imported validator key
+	if key, path, err := w.getImportedValidatorKey(pubkey); err != nil {
+		return err
+	} else if key != nil {
+		for name := range w.keystores {
+			if err := w.keystores[name].StoreValidatorKey(key, path); err != nil {
+				return fmt.Errorf("Could not store %s validator key: %w", name, err)
+			}
+		}
+		return nil
+	}
+
 	// Find matching validator key
 	var index uint
 	var validatorKey *eth2types.BLSPrivateKey
@@ -166,7 +226,7 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 
 	// Check validator key
 	if validatorKey == nil {
-		return fmt.Errorf("Validator %s key not found", pubkey.Hex())
+		return fmt.Errorf("Validator %s key not found: %w", pubkey.Hex(), ErrValidatorKeyNotFound)
 	}
 
 	// Update account index
@@ -188,6 +248,131 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 
 }
 
+// Delete a validator key from all keystores which support it and return its slashing protection data
+func (w *Wallet) DeleteValidatorKey(pubkey rptypes.ValidatorPubkey) ([]byte, error) {
+
+	// Delete from keystores
+	var slashingProtection []byte
+	deleted := false
+	for name := range w.keystores {
+		ks, ok := w.keystores[name].(keystore.DeletableKeystore)
+		if !ok {
+			continue
+		}
+		data, err := ks.DeleteValidatorKey(pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("Could not delete %s validator key: %w", name, err)
+		}
+		slashingProtection = data
+		deleted = true
+	}
+
+	// Check a keystore supported deletion
+	if !deleted {
+		return nil, errors.New("None of the configured keystores support deleting validator keys")
+	}
+
+	// Return
+	return slashingProtection, nil
+
+}
+
+// Remove all validator keys from keystores which support it and return their merged slashing protection data
+// If a key can't be removed, the data of the keys already removed is returned along with the error
+func (w *Wallet) ExportSlashingProtection() (*slashing.Interchange, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Get validator keys
+	keys, err := w.getValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete validator keys
+	interchanges := []*slashing.Interchange{}
+	for _, vk := range keys {
+		pubkey := rptypes.BytesToValidatorPubkey(vk.Key.PublicKey().Marshal())
+		data, err := w.DeleteValidatorKey(pubkey)
+		if errors.Is(err, keystore.ErrValidatorKeyNotFound) {
+			// Keys which aren't loaded by the validator client have no history
+			continue
+		} else if err != nil {
+			return mergePartialInterchanges(interchanges, err)
+		}
+		interchange, err := slashing.Parse(data)
+		if err != nil {
+			return mergePartialInterchanges(interchanges, fmt.Errorf("Could not decode slashing protection data for deleted validator key %s: %w; data: %s", pubkey.Hex(), err, string(data)))
+		}
+		interchanges = append(interchanges, interchange)
+	}
+
+	// Return merged data
+	return slashing.Merge(interchanges...)
+
+}
+
+// Merge the slashing protection data exported before an error, so it isn't lost with the deleted keys
+func mergePartialInterchanges(interchanges []*slashing.Interchange, exportErr error) (*slashing.Interchange, error) {
+	if len(interchanges) == 0 {
+		return nil, exportErr
+	}
+	merged, err := slashing.Merge(interchanges...)
+	if err != nil {
+		return nil, fmt.Errorf("%s; the slashing protection data of keys already deleted could not be merged: %w", exportErr.Error(), err)
+	}
+	return merged, exportErr
+}
+
+// Store all validator keys in keystores which accept slashing protection data, along with their history
+func (w *Wallet) ImportSlashingProtection(interchange *slashing.Interchange) ([]rptypes.ValidatorPubkey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Check a keystore supports slashing protection data
+	keystores := map[string]keystore.SlashingProtectionKeystore{}
+	for name := range w.keystores {
+		if ks, ok := w.keystores[name].(keystore.SlashingProtectionKeystore); ok {
+			keystores[name] = ks
+		}
+	}
+	if len(keystores) == 0 {
+		return nil, errors.New("None of the configured keystores support importing slashing protection data")
+	}
+
+	// Get validator keys
+	keys, err := w.getValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Store validator keys
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, vk := range keys {
+		pubkey := rptypes.BytesToValidatorPubkey(vk.Key.PublicKey().Marshal())
+		data, err := interchange.Filter(pubkey).Serialize()
+		if err != nil {
+			return nil, err
+		}
+		for name, ks := range keystores {
+			if err := ks.StoreValidatorKeyWithSlashingProtection(vk.Key, vk.DerivationPath, data); err != nil {
+				return nil, fmt.Errorf("Could not store %s validator key: %w", name, err)
+			}
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	// Return
+	return pubkeys, nil
+
+}
+
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
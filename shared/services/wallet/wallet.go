@@ -1,28 +1,38 @@
 package wallet
 
 import (
+	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"path/filepath"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
 // Config
 const (
 	EntropyBits = 256
 	FileMode    = 0600
+	BackupsDir  = "backups"
+	MaxBackups  = 10
 )
 
 // Wallet
@@ -41,6 +51,10 @@ type Wallet struct {
 	seed []byte
 	mk   *hdkeychain.ExtendedKey
 
+	// Selected node account
+	nodeAccountName  string
+	nodeAccountIndex uint
+
 	// Node key cache
 	nodeKey     *ecdsa.PrivateKey
 	nodeKeyPath string
@@ -48,23 +62,73 @@ type Wallet struct {
 	// Validator key caches
 	validatorKeys       map[uint]*eth2types.BLSPrivateKey
 	validatorKeyIndices map[string]uint
+	importedKeys        map[string]*eth2types.BLSPrivateKey
 
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// Remote validator signer
+	remoteSigner RemoteSigner
+
+	// External node account signer
+	nodeSigner NodeSigner
+
+	// Watch-only node account address, used to export unsigned transactions without the wallet
+	watchOnlyAddress *common.Address
+
+	// Node account nonce allocator, created when a transactor first needs it
+	getNonceAllocator func() (NonceAllocator, error)
+
+	// Recorders for node account transactions which are simulated or exported instead of submitted
+	dryRunRecorder DryRunRecorder
+	exportRecorder TransactionRecorder
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 }
 
+// Remote validator signer interface
+type RemoteSigner interface {
+	GetSigner(pubkey rptypes.ValidatorPubkey) validator.Signer
+}
+
+// External node account signer interface
+type NodeSigner interface {
+	GetAddress() common.Address
+	GetUrl() string
+	SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Node account nonce allocator interface, shared by all processes signing with the node account
+// Transactions at allocated nonces are submitted through it, so nonces whose transactions aren't submitted are released
+type NonceAllocator interface {
+	AllocateNonce(ctx context.Context, from common.Address) (uint64, error)
+	ReleaseNonce(from common.Address, nonce uint64) error
+	SubmitTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// Transaction recorder interface, for node account transactions which are simulated or exported instead of submitted
+type TransactionRecorder interface {
+	Record(ctx context.Context, from common.Address, tx *types.Transaction) error
+}
+
+// Dry-run recorder interface, which also estimates gas for the transactions it simulates
+type DryRunRecorder interface {
+	TransactionRecorder
+	EstimateGas(ctx context.Context, from common.Address, tx *types.Transaction) (uint64, error)
+}
+
 // Encrypted wallet store
 type walletStore struct {
-	Crypto      map[string]interface{} `json:"crypto"`
-	Name        string                 `json:"name"`
-	Version     uint                   `json:"version"`
-	UUID        uuid.UUID              `json:"uuid"`
-	NextAccount uint                   `json:"next_account"`
+	Crypto       map[string]interface{} `json:"crypto"`
+	Name         string                 `json:"name"`
+	Version      uint                   `json:"version"`
+	UUID         uuid.UUID              `json:"uuid"`
+	NextAccount  uint                   `json:"next_account"`
+	ImportedKeys []importedKey          `json:"imported_keys,omitempty"`
+	NodeAccounts []nodeAccount          `json:"node_accounts,omitempty"`
 }
 
 // Create new wallet
@@ -82,8 +146,10 @@ func NewWallet(walletPath, chainIDStr string, maxFee *big.Int, maxPriorityFee *b
 		pm:                  passwordManager,
 		encryptor:           eth2ks.New(),
 		chainID:             chainID,
+		nodeAccountName:     DefaultNodeAccount,
 		validatorKeys:       map[uint]*eth2types.BLSPrivateKey{},
 		validatorKeyIndices: map[string]uint{},
+		importedKeys:        map[string]*eth2types.BLSPrivateKey{},
 		keystores:           map[string]keystore.Keystore{},
 		maxFee:              maxFee,
 		maxPriorityFee:      maxPriorityFee,
@@ -106,11 +172,70 @@ func (w *Wallet) GetChainID() *big.Int {
 	return copy
 }
 
+// Sets the desired gas price & limit used by node account transactors
+func (w *Wallet) SetGasSettings(maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64) {
+	w.maxFee = maxFee
+	w.maxPriorityFee = maxPriorityFee
+	w.gasLimit = gasLimit
+}
+
 // Add a keystore to the wallet
 func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Set the remote signer used to sign validator messages
+func (w *Wallet) SetRemoteSigner(rs RemoteSigner) {
+	w.remoteSigner = rs
+}
+
+// Set the external signer used to sign node account transactions
+func (w *Wallet) SetNodeSigner(ns NodeSigner) {
+	w.nodeSigner = ns
+}
+
+// Set the node account address used to export unsigned transactions when the wallet isn't initialized
+func (w *Wallet) SetWatchOnlyNodeAddress(address common.Address) {
+	w.watchOnlyAddress = &address
+}
+
+// Set the allocator used to assign nonces to node account transactions
+func (w *Wallet) SetNonceAllocator(na NonceAllocator) {
+	w.getNonceAllocator = func() (NonceAllocator, error) {
+		return na, nil
+	}
+}
+
+// Set a function creating the allocator used to assign nonces to node account transactions
+// It's only called when a node account transactor is created, so wallet operations which don't submit transactions don't depend on it
+func (w *Wallet) SetNonceAllocatorGetter(getNonceAllocator func() (NonceAllocator, error)) {
+	w.getNonceAllocator = getNonceAllocator
+}
+
+// Get a copy of the wallet whose node account transactions are recorded by the dry-run recorder instead of being submitted
+func (w *Wallet) ForDryRun(dr DryRunRecorder) *Wallet {
+	dw := *w
+	dw.dryRunRecorder = dr
+	return &dw
+}
+
+// Get a copy of the wallet whose node account transactions are recorded unsigned by the export recorder, to be signed on another machine
+func (w *Wallet) ForUnsignedExport(er TransactionRecorder) *Wallet {
+	ew := *w
+	ew.exportRecorder = er
+	return &ew
+}
+
+// Check if node account transactions are signed by an external signer
+func (w *Wallet) HasExternalNodeSigner() bool {
+	return (w.nodeSigner != nil)
+}
+
+// Check if unsigned transactions are exported for the watch-only node address, without the wallet
+func (w *Wallet) isWatchOnly() bool {
+	return (w.exportRecorder != nil && w.watchOnlyAddress != nil && !w.IsInitialized())
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -196,6 +321,12 @@ func (w *Wallet) Recover(mnemonic string) error {
 
 }
 
+// Get the path of the wallet store backups
+// Backups hold the wallet seed encrypted with the password at the time, so they are removed when it changes
+func (w *Wallet) getBackupPath() string {
+	return filepath.Join(filepath.Dir(w.walletPath), BackupsDir, filepath.Base(w.walletPath))
+}
+
 // Save the wallet store to disk
 func (w *Wallet) Save() error {
 
@@ -210,8 +341,13 @@ func (w *Wallet) Save() error {
 		return fmt.Errorf("Could not encode wallet: %w", err)
 	}
 
+	// Back up the existing wallet store
+	if err := files.Backup(w.walletPath, w.getBackupPath(), MaxBackups); err != nil {
+		return fmt.Errorf("Could not back up wallet: %w", err)
+	}
+
 	// Write wallet store to disk
-	if err := ioutil.WriteFile(w.walletPath, wsBytes, FileMode); err != nil {
+	if err := files.WriteFile(w.walletPath, wsBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write wallet to disk: %w", err)
 	}
 
@@ -220,6 +356,42 @@ func (w *Wallet) Save() error {
 
 }
 
+// Check that the wallet store on disk decrypts to the loaded wallet seed
+func (w *Wallet) Verify() error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+
+	// Read & decode wallet store
+	wsBytes, err := ioutil.ReadFile(w.walletPath)
+	if err != nil {
+		return fmt.Errorf("Could not read wallet from disk: %w", err)
+	}
+	ws := new(walletStore)
+	if err := json.Unmarshal(wsBytes, ws); err != nil {
+		return fmt.Errorf("Could not decode wallet: %w", err)
+	}
+
+	// Decrypt seed
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	seed, err := w.encryptor.Decrypt(ws.Crypto, password)
+	if err != nil {
+		return fmt.Errorf("Could not decrypt wallet seed: %w", err)
+	}
+	if !bytes.Equal(seed, w.seed) {
+		return errors.New("The wallet seed on disk does not match the loaded wallet")
+	}
+
+	// Return
+	return nil
+
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -235,6 +407,11 @@ func (w *Wallet) loadStore() (bool, error) {
 		return false, nil
 	}
 
+	// Cancel if the password is not available yet, e.g. the wallet is waiting to be unlocked
+	if !w.pm.IsPasswordSet() {
+		return false, nil
+	}
+
 	// Decode wallet store
 	w.ws = new(walletStore)
 	if err = json.Unmarshal(wsBytes, w.ws); err != nil {
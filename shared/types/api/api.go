@@ -1,6 +1,59 @@
 package api
 
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
 type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// A signed transaction which was simulated instead of being submitted in dry-run mode
+type DryRunTransaction struct {
+	Hash              common.Hash     `json:"hash"`
+	RawTx             hexutil.Bytes   `json:"rawTx"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	Nonce             uint64          `json:"nonce"`
+	GasLimit          uint64          `json:"gasLimit"`
+	MaxFeeWei         *big.Int        `json:"maxFee"`
+	MaxPriorityFeeWei *big.Int        `json:"maxPriorityFee"`
+	Value             *big.Int        `json:"value"`
+	Reverted          bool            `json:"reverted"`
+	RevertReason      string          `json:"revertReason"`
+}
+
+// A node account transaction exported unsigned, to be signed on another machine
+type UnsignedTransaction struct {
+	From              common.Address  `json:"from"`
+	ChainID           *big.Int        `json:"chainId"`
+	Nonce             uint64          `json:"nonce"`
+	To                *common.Address `json:"to"`
+	Value             *big.Int        `json:"value"`
+	Data              hexutil.Bytes   `json:"data"`
+	GasLimit          uint64          `json:"gasLimit"`
+	MaxFeeWei         *big.Int        `json:"maxFee"`
+	MaxPriorityFeeWei *big.Int        `json:"maxPriorityFee"`
+}
+
+// A node account transaction signed on another machine, to be broadcast
+type SignedTransaction struct {
+	Hash  common.Hash    `json:"hash"`
+	From  common.Address `json:"from"`
+	Nonce uint64         `json:"nonce"`
+	RawTx hexutil.Bytes  `json:"rawTx"`
+}
+
+// File of transactions exported with --export-unsigned, for 'rocketpool sign'
+type UnsignedTransactionsFile struct {
+	Transactions []UnsignedTransaction `json:"transactions"`
+}
+
+// File of transactions signed with 'rocketpool sign', for 'rocketpool broadcast'
+type SignedTransactionsFile struct {
+	Transactions []SignedTransaction `json:"transactions"`
+}
@@ -46,6 +46,27 @@ type ValidatorDetails struct {
 	NodeBalance *big.Int `json:"nodeBalance"`
 }
 
+type MinipoolPerformanceResponse struct {
+	Status     string                       `json:"status"`
+	Error      string                       `json:"error"`
+	Tracking   bool                         `json:"tracking"`
+	FirstEpoch uint64                       `json:"firstEpoch"`
+	LastEpoch  uint64                       `json:"lastEpoch"`
+	Minipools  []MinipoolPerformanceDetails `json:"minipools"`
+}
+type MinipoolPerformanceDetails struct {
+	Address                  common.Address        `json:"address"`
+	ValidatorPubkey          types.ValidatorPubkey `json:"validatorPubkey"`
+	ValidatorExists          bool                  `json:"validatorExists"`
+	ValidatorIndex           uint64                `json:"validatorIndex"`
+	Tracked                  bool                  `json:"tracked"`
+	AttestationsExpected     uint64                `json:"attestationsExpected"`
+	AttestationsMissed       uint64                `json:"attestationsMissed"`
+	AverageInclusionDistance float64               `json:"averageInclusionDistance"`
+	ProposalsExpected        uint64                `json:"proposalsExpected"`
+	ProposalsMissed          uint64                `json:"proposalsMissed"`
+}
+
 type CanRefundMinipoolResponse struct {
 	Status                    string             `json:"status"`
 	Error                     string             `json:"error"`
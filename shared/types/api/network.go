@@ -22,6 +22,18 @@ type RplPriceResponse struct {
 	MaxPerMinipoolRplStake *big.Int `json:"maxPerMinipoolRplStake"`
 }
 
+type GasPricesResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	Provider       string         `json:"provider"`
+	Tiers          []GasPriceTier `json:"tiers"`
+	PriorityFeeWei *big.Int       `json:"priorityFeeWei"`
+}
+type GasPriceTier struct {
+	Name      string   `json:"name"`
+	MaxFeeWei *big.Int `json:"maxFeeWei"`
+}
+
 type NetworkStatsResponse struct {
 	Status                    string  `json:"status"`
 	Error                     string  `json:"error"`
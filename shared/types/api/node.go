@@ -248,6 +248,26 @@ type NodeClaimRplResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type NodeRewardsHistoryResponse struct {
+	Status    string                   `json:"status"`
+	Error     string                   `json:"error"`
+	Period    string                   `json:"period"`
+	Minipools []MinipoolRewardsHistory `json:"minipools"`
+}
+type MinipoolRewardsHistory struct {
+	Address        common.Address         `json:"address"`
+	ValidatorIndex uint64                 `json:"validatorIndex"`
+	Periods        []RewardsHistoryPeriod `json:"periods"`
+}
+type RewardsHistoryPeriod struct {
+	Start        time.Time `json:"start"`
+	StartEpoch   uint64    `json:"startEpoch"`
+	EndEpoch     uint64    `json:"endEpoch"`
+	StartBalance float64   `json:"startBalance"`
+	EndBalance   float64   `json:"endBalance"`
+	Earned       float64   `json:"earned"`
+}
+
 type NodeRewardsResponse struct {
 	Status                      string        `json:"status"`
 	Error                       string        `json:"error"`
@@ -279,3 +299,34 @@ type DepositContractInfoResponse struct {
 	BeaconNetwork         uint64         `json:"beaconNetwork"`
 	SufficientSync        bool           `json:"sufficientSync"`
 }
+
+type PendingTransactionsResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	Transactions []PendingTransaction `json:"transactions"`
+}
+type PendingTransaction struct {
+	Hash              common.Hash     `json:"hash"`
+	OriginalHash      common.Hash     `json:"originalHash"`
+	Nonce             uint64          `json:"nonce"`
+	Task              string          `json:"task"`
+	To                *common.Address `json:"to"`
+	MaxFeeWei         *big.Int        `json:"maxFeeWei"`
+	MaxPriorityFeeWei *big.Int        `json:"maxPriorityFeeWei"`
+	Replacements      int             `json:"replacements"`
+	SubmittedTime     time.Time       `json:"submittedTime"`
+	Cancelled         bool            `json:"cancelled"`
+	MaxFeeReached     bool            `json:"maxFeeReached"`
+}
+
+type CancelTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type BroadcastTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
@@ -0,0 +1,20 @@
+package api
+
+// A request to run an API command on the API server
+type ServerRequest struct {
+	Args       []string `json:"args"`
+	MaxFee     float64  `json:"maxFee"`
+	MaxPrioFee float64  `json:"maxPrioFee"`
+	GasLimit   uint64   `json:"gasLimit"`
+	Nonce      string   `json:"nonce"`
+	Account    string   `json:"account"`
+	DryRun     bool     `json:"dryRun"`
+	Unsigned   bool     `json:"unsigned"`
+	Input      string   `json:"input,omitempty"`
+}
+
+type ServerVersionResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Version string `json:"version"`
+}
@@ -6,11 +6,25 @@ import (
 )
 
 type WalletStatusResponse struct {
-	Status            string         `json:"status"`
-	Error             string         `json:"error"`
-	PasswordSet       bool           `json:"passwordSet"`
-	WalletInitialized bool           `json:"walletInitialized"`
-	AccountAddress    common.Address `json:"accountAddress"`
+	Status                string                  `json:"status"`
+	Error                 string                  `json:"error"`
+	PasswordSet           bool                    `json:"passwordSet"`
+	WalletInitialized     bool                    `json:"walletInitialized"`
+	AccountName           string                  `json:"accountName"`
+	AccountAddress        common.Address          `json:"accountAddress"`
+	ImportedValidatorKeys []types.ValidatorPubkey `json:"importedValidatorKeys"`
+}
+
+type ChangePasswordResponse struct {
+	Status             string   `json:"status"`
+	Error              string   `json:"error"`
+	UnchangedKeystores []string `json:"unchangedKeystores"`
+}
+
+type UnlockWalletResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
 }
 
 type SetPasswordResponse struct {
@@ -26,10 +40,11 @@ type InitWalletResponse struct {
 }
 
 type RecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status                   string                  `json:"status"`
+	Error                    string                  `json:"error"`
+	AccountAddress           common.Address          `json:"accountAddress"`
+	ValidatorKeys            []types.ValidatorPubkey `json:"validatorKeys"`
+	UnrecoveredValidatorKeys []types.ValidatorPubkey `json:"unrecoveredValidatorKeys"`
 }
 
 type RebuildWalletResponse struct {
@@ -38,6 +53,40 @@ type RebuildWalletResponse struct {
 	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
 }
 
+type VerifyWalletResponse struct {
+	Status        string                  `json:"status"`
+	Error         string                  `json:"error"`
+	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+	Failures      []ValidatorKeyFailure   `json:"failures"`
+}
+type ValidatorKeyFailure struct {
+	Pubkey   types.ValidatorPubkey `json:"pubkey"`
+	Keystore string                `json:"keystore"`
+	Error    string                `json:"error"`
+}
+
+type ImportValidatorKeyResponse struct {
+	Status string                `json:"status"`
+	Error  string                `json:"error"`
+	Pubkey types.ValidatorPubkey `json:"pubkey"`
+}
+
+type NodeAccountsResponse struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error"`
+	Accounts []NodeAccount `json:"accounts"`
+}
+type NodeAccount struct {
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+}
+
+type AddNodeAccountResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
+}
+
 type ExportWalletResponse struct {
 	Status            string `json:"status"`
 	Error             string `json:"error"`
@@ -45,3 +94,28 @@ type ExportWalletResponse struct {
 	Wallet            string `json:"wallet"`
 	AccountPrivateKey string `json:"accountPrivateKey"`
 }
+
+type DeleteValidatorKeyResponse struct {
+	Status             string `json:"status"`
+	Error              string `json:"error"`
+	SlashingProtection string `json:"slashingProtection"`
+}
+
+type ExportSlashingProtectionResponse struct {
+	Status             string                  `json:"status"`
+	Error              string                  `json:"error"`
+	SlashingProtection string                  `json:"slashingProtection"`
+	ValidatorKeys      []types.ValidatorPubkey `json:"validatorKeys"`
+}
+
+type ImportSlashingProtectionResponse struct {
+	Status        string                  `json:"status"`
+	Error         string                  `json:"error"`
+	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+}
+
+type SignTransactionsResponse struct {
+	Status       string              `json:"status"`
+	Error        string              `json:"error"`
+	Transactions []SignedTransaction `json:"transactions"`
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// Source of data passed to API commands which is too large for their arguments
+var (
+	input     io.Reader = os.Stdin
+	inputLock sync.Mutex
+)
+
+// Set the source of API command input data and return the previous one
+// Used by the API server to supply request input instead of reading stdin
+func SetInput(r io.Reader) io.Reader {
+	inputLock.Lock()
+	defer inputLock.Unlock()
+	previous := input
+	input = r
+	return previous
+}
+
+// Read the input data of the current API command
+func ReadInput() ([]byte, error) {
+	inputLock.Lock()
+	defer inputLock.Unlock()
+	return ioutil.ReadAll(input)
+}
@@ -4,20 +4,92 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
+	"sync"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// Destination for printed API responses
+var (
+	output     io.Writer = os.Stdout
+	outputLock sync.Mutex
+)
+
+// Recorder for transactions simulated by the API command in dry-run mode
+type DryRunRecorder interface {
+	Take() []api.DryRunTransaction
+}
+
+// Dry-run recorder for the current API command
+var dryRunRecorder DryRunRecorder
+
+// Set the dry-run recorder whose transactions are included in API responses; nil disables dry-run mode
+func SetDryRun(dr DryRunRecorder) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	dryRunRecorder = dr
+}
+
+// Recorder for transactions exported unsigned by the API command
+type ExportRecorder interface {
+	Take() []api.UnsignedTransaction
+}
+
+// Unsigned transaction export recorder for the current API command
+var exportRecorder ExportRecorder
+
+// Set the export recorder whose transactions are included in API responses; nil disables unsigned transaction export
+func SetUnsignedExport(er ExportRecorder) {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	exportRecorder = er
+}
+
+// Set the destination for printed API responses and return the previous one
+// Used by the API server to capture responses instead of writing them to stdout
+func SetOutput(w io.Writer) io.Writer {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	previous := output
+	output = w
+	return previous
+}
+
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
+	responseBytes := EncodeResponse(response, responseError)
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	if dryRunRecorder != nil {
+		if txs := dryRunRecorder.Take(); len(txs) > 0 {
+			responseBytes = addResponseField(responseBytes, "dryRunTransactions", txs)
+		}
+	}
+	if exportRecorder != nil {
+		if txs := exportRecorder.Take(); len(txs) > 0 {
+			responseBytes = addResponseField(responseBytes, "unsignedTransactions", txs)
+		}
+	}
+	fmt.Fprintln(output, string(responseBytes))
+}
+
+// Print an API error response
+func PrintErrorResponse(err error) {
+	PrintResponse(&api.APIResponse{}, err)
+}
+
+// Encode an API response
+// response must be a pointer to a struct type with Error and Status string fields
+func EncodeResponse(response interface{}, responseError error) []byte {
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return EncodeErrorResponse(errors.New("Invalid API response"))
 	}
 
 	// Create zero response value if nil
@@ -30,8 +102,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return EncodeErrorResponse(errors.New("Invalid API response"))
 	}
 
 	// Populate error
@@ -49,16 +120,31 @@ func PrintResponse(response interface{}, responseError error) {
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
-		return
+		return EncodeErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
 	}
+	return responseBytes
 
-	// Print
-	fmt.Println(string(responseBytes))
+}
 
+// Encode an API error response
+func EncodeErrorResponse(err error) []byte {
+	return EncodeResponse(&api.APIResponse{}, err)
 }
 
-// Print an API error response
-func PrintErrorResponse(err error) {
-	PrintResponse(&api.APIResponse{}, err)
+// Add a field to an encoded API response
+func addResponseField(responseBytes []byte, name string, value interface{}) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return EncodeErrorResponse(fmt.Errorf("Could not decode API response: %w", err))
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return EncodeErrorResponse(fmt.Errorf("Could not encode API response field %s: %w", name, err))
+	}
+	response[name] = valueBytes
+	responseBytes, err = json.Marshal(response)
+	if err != nil {
+		return EncodeErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+	}
+	return responseBytes
 }
@@ -1,17 +1,19 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
-	"github.com/rocket-pool/rocketpool-go/utils"
-	"github.com/rocket-pool/rocketpool-go/utils/client"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -56,7 +58,9 @@ func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasTh
 }
 
 // Print a TX's details to the logger and waits for it to be mined.
-func PrintAndWaitForTransaction(config config.RocketPoolConfig, hash common.Hash, ec *client.EthClientProxy, logger log.ColorLogger) error {
+// The TX is tracked by the transaction manager, which replaces it with higher fees if it gets stuck.
+// If ctx is cancelled or the max fee is reached first, the TX is left pending for the node daemon to manage.
+func PrintAndWaitForTransaction(ctx context.Context, config config.RocketPoolConfig, hash common.Hash, txm *transactions.Manager, opts *bind.TransactOpts, task string, logger log.ColorLogger) error {
 
 	txWatchUrl := config.Smartnode.TxWatchUrl
 	hashString := hash.String()
@@ -68,10 +72,23 @@ func PrintAndWaitForTransaction(config config.RocketPoolConfig, hash common.Hash
 	}
 	logger.Println("Waiting for the transaction to be mined...")
 
-	// Wait for the TX to be mined
-	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
+	// Track the TX
+	tx, err := txm.Track(ctx, hash, task)
+	if err != nil {
+		return fmt.Errorf("Error tracking transaction: %w", err)
+	}
+
+	// Wait for the TX or one of its replacements to be mined
+	receipt, err := txm.Wait(ctx, tx, opts, logger)
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, transactions.ErrLeftPending) {
+			logger.Printlnf("Stopped waiting for transaction %s, it will be managed until it's mined.", tx.GetHash().String())
+		}
 		return fmt.Errorf("Error mining transaction: %w", err)
 	}
+	if receipt.TxHash != hash {
+		logger.Printlnf("Transaction was mined as replacement %s.", receipt.TxHash.String())
+	}
 
 	return nil
 
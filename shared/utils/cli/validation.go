@@ -9,10 +9,14 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 )
 
 // Config
@@ -71,6 +75,15 @@ func ValidateAddress(name, value string) (common.Address, error) {
 	return common.HexToAddress(value), nil
 }
 
+// Validate a validator pubkey
+func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
+	pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return pubkey, nil
+}
+
 // Validate a wei amount
 func ValidateWeiAmount(name, value string) (*big.Int, error) {
 	val := new(big.Int)
@@ -125,6 +138,15 @@ func ValidateProposalType(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate a rewards history period
+func ValidateRewardsPeriod(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "daily" || val == "weekly") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid periods are 'daily' and 'weekly'", name, value)
+	}
+	return val, nil
+}
+
 //
 // Command specific types
 //
@@ -226,6 +248,14 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 	return value, nil
 }
 
+// Validate a wallet mnemonic share
+func ValidateWalletMnemonicShare(name, value string) (string, error) {
+	if !shamir.IsMnemonicShareValid(value) {
+		return "", fmt.Errorf("Invalid %s '%s'", name, value)
+	}
+	return value, nil
+}
+
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
@@ -243,6 +273,15 @@ func ValidateDAOMemberID(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate a node account name
+func ValidateNodeAccountName(name, value string) (string, error) {
+	val := strings.TrimSpace(value)
+	if !regexp.MustCompile("^[A-Za-z0-9_-]+$").MatchString(val) {
+		return "", fmt.Errorf("Invalid %s '%s' - must only contain letters, numbers, dashes and underscores", name, val)
+	}
+	return val, nil
+}
+
 // Validate a transaction hash
 func ValidateTxHash(name, value string) (common.Hash, error) {
 
@@ -266,3 +305,16 @@ func ValidateTxHash(name, value string) (common.Hash, error) {
 	return hash, nil
 
 }
+
+// Validate a signed, RLP-encoded transaction
+func ValidateRawTransaction(name, value string) (*ethtypes.Transaction, error) {
+	bytes, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(bytes); err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"os"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// Config
+const (
+	OpenAttempts   = 10
+	OpenRetryDelay = 500 * time.Millisecond
+)
+
+// Open a LevelDB database, retrying while it's locked by another process
+// Databases shared by the daemons & API should only be held open while they're used
+// Returns nil when opening read-only if the database doesn't exist yet
+func OpenLevelDB(path string, readOnly bool) (*leveldb.DB, error) {
+	if readOnly {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+	var err error
+	for attempt := 0; attempt < OpenAttempts; attempt++ {
+		var db *leveldb.DB
+		db, err = leveldb.OpenFile(path, &opt.Options{ReadOnly: readOnly})
+		if err == nil {
+			return db, nil
+		}
+		time.Sleep(OpenRetryDelay)
+	}
+	return nil, err
+}
@@ -2,10 +2,16 @@ package eth1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/client"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/urfave/cli"
 )
@@ -55,3 +61,87 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 	return nil
 
 }
+
+// Send ETH to an address
+// Like eth.SendTransaction, but the transaction is only signed if the transactor is set not to send it
+func SendTransaction(ec *client.EthClientProxy, toAddress common.Address, chainID *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+
+	// Get from address nonce
+	var nonce uint64
+	if opts.Nonce == nil {
+		var err error
+		nonce, err = ec.PendingNonceAt(context.Background(), opts.From)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("Could not get next available nonce: %w", err)
+		}
+	} else {
+		nonce = opts.Nonce.Uint64()
+	}
+
+	// Set default value
+	value := opts.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	// Estimate gas limit
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		var err error
+		gasLimit, err = ec.EstimateGas(context.Background(), ethereum.CallMsg{
+			From:     opts.From,
+			To:       &toAddress,
+			GasPrice: big.NewInt(0), // use 0 gwei for simulation
+			Value:    value,
+		})
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("Could not estimate gas limit: %w", err)
+		}
+	}
+
+	// Sign transaction
+	signedTx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      nonce,
+		GasTipCap:  opts.GasTipCap,
+		GasFeeCap:  opts.GasFeeCap,
+		Gas:        gasLimit,
+		To:         &toAddress,
+		Value:      value,
+		Data:       []byte{},
+		AccessList: []types.AccessTuple{},
+	}))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if opts.NoSend {
+		return signedTx.Hash(), nil
+	}
+
+	// Send transaction
+	if err := ec.SendTransaction(context.Background(), signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+
+}
+
+// Wait for a transaction which the next transaction depends on to be mined
+// In dry-run mode it may have only been simulated, so it must already be mined for the next transaction to be simulated against its changes
+func WaitForDependency(c *cli.Context, ec *client.EthClientProxy, hash common.Hash) error {
+	if !c.GlobalBool("dry-run") {
+		_, err := utils.WaitForTransaction(ec, hash)
+		return err
+	}
+	receipt, err := ec.TransactionReceipt(context.Background(), hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("Transaction %s has not been mined, so the transactions depending on it can't be simulated", hash.Hex())
+	}
+	if err != nil {
+		return fmt.Errorf("Could not get receipt of transaction %s: %w", hash.Hex(), err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return fmt.Errorf("Transaction %s failed, so the transactions depending on it can't be simulated", hash.Hex())
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package eth2
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -31,10 +32,10 @@ func EpochAt(config beacon.Eth2Config, time uint64) uint64 {
 }
 
 // Get the balances of the minipools on the beacon chain
-func GetBeaconBalances(rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, beaconHead beacon.BeaconHead, opts *bind.CallOpts) ([]minipoolBalanceDetails, error) {
+func GetBeaconBalances(ctx context.Context, rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, beaconHead beacon.BeaconHead, opts *bind.CallOpts) ([]minipoolBalanceDetails, error) {
 
 	// Get minipool validator statuses
-	validators, err := rputils.GetMinipoolValidators(rp, bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: beaconHead.Epoch})
+	validators, err := rputils.GetMinipoolValidators(ctx, rp, bc, addresses, opts, &beacon.ValidatorStatusOptions{Epoch: beaconHead.Epoch})
 	if err != nil {
 		return []minipoolBalanceDetails{}, err
 	}
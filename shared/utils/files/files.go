@@ -0,0 +1,136 @@
+package files
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Config
+const (
+	BackupDirMode         = 0700
+	BackupTimestampFormat = "20060102T150405.000000000Z"
+)
+
+// Write a file atomically
+// The data is written to a temporary file in the same folder, synced to disk and renamed over the target
+func WriteFile(path string, data []byte, mode os.FileMode) error {
+
+	// Create temporary file
+	dir := filepath.Dir(path)
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	// Write & sync data
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// Replace target file
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	// Sync folder so the rename is persisted
+	return syncDir(dir)
+
+}
+
+// Copy a file to a timestamped backup at backupPath, keeping only the most recent backups
+// Backups hold the file's previous contents, including any secrets they were encrypted with
+// Does nothing if the file does not exist
+func Backup(path, backupPath string, keep int) error {
+
+	// Read file
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Write backup
+	if err := os.MkdirAll(filepath.Dir(backupPath), BackupDirMode); err != nil {
+		return fmt.Errorf("Could not create backup folder: %w", err)
+	}
+	timestamp := time.Now().UTC().Format(BackupTimestampFormat)
+	if err := WriteFile(backupPath+"."+timestamp, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("Could not write backup: %w", err)
+	}
+
+	// Remove old backups
+	backups, err := GetBackups(backupPath)
+	if err != nil {
+		return err
+	}
+	for bi := 0; bi < len(backups)-keep; bi++ {
+		if err := os.Remove(backups[bi]); err != nil {
+			return fmt.Errorf("Could not remove old backup: %w", err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the backups of a file, oldest first
+func GetBackups(backupPath string) ([]string, error) {
+	backups, err := filepath.Glob(backupPath + ".*")
+	if err != nil {
+		return []string{}, fmt.Errorf("Could not list backups: %w", err)
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// Remove all backups of a file
+func RemoveBackups(backupPath string) error {
+	backups, err := GetBackups(backupPath)
+	if err != nil {
+		return err
+	}
+	for _, backup := range backups {
+		if err := os.Remove(backup); err != nil {
+			return fmt.Errorf("Could not remove backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// Sync a folder to disk
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && !os.IsPermission(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package files
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAndBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wallet")
+	backupPath := filepath.Join(dir, "backups", "wallet")
+	for _, content := range []string{"one", "two", "three", "four"} {
+		if err := Backup(path, backupPath, 2); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "four" {
+		t.Errorf("Incorrect file content %q: %v", data, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Incorrect file mode: %v", err)
+	}
+
+	backups, err := GetBackups(backupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %d", len(backups))
+	}
+	if data, err := ioutil.ReadFile(backups[1]); err != nil || string(data) != "three" {
+		t.Errorf("Incorrect latest backup content %q: %v", data, err)
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected temporary files to be removed, found %d entries", len(entries))
+	}
+}
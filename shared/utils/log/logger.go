@@ -22,6 +22,19 @@ func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 	}
 }
 
+// Get a copy of the logger which prefixes each message, e.g. with the node account a task runs for
+func (l ColorLogger) WithPrefix(prefix string) ColorLogger {
+	sprintFunc := l.sprintFunc
+	sprintfFunc := l.sprintfFunc
+	l.sprintFunc = func(a ...interface{}) string {
+		return sprintFunc(append([]interface{}{prefix, " "}, a...)...)
+	}
+	l.sprintfFunc = func(format string, a ...interface{}) string {
+		return sprintfFunc("%s "+format, append([]interface{}{prefix}, a...)...)
+	}
+	return l
+}
+
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
 	log.Print(l.sprintFunc(v...))
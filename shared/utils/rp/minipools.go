@@ -2,6 +2,7 @@ package rp
 
 import (
 	"bytes"
+	"context"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,7 +18,7 @@ import (
 const MinipoolPubkeyBatchSize = 50
 
 // Get minipool validator statuses
-func GetMinipoolValidators(rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, callOpts *bind.CallOpts, validatorStatusOpts *beacon.ValidatorStatusOptions) (map[common.Address]beacon.ValidatorStatus, error) {
+func GetMinipoolValidators(ctx context.Context, rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, callOpts *bind.CallOpts, validatorStatusOpts *beacon.ValidatorStatusOptions) (map[common.Address]beacon.ValidatorStatus, error) {
 
 	// Load minipool validator pubkeys in batches
 	pubkeys := make([]types.ValidatorPubkey, len(addresses))
@@ -69,7 +70,7 @@ func GetMinipoolValidators(rp *rocketpool.RocketPool, bc beacon.Client, addresse
 	}
 
 	// Get validator statuses
-	statuses, err := bc.GetValidatorStatuses(filteredPubkeys, validatorStatusOpts)
+	statuses, err := bc.GetValidatorStatuses(ctx, filteredPubkeys, validatorStatusOpts)
 	if err != nil {
 		return map[common.Address]beacon.ValidatorStatus{}, err
 	}
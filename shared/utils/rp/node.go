@@ -13,9 +13,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 )
 
-func GetNodeValidatorIndices(rp *rocketpool.RocketPool, ec *client.EthClientProxy, bc beacon.Client, nodeAddress common.Address) ([]uint64, error) {
+func GetNodeValidatorIndices(ctx context.Context, rp *rocketpool.RocketPool, ec *client.EthClientProxy, bc beacon.Client, nodeAddress common.Address) ([]uint64, error) {
 	// Get current block number so all subsequent queries are done at same point in time
-	blockNumber, err := ec.BlockNumber(context.Background())
+	blockNumber, err := ec.BlockNumber(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting block number: %w", err)
 	}
@@ -31,18 +31,17 @@ func GetNodeValidatorIndices(rp *rocketpool.RocketPool, ec *client.EthClientProx
 	}
 
 	// Get validator statuses by pubkeys
-	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	statuses, err := bc.GetValidatorStatuses(ctx, pubkeys, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting validator statuses: %w", err)
 	}
 
-	// Enumerate validators statuses and fill indices array
-	validatorIndices := make([]uint64, len(statuses)+1)
-
-	i := 0
+	// Enumerate validators statuses and fill indices array, skipping validators not yet on the beacon chain
+	validatorIndices := make([]uint64, 0, len(statuses))
 	for _, status := range statuses {
-		validatorIndices[i] = status.Index
-		i++
+		if status.Exists {
+			validatorIndices = append(validatorIndices, status.Index)
+		}
 	}
 
 	return validatorIndices, nil
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	EventResubscribeDelay    = 5 * time.Second
+	MaxEventResubscribeDelay = 2 * time.Minute
+	EventIdleTimeout         = 15 * time.Minute
+)
+
+// Triggers task runs from beacon events, sharing a single event subscription between tasks
+type EventTrigger struct {
+	triggers            map[string][]chan struct{}
+	resubscribeDelay    time.Duration
+	maxResubscribeDelay time.Duration
+	idleTimeout         time.Duration
+	lock                sync.Mutex
+}
+
+// Create new event trigger
+func NewEventTrigger() *EventTrigger {
+	return &EventTrigger{
+		triggers:            make(map[string][]chan struct{}),
+		resubscribeDelay:    EventResubscribeDelay,
+		maxResubscribeDelay: MaxEventResubscribeDelay,
+		idleTimeout:         EventIdleTimeout,
+	}
+}
+
+// Get a task trigger which fires on any of the given event topics
+// Events received while a trigger is pending are merged into it
+func (t *EventTrigger) Subscribe(topics ...string) <-chan struct{} {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	trigger := make(chan struct{}, 1)
+	for _, topic := range topics {
+		t.triggers[topic] = append(t.triggers[topic], trigger)
+	}
+	return trigger
+}
+
+// Subscribe to beacon events and fire task triggers until ctx is cancelled
+// The subscription is retried with backoff while the beacon client can't subscribe, and renewed if its stream ends or goes idle
+// Tasks keep running on their intervals while there is no subscription
+func (t *EventTrigger) Run(ctx context.Context, bc beacon.Client, errorLog log.ColorLogger) {
+
+	// Get subscribed topics
+	t.lock.Lock()
+	topics := make([]string, 0, len(t.triggers))
+	for topic := range t.triggers {
+		topics = append(topics, topic)
+	}
+	t.lock.Unlock()
+	if len(topics) == 0 {
+		return
+	}
+
+	delay := t.resubscribeDelay
+	for {
+
+		// Subscribe to events and fire triggers until the subscription ends
+		received, err := t.runSubscription(ctx, bc, topics)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			errorLog.Printlnf("Could not subscribe to beacon events, retrying in %s: %s", delay, err.Error())
+		} else {
+			errorLog.Printlnf("Beacon event subscription ended or went idle, resubscribing in %s...", delay)
+		}
+
+		// Back off until events are received again
+		if received {
+			delay = t.resubscribeDelay
+		}
+		if !sleep(ctx, delay) {
+			return
+		}
+		delay *= 2
+		if delay > t.maxResubscribeDelay {
+			delay = t.maxResubscribeDelay
+		}
+
+	}
+
+}
+
+// Subscribe to beacon events and fire task triggers until the stream ends, goes idle or ctx is cancelled
+// Returns whether any events were received
+func (t *EventTrigger) runSubscription(ctx context.Context, bc beacon.Client, topics []string) (bool, error) {
+
+	// Subscribe to events
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := bc.SubscribeEvents(subCtx, topics)
+	if err != nil {
+		return false, err
+	}
+
+	// Fire triggers
+	received := false
+	idleTimer := time.NewTimer(t.idleTimeout)
+	defer idleTimer.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return received, nil
+			}
+			received = true
+			t.fire(event.Topic)
+			if !idleTimer.Stop() {
+				<-idleTimer.C
+			}
+			idleTimer.Reset(t.idleTimeout)
+		case <-idleTimer.C:
+			return received, nil
+		case <-ctx.Done():
+			return received, nil
+		}
+	}
+
+}
+
+// Fire the task triggers for an event topic
+func (t *EventTrigger) fire(topic string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, trigger := range t.triggers[topic] {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+}
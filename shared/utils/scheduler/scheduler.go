@@ -0,0 +1,299 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Default timeout for grouped tasks, so a slow run doesn't block the rest of its group indefinitely
+const DefaultGroupTimeout = 15 * time.Minute
+
+// A task run periodically by the scheduler
+type Task struct {
+
+	// Name used in logs and to look up the task's config
+	Name string
+
+	// Name used to look up the task's config instead of Name, e.g. when a task runs once per node account
+	ConfigName string
+
+	// Task body; the context is cancelled when the task times out or is abandoned during shutdown
+	Run func(ctx context.Context) error
+
+	// Time between runs, plus a random amount of up to Jitter
+	Interval time.Duration
+	Jitter   time.Duration
+
+	// Delay before the first run
+	StartDelay time.Duration
+
+	// Runs taking longer than Timeout are cancelled and reported as failed; 0 disables the timeout
+	Timeout time.Duration
+
+	// Each consecutive failure doubles the time until the next run, up to MaxBackoff; 0 disables backoff
+	MaxBackoff time.Duration
+
+	// Stop running the task after its first successful run
+	RunOnce bool
+
+	// Run the task early whenever a value is received, e.g. from an EventTrigger; nil disables
+	Trigger <-chan struct{}
+
+	// Tasks in the same group never run at the same time, e.g. tasks submitting transactions from the same node account
+	// Grouped tasks time out after DefaultGroupTimeout unless Timeout is set
+	Group string
+}
+
+// Task scheduler
+type Scheduler struct {
+	tasks    []Task
+	groups   map[string]chan struct{}
+	errorLog log.ColorLogger
+}
+
+// Create new task scheduler
+func NewScheduler(errorLog log.ColorLogger) *Scheduler {
+	return &Scheduler{
+		tasks:    []Task{},
+		groups:   map[string]chan struct{}{},
+		errorLog: errorLog,
+	}
+}
+
+// Register a task, applying its config by name
+// Disabled tasks are not registered
+func (s *Scheduler) Register(task Task, taskConfigs map[string]config.TaskConfig) error {
+
+	// Apply defaults & config
+	if task.Group != "" && task.Timeout == 0 {
+		task.Timeout = DefaultGroupTimeout
+	}
+	configName := task.Name
+	if task.ConfigName != "" {
+		configName = task.ConfigName
+	}
+	if taskConfig, ok := taskConfigs[configName]; ok {
+		if taskConfig.Disabled {
+			return nil
+		}
+		if err := applyDuration(&task.Interval, taskConfig.Interval, task.Name, "interval"); err != nil {
+			return err
+		}
+		if err := applyDuration(&task.Jitter, taskConfig.Jitter, task.Name, "jitter"); err != nil {
+			return err
+		}
+		if err := applyDuration(&task.Timeout, taskConfig.Timeout, task.Name, "timeout"); err != nil {
+			return err
+		}
+		if err := applyDuration(&task.MaxBackoff, taskConfig.MaxBackoff, task.Name, "max backoff"); err != nil {
+			return err
+		}
+	}
+
+	// Check task
+	if task.Run == nil {
+		return fmt.Errorf("Task '%s' has no run function", task.Name)
+	}
+	if task.Interval <= 0 {
+		return fmt.Errorf("Task '%s' must have a positive interval", task.Name)
+	}
+
+	// Register
+	if task.Group != "" && s.groups[task.Group] == nil {
+		s.groups[task.Group] = make(chan struct{}, 1)
+	}
+	s.tasks = append(s.tasks, task)
+	return nil
+
+}
+
+// Get the names of the registered tasks
+func (s *Scheduler) GetTaskNames() []string {
+	names := make([]string, len(s.tasks))
+	for ti, task := range s.tasks {
+		names[ti] = task.Name
+	}
+	return names
+}
+
+// Run all registered tasks, each on its own schedule
+// Once ctx is cancelled no new runs are started, and runs still in progress after shutdownTimeout are cancelled
+// Blocks until every task has stopped
+func (s *Scheduler) Run(ctx context.Context, shutdownTimeout time.Duration) {
+
+	// Task runs use their own context so they can finish during shutdown
+	runCtx, cancelRuns := context.WithCancel(context.Background())
+	defer cancelRuns()
+
+	// Cancel runs still in progress once the shutdown timeout has elapsed
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+			return
+		}
+		select {
+		case <-time.After(shutdownTimeout):
+			s.errorLog.Printlnf("Tasks did not finish within %s, cancelling them...", shutdownTimeout)
+			cancelRuns()
+		case <-stopped:
+		}
+	}()
+
+	// Run tasks
+	wg := new(sync.WaitGroup)
+	wg.Add(len(s.tasks))
+	for _, task := range s.tasks {
+		go func(task Task) {
+			s.runTask(ctx, runCtx, task)
+			wg.Done()
+		}(task)
+	}
+	wg.Wait()
+	close(stopped)
+
+}
+
+// Run a task on its schedule until ctx is cancelled
+func (s *Scheduler) runTask(ctx context.Context, runCtx context.Context, task Task) {
+
+	if !sleep(ctx, task.StartDelay) {
+		return
+	}
+
+	failures := 0
+	for {
+
+		// Run the task and track consecutive failures
+		if err := s.runGrouped(ctx, runCtx, task); err != nil {
+			s.errorLog.Printlnf("Task '%s' failed: %s", task.Name, err.Error())
+			failures++
+		} else {
+			if task.RunOnce {
+				return
+			}
+			failures = 0
+		}
+
+		// Wait for the next run, ignoring triggers while backing off
+		trigger := task.Trigger
+		if failures > 0 {
+			trigger = nil
+		}
+		if !wait(ctx, getDelay(task, failures), trigger) {
+			return
+		}
+
+	}
+
+}
+
+// Run a task once, waiting for any other task in its group to finish first
+// The run is skipped if ctx is cancelled before it starts
+func (s *Scheduler) runGrouped(ctx context.Context, runCtx context.Context, task Task) error {
+	if lock := s.groups[task.Group]; lock != nil {
+		select {
+		case lock <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+		defer func() { <-lock }()
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return runOnce(runCtx, task)
+}
+
+// Run a task once, cancelling it if it exceeds its timeout
+func runOnce(ctx context.Context, task Task) error {
+
+	// Run without a timeout
+	if task.Timeout <= 0 {
+		return task.Run(ctx)
+	}
+
+	// Run with a timeout
+	timeoutCtx, cancel := context.WithTimeout(ctx, task.Timeout)
+	defer cancel()
+	err := task.Run(timeoutCtx)
+	if timeoutCtx.Err() == context.DeadlineExceeded {
+		if err != nil {
+			return fmt.Errorf("timed out after %s: %w", task.Timeout, err)
+		}
+		return fmt.Errorf("timed out after %s", task.Timeout)
+	}
+	return err
+
+}
+
+// Get the delay before the next run of a task
+func getDelay(task Task, failures int) time.Duration {
+
+	// Get the base interval, backing off on consecutive failures
+	delay := task.Interval
+	if failures > 0 && task.MaxBackoff > 0 {
+		for i := 0; i < failures && delay < task.MaxBackoff; i++ {
+			delay *= 2
+		}
+		if delay > task.MaxBackoff {
+			delay = task.MaxBackoff
+		}
+	}
+
+	// Add jitter
+	if task.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(task.Jitter)))
+	}
+
+	return delay
+
+}
+
+// Parse a duration string from a task config if set
+func applyDuration(value *time.Duration, setting string, taskName string, settingName string) error {
+	if setting == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(setting)
+	if err != nil {
+		return fmt.Errorf("Invalid %s '%s' for task '%s': %w", settingName, setting, taskName, err)
+	}
+	*value = duration
+	return nil
+}
+
+// Wait for a duration or until triggered, returning false if ctx is cancelled first
+func wait(ctx context.Context, duration time.Duration, trigger <-chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(duration):
+		return true
+	case <-trigger:
+		return true
+	}
+}
+
+// Sleep for a duration, returning false if ctx is cancelled first
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
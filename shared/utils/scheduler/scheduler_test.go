@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestRegisterAppliesConfig(t *testing.T) {
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	run := func(ctx context.Context) error { return nil }
+	taskConfigs := map[string]config.TaskConfig{
+		"disabled": {Disabled: true},
+		"custom":   {Interval: "1m", Jitter: "10s", Timeout: "30s"},
+	}
+
+	if err := s.Register(Task{Name: "disabled", Run: run, Interval: time.Minute}, taskConfigs); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Task{Name: "disabled@account", ConfigName: "disabled", Run: run, Interval: time.Minute}, taskConfigs); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Task{Name: "custom", Run: run, Interval: time.Hour}, taskConfigs); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Task{Name: "invalid", Run: run}, taskConfigs); err == nil {
+		t.Error("expected an error registering a task without an interval")
+	}
+
+	if names := s.GetTaskNames(); len(names) != 1 || names[0] != "custom" {
+		t.Fatalf("unexpected registered tasks %v", names)
+	}
+	task := s.tasks[0]
+	if task.Interval != time.Minute || task.Jitter != 10*time.Second || task.Timeout != 30*time.Second {
+		t.Errorf("config was not applied to task: %+v", task)
+	}
+}
+
+func TestGetDelayBacksOff(t *testing.T) {
+	task := Task{Interval: time.Minute, MaxBackoff: 5 * time.Minute}
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for failures, delay := range expected {
+		if actual := getDelay(task, failures); actual != delay {
+			t.Errorf("expected a delay of %s after %d failures, got %s", delay, failures, actual)
+		}
+	}
+}
+
+func TestRunOnceStopsAfterSuccess(t *testing.T) {
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	runs := 0
+	task := Task{
+		Name:       "once",
+		Interval:   time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		RunOnce:    true,
+		Run: func(ctx context.Context) error {
+			runs++
+			if runs < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		},
+	}
+	if err := s.Register(task, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.Run(context.Background(), time.Second)
+	if runs != 3 {
+		t.Errorf("expected 3 runs, got %d", runs)
+	}
+}
+
+func TestRunStopsOnShutdown(t *testing.T) {
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	cancelled := false
+	task := Task{
+		Name:     "blocking",
+		Interval: time.Hour,
+		Run: func(runCtx context.Context) error {
+			close(started)
+			<-runCtx.Done()
+			cancelled = true
+			return runCtx.Err()
+		},
+	}
+	if err := s.Register(task, nil); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		<-started
+		cancel()
+	}()
+	s.Run(ctx, 10*time.Millisecond)
+	if !cancelled {
+		t.Error("expected the in-progress run to be cancelled after the shutdown timeout")
+	}
+}
+
+func TestGroupedTasksRunSerially(t *testing.T) {
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	var lock sync.Mutex
+	running, overlaps := 0, 0
+	run := func(ctx context.Context) error {
+		lock.Lock()
+		running++
+		if running > 1 {
+			overlaps++
+		}
+		lock.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		if err := s.Register(Task{Name: name, Run: run, Interval: time.Millisecond, RunOnce: true, Group: "account"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Run(context.Background(), time.Second)
+	if overlaps != 0 {
+		t.Errorf("expected grouped tasks not to overlap, got %d overlapping runs", overlaps)
+	}
+}
+
+func TestGroupedTasksSkippedOnShutdown(t *testing.T) {
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	runs := 0
+	run := func(runCtx context.Context) error {
+		runs++
+		close(started)
+		<-runCtx.Done()
+		return nil
+	}
+	for _, name := range []string{"a", "b"} {
+		if err := s.Register(Task{Name: name, Run: run, Interval: time.Hour, Group: "account"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.tasks[0].Timeout != DefaultGroupTimeout {
+		t.Errorf("expected grouped tasks to time out after %s, got %s", DefaultGroupTimeout, s.tasks[0].Timeout)
+	}
+	go func() {
+		<-started
+		cancel()
+	}()
+	s.Run(ctx, 10*time.Millisecond)
+	if runs != 1 {
+		t.Errorf("expected the run queued behind the group to be skipped, got %d runs", runs)
+	}
+}
+
+// Beacon client whose first event subscription fails
+type fakeEventClient struct {
+	beacon.Client
+	subscriptions chan chan beacon.Event
+	attempts      int
+}
+
+func (f *fakeEventClient) SubscribeEvents(ctx context.Context, topics []string) (<-chan beacon.Event, error) {
+	f.attempts++
+	if f.attempts == 1 {
+		return nil, errors.New("not ready")
+	}
+	events := make(chan beacon.Event, 1)
+	f.subscriptions <- events
+	return events, nil
+}
+
+func TestEventTriggerRunsTasks(t *testing.T) {
+	trigger := NewEventTrigger()
+	trigger.resubscribeDelay = time.Millisecond
+	trigger.maxResubscribeDelay = time.Millisecond
+	s := NewScheduler(log.NewColorLogger(color.FgRed))
+	runs := make(chan struct{}, 10)
+	task := Task{
+		Name:     "triggered",
+		Interval: time.Hour,
+		Trigger:  trigger.Subscribe(beacon.EventHead),
+		Run: func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		},
+	}
+	if err := s.Register(task, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bc := &fakeEventClient{subscriptions: make(chan chan beacon.Event)}
+	go trigger.Run(ctx, bc, log.NewColorLogger(color.FgRed))
+	go s.Run(ctx, time.Second)
+	waitForRun := func(description string) {
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", description)
+		}
+	}
+	waitForRun("the first run")
+
+	// The failed subscription is retried, and events trigger runs
+	var events chan beacon.Event
+	select {
+	case events = <-bc.subscriptions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the subscription to be retried")
+	}
+	events <- beacon.Event{Topic: beacon.EventHead}
+	waitForRun("a triggered run")
+
+	// The subscription is renewed when its stream ends
+	close(events)
+	select {
+	case events = <-bc.subscriptions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the subscription to be renewed")
+	}
+	events <- beacon.Event{Topic: beacon.EventHead}
+	waitForRun("a run triggered after resubscribing")
+}
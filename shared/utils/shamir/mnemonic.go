@@ -0,0 +1,172 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Mnemonic share layout: set ID (2 bytes), threshold (1), index (1), share value, checksum (4)
+// Shares are encoded with the BIP-39 wordlist at 11 bits per word
+const (
+	shareHeaderLength   = 4
+	shareChecksumLength = 4
+	wordBits            = 11
+)
+
+// Split a BIP-39 mnemonic into mnemonic shares, any threshold of which recover the same mnemonic & seed
+func SplitMnemonic(mnemonic string, shares int, threshold int) ([]string, error) {
+
+	// Get mnemonic entropy
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid mnemonic: %w", err)
+	}
+
+	// Split entropy
+	entropyShares, err := Split(entropy, shares, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate set ID
+	id := make([]byte, 2)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("Could not generate share set ID: %w", err)
+	}
+
+	// Encode shares
+	result := make([]string, len(entropyShares))
+	for si, share := range entropyShares {
+		data := append([]byte{id[0], id[1], byte(threshold), share.Index}, share.Value...)
+		checksum := sha256.Sum256(data)
+		result[si] = encodeWords(append(data, checksum[:shareChecksumLength]...))
+	}
+
+	// Return
+	return result, nil
+
+}
+
+// Recover a BIP-39 mnemonic from mnemonic shares
+func CombineMnemonicShares(shares []string) (string, error) {
+
+	// Decode shares
+	var id []byte
+	var threshold int
+	entropyShares := []Share{}
+	for _, mnemonicShare := range shares {
+		shareID, shareThreshold, share, err := decodeMnemonicShare(mnemonicShare)
+		if err != nil {
+			return "", err
+		}
+		if id == nil {
+			id = shareID
+			threshold = shareThreshold
+		} else if !bytes.Equal(id, shareID) || threshold != shareThreshold {
+			return "", errors.New("Shares are not from the same mnemonic")
+		}
+		entropyShares = append(entropyShares, share)
+	}
+
+	// Check threshold
+	if len(entropyShares) < threshold {
+		return "", fmt.Errorf("%d shares are required to recover the mnemonic, but only %d were provided", threshold, len(entropyShares))
+	}
+
+	// Recover entropy & mnemonic
+	entropy, err := Combine(entropyShares[:threshold])
+	if err != nil {
+		return "", err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("Could not recover mnemonic from shares: %w", err)
+	}
+	return mnemonic, nil
+
+}
+
+// Get the number of shares required to recover the mnemonic a share belongs to
+func GetMnemonicShareThreshold(mnemonicShare string) (int, error) {
+	_, threshold, _, err := decodeMnemonicShare(mnemonicShare)
+	return threshold, err
+}
+
+// Check if a mnemonic share is valid
+func IsMnemonicShareValid(mnemonicShare string) bool {
+	_, _, _, err := decodeMnemonicShare(mnemonicShare)
+	return (err == nil)
+}
+
+// Decode a mnemonic share and check its checksum
+func decodeMnemonicShare(mnemonicShare string) ([]byte, int, Share, error) {
+
+	// Decode words
+	data, err := decodeWords(mnemonicShare)
+	if err != nil {
+		return nil, 0, Share{}, err
+	}
+	if len(data) <= shareHeaderLength+shareChecksumLength {
+		return nil, 0, Share{}, errors.New("Invalid share length")
+	}
+
+	// Check checksum
+	payload := data[:len(data)-shareChecksumLength]
+	checksum := sha256.Sum256(payload)
+	if !bytes.Equal(checksum[:shareChecksumLength], data[len(payload):]) {
+		return nil, 0, Share{}, errors.New("Invalid share checksum")
+	}
+
+	// Return
+	return payload[:2], int(payload[2]), Share{Index: payload[3], Value: payload[shareHeaderLength:]}, nil
+
+}
+
+// Encode data as words; the data length must be a multiple of 4 bytes, so it always fills whole words with padding
+func encodeWords(data []byte) string {
+	wordCount := len(data) * 3 / 4
+	value := new(big.Int).SetBytes(data)
+	value.Lsh(value, uint(wordCount*wordBits-len(data)*8))
+	wordList := bip39.GetWordList()
+	words := make([]string, wordCount)
+	mask := big.NewInt(1<<wordBits - 1)
+	for wi := wordCount - 1; wi >= 0; wi-- {
+		words[wi] = wordList[new(big.Int).And(value, mask).Int64()]
+		value.Rsh(value, wordBits)
+	}
+	return strings.Join(words, " ")
+}
+
+// Decode words into data
+func decodeWords(mnemonicShare string) ([]byte, error) {
+	words := strings.Fields(mnemonicShare)
+	if len(words) == 0 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("Invalid share word count %d", len(words))
+	}
+	value := new(big.Int)
+	for _, word := range words {
+		index, ok := bip39.GetWordIndex(word)
+		if !ok {
+			return nil, fmt.Errorf("Invalid share word '%s'", word)
+		}
+		value.Lsh(value, wordBits)
+		value.Or(value, big.NewInt(int64(index)))
+	}
+	dataLength := len(words) * 4 / 3
+	padding := uint(len(words)*wordBits - dataLength*8)
+	if new(big.Int).And(value, big.NewInt(1<<padding-1)).Sign() != 0 {
+		return nil, errors.New("Invalid share padding")
+	}
+	value.Rsh(value, padding)
+	valueBytes := value.Bytes()
+	data := make([]byte, dataLength)
+	copy(data[dataLength-len(valueBytes):], valueBytes)
+	return data, nil
+}
@@ -0,0 +1,145 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// Config
+const MaxShares = 16
+
+// A share of a secret, evaluated at x = Index
+type Share struct {
+	Index byte
+	Value []byte
+}
+
+// GF(2^8) log & exp tables, using the AES polynomial with generator 3
+var expTable [510]byte
+var logTable [256]byte
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		expTable[i+255] = x
+		logTable[x] = byte(i)
+		x = mul3(x)
+	}
+}
+
+// Split a secret into shares, any threshold of which can recover it
+func Split(secret []byte, shares int, threshold int) ([]Share, error) {
+
+	// Check parameters
+	if len(secret) == 0 {
+		return nil, errors.New("Cannot split an empty secret")
+	}
+	if err := ValidateThreshold(shares, threshold); err != nil {
+		return nil, err
+	}
+
+	// Create shares
+	result := make([]Share, shares)
+	for si := range result {
+		result[si] = Share{Index: byte(si + 1), Value: make([]byte, len(secret))}
+	}
+
+	// Split each byte with a random polynomial of degree threshold - 1
+	coefficients := make([]byte, threshold)
+	for bi, b := range secret {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("Could not generate share polynomial: %w", err)
+		}
+		for si := range result {
+			result[si].Value[bi] = evaluate(coefficients, result[si].Index)
+		}
+	}
+
+	// Return
+	return result, nil
+
+}
+
+// Check a secret can be split into shares with the threshold
+func ValidateThreshold(shares int, threshold int) error {
+	if threshold < 2 || threshold > shares || shares > MaxShares {
+		return fmt.Errorf("Invalid threshold %d of %d shares - must be between 2 and the number of shares, with at most %d shares", threshold, shares, MaxShares)
+	}
+	return nil
+}
+
+// Recover a secret from shares
+func Combine(shares []Share) ([]byte, error) {
+
+	// Check shares
+	if len(shares) == 0 {
+		return nil, errors.New("No shares provided")
+	}
+	indices := map[byte]bool{}
+	for _, share := range shares {
+		if share.Index == 0 {
+			return nil, errors.New("Invalid share index 0")
+		}
+		if indices[share.Index] {
+			return nil, fmt.Errorf("Share %d was provided more than once", share.Index)
+		}
+		if len(share.Value) != len(shares[0].Value) {
+			return nil, errors.New("Shares have different lengths")
+		}
+		indices[share.Index] = true
+	}
+
+	// Interpolate each byte at x = 0
+	secret := make([]byte, len(shares[0].Value))
+	for bi := range secret {
+		var value byte
+		for i, si := range shares {
+			basis := byte(1)
+			for j, sj := range shares {
+				if i == j {
+					continue
+				}
+				basis = mul(basis, div(sj.Index, sj.Index^si.Index))
+			}
+			value ^= mul(si.Value[bi], basis)
+		}
+		secret[bi] = value
+	}
+
+	// Return
+	return secret, nil
+
+}
+
+// Evaluate a polynomial at x
+func evaluate(coefficients []byte, x byte) byte {
+	var result byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = mul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// GF(2^8) arithmetic
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+255-int(logTable[b])]
+}
+func mul3(x byte) byte {
+	x2 := x << 1
+	if x&0x80 != 0 {
+		x2 ^= 0x1b
+	}
+	return x2 ^ x
+}
@@ -0,0 +1,77 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("a secret which must be split up")
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Any threshold of shares recovers the secret
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		selected := []Share{}
+		for _, si := range subset {
+			selected = append(selected, shares[si])
+		}
+		recovered, err := Combine(selected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(recovered, secret) {
+			t.Errorf("Shares %v did not recover the secret", subset)
+		}
+	}
+
+	// Fewer shares do not
+	if recovered, err := Combine(shares[:2]); err == nil && bytes.Equal(recovered, secret) {
+		t.Error("Expected 2 shares not to recover the secret")
+	}
+}
+
+func TestMnemonicShares(t *testing.T) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := SplitMnemonic(mnemonic, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, share := range shares {
+		if !IsMnemonicShareValid(share) {
+			t.Fatalf("Share '%s' is not valid", share)
+		}
+	}
+
+	// Threshold shares recover the mnemonic and its seed
+	recovered, err := CombineMnemonicShares([]string{shares[2], shares[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered != mnemonic || !bytes.Equal(bip39.NewSeed(recovered, ""), bip39.NewSeed(mnemonic, "")) {
+		t.Error("Shares did not recover the mnemonic")
+	}
+	if _, err := CombineMnemonicShares(shares[:1]); err == nil {
+		t.Error("Expected recovering from too few shares to fail")
+	}
+
+	// Shares from different mnemonics can't be combined
+	otherShares, err := SplitMnemonic(mnemonic, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CombineMnemonicShares([]string{shares[0], otherShares[1]}); err == nil {
+		t.Error("Expected combining shares of different splits to fail")
+	}
+}
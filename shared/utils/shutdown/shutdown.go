@@ -0,0 +1,31 @@
+package shutdown
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Get a context which is cancelled when the process receives an interrupt or termination signal
+// A second signal exits the process immediately
+func Context(logger log.ColorLogger) context.Context {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signals
+		logger.Printlnf("Received %s signal, shutting down...", sig.String())
+		cancel()
+		sig = <-signals
+		logger.Printlnf("Received %s signal again, exiting immediately.", sig.String())
+		os.Exit(1)
+	}()
+
+	return ctx
+
+}
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -11,12 +13,12 @@ import (
 // Deposit settings
 const DepositAmount = 16000000000 // gwei
 
-// Get deposit data & root for a given validator key and withdrawal credentials
-func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) (eth2.DepositData, common.Hash, error) {
+// Get deposit data & root for a given validator signer and withdrawal credentials
+func GetDepositData(signer Signer, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config) (eth2.DepositData, common.Hash, error) {
 
 	// Build deposit data
 	dd := eth2.DepositDataNoSignature{
-		PublicKey:             validatorKey.PublicKey().Marshal(),
+		PublicKey:             signer.GetPubkey().Bytes(),
 		WithdrawalCredentials: withdrawalCredentials[:],
 		Amount:                DepositAmount,
 	}
@@ -38,12 +40,18 @@ func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials
 		return eth2.DepositData{}, common.Hash{}, err
 	}
 
+	// Sign deposit data
+	signature, err := signer.SignDeposit(dd, srHash[:], eth2Config.GenesisForkVersion)
+	if err != nil {
+		return eth2.DepositData{}, common.Hash{}, fmt.Errorf("Could not sign deposit data: %w", err)
+	}
+
 	// Build deposit data struct (with signature)
 	var depositData = eth2.DepositData{
 		PublicKey:             dd.PublicKey,
 		WithdrawalCredentials: dd.WithdrawalCredentials,
 		Amount:                dd.Amount,
-		Signature:             validatorKey.Sign(srHash[:]).Marshal(),
+		Signature:             signature,
 	}
 
 	// Get deposit data root
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/eth2"
+)
+
+// Fork info used to compute signature domains
+type ForkInfo struct {
+	Fork                  beacon.Fork
+	GenesisValidatorsRoot []byte
+}
+
+// Validator message signer
+// Remote signers require the full message as well as the signing root, so each message type has its own method
+type Signer interface {
+	GetPubkey() rptypes.ValidatorPubkey
+	SignDeposit(depositData eth2.DepositDataNoSignature, signingRoot []byte, genesisForkVersion []byte) ([]byte, error)
+	SignVoluntaryExit(exitMessage eth2.VoluntaryExit, signingRoot []byte, forkInfo ForkInfo) ([]byte, error)
+}
+
+// Signs validator messages with a local validator key
+type LocalSigner struct {
+	key *eth2types.BLSPrivateKey
+}
+
+// Create new local signer
+func NewLocalSigner(key *eth2types.BLSPrivateKey) *LocalSigner {
+	return &LocalSigner{key: key}
+}
+
+// Get the validator pubkey
+func (s *LocalSigner) GetPubkey() rptypes.ValidatorPubkey {
+	return rptypes.BytesToValidatorPubkey(s.key.PublicKey().Marshal())
+}
+
+// Sign deposit data
+func (s *LocalSigner) SignDeposit(depositData eth2.DepositDataNoSignature, signingRoot []byte, genesisForkVersion []byte) ([]byte, error) {
+	return s.key.Sign(signingRoot).Marshal(), nil
+}
+
+// Sign a voluntary exit message
+func (s *LocalSigner) SignVoluntaryExit(exitMessage eth2.VoluntaryExit, signingRoot []byte, forkInfo ForkInfo) ([]byte, error) {
+	return s.key.Sign(signingRoot).Marshal(), nil
+}
+
+// Get the signature domain for a domain type at a given epoch
+func (f ForkInfo) GetDomain(domainType eth2types.DomainType, epoch uint64) []byte {
+	forkVersion := f.Fork.CurrentVersion
+	if epoch < f.Fork.Epoch {
+		forkVersion = f.Fork.PreviousVersion
+	}
+	return eth2types.Domain(domainType, forkVersion, f.GenesisValidatorsRoot)
+}
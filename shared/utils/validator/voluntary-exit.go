@@ -1,13 +1,15 @@
 package validator
 
 import (
+	"fmt"
+
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
-// Get a voluntary exit message signature for a given validator key and index
-func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, error) {
+// Get a voluntary exit message signature for a given validator signer and index
+func GetSignedExitMessage(signer Signer, validatorIndex uint64, epoch uint64, forkInfo ForkInfo) (types.ValidatorSignature, error) {
 
 	// Build voluntary exit message
 	exitMessage := eth2.VoluntaryExit{
@@ -24,7 +26,7 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	// Get signing root
 	sr := eth2.SigningRoot{
 		ObjectRoot: or[:],
-		Domain:     signatureDomain,
+		Domain:     forkInfo.GetDomain(eth2types.DomainVoluntaryExit, epoch),
 	}
 
 	srHash, err := sr.HashTreeRoot()
@@ -33,7 +35,10 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	}
 
 	// Sign message
-	signature := validatorKey.Sign(srHash[:]).Marshal()
+	signature, err := signer.SignVoluntaryExit(exitMessage, srHash[:], forkInfo)
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not sign voluntary exit message: %w", err)
+	}
 
 	// Return
 	return types.BytesToValidatorSignature(signature), nil